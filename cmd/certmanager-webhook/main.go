@@ -0,0 +1,154 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/gardener/external-dns-management/pkg/certmanager/solver"
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned"
+
+	_ "go.uber.org/automaxprocs"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+)
+
+var Version string
+
+const (
+	authConfigMapNamespace = "kube-system"
+	authConfigMapName      = "extension-apiserver-authentication"
+)
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "version" {
+		fmt.Println(Version)
+		os.Exit(0)
+	}
+
+	var (
+		kubeconfig   string
+		port         int
+		certFile     string
+		keyFile      string
+		groupName    string
+		solverName   string
+		clientCAFile string
+		clusterResNs string
+	)
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "kubeconfig of the cluster for the challenge DNSEntries (default: in-cluster configuration)")
+	flag.IntVar(&port, "secure-port", 8443, "HTTPS port of the solver API")
+	flag.StringVar(&certFile, "tls-cert-file", "", "file containing the TLS server certificate")
+	flag.StringVar(&keyFile, "tls-private-key-file", "", "file containing the TLS server key")
+	flag.StringVar(&groupName, "group-name", os.Getenv("GROUP_NAME"), "API group name of the solver as configured in the issuers (default: from env GROUP_NAME)")
+	flag.StringVar(&solverName, "solver-name", "gardener-dns", "solver name as configured in the issuers")
+	flag.StringVar(&clientCAFile, "requestheader-client-ca-file", "", "CA file to verify the client certificate of the kube-apiserver (default: from configmap kube-system/extension-apiserver-authentication)")
+	flag.StringVar(&clusterResNs, "cluster-resource-namespace", "kube-system", "cluster resource namespace of cert-manager used for challenges of ClusterIssuers")
+	flag.Parse()
+
+	if err := run(kubeconfig, port, certFile, keyFile, groupName, solverName, clientCAFile, clusterResNs); err != nil {
+		logger.Errorf("%s", err)
+		os.Exit(1)
+	}
+}
+
+func run(kubeconfig string, port int, certFile, keyFile, groupName, solverName, clientCAFile, clusterResNs string) error {
+	if groupName == "" {
+		return fmt.Errorf("missing group name")
+	}
+	if certFile == "" || keyFile == "" {
+		return fmt.Errorf("missing TLS certificate or key file")
+	}
+	cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return fmt.Errorf("cannot get cluster configuration: %w", err)
+	}
+	client, err := versioned.NewForConfig(cfg)
+	if err != nil {
+		return err
+	}
+	clientCAs, allowedNames, err := requestHeaderAuthentication(cfg, clientCAFile)
+	if err != nil {
+		return err
+	}
+
+	log := logger.New().NewContext("solver", solverName)
+	handler := solver.NewHandler(log, groupName, solverName, solver.New(client, clusterResNs), true, allowedNames...)
+	server := &http.Server{
+		Addr:              fmt.Sprintf(":%d", port),
+		Handler:           handler,
+		ReadHeaderTimeout: 30 * time.Second,
+		TLSConfig: &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ClientCAs:  clientCAs,
+			ClientAuth: tls.VerifyClientCertIfGiven,
+		},
+	}
+	log.Infof("serving solver %s for group %s on port %d", solverName, groupName, port)
+	return server.ListenAndServeTLS(certFile, keyFile)
+}
+
+// requestHeaderAuthentication returns the CA and common names of the client certificates
+// used by the kube-apiserver to proxy requests to aggregated APIs.
+func requestHeaderAuthentication(cfg *rest.Config, clientCAFile string) (*x509.CertPool, []string, error) {
+	var (
+		data         []byte
+		allowedNames []string
+	)
+	if clientCAFile != "" {
+		var err error
+		data, err = ioutil.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, nil, err
+		}
+	} else {
+		clientset, err := kubernetes.NewForConfig(cfg)
+		if err != nil {
+			return nil, nil, err
+		}
+		cm, err := clientset.CoreV1().ConfigMaps(authConfigMapNamespace).Get(context.Background(), authConfigMapName, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, fmt.Errorf("cannot read request header authentication: %w", err)
+		}
+		data = []byte(cm.Data["requestheader-client-ca-file"])
+		if names := cm.Data["requestheader-allowed-names"]; names != "" {
+			if err := json.Unmarshal([]byte(names), &allowedNames); err != nil {
+				return nil, nil, fmt.Errorf("invalid requestheader-allowed-names: %w", err)
+			}
+		}
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, nil, fmt.Errorf("no request header client CA found")
+	}
+	return pool, allowedNames, nil
+}
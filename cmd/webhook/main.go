@@ -0,0 +1,56 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/alicloud"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/aws"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/azure-private"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/cloudflare"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/google"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/infoblox"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/netlify"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/openstack"
+	_ "github.com/gardener/external-dns-management/pkg/controller/provider/remote"
+	_ "github.com/gardener/external-dns-management/pkg/webhook/dnsentry"
+	_ "github.com/gardener/external-dns-management/pkg/webhook/dnsprovider"
+
+	_ "go.uber.org/automaxprocs"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/gcp"
+	_ "k8s.io/client-go/plugin/pkg/client/auth/oidc"
+)
+
+var Version string
+
+func init() {
+	resources.Register(v1alpha1.SchemeBuilder)
+}
+
+func main() {
+	if len(os.Args) == 2 && os.Args[1] == "version" {
+		fmt.Println(Version)
+		os.Exit(0)
+	}
+	controllermanager.Start("dns-admission-webhook", "dns admission webhook", "validating admission webhooks for DNSEntries and DNSProviders")
+}
@@ -218,6 +218,53 @@ spec:
               ownerId:
                 description: owner id used to tag entries in external DNS system
                 type: string
+              privateTargets:
+                description: target records used for private hosted zones (split-horizon). If set, the entry is maintained in a public and a private hosted zone serving the dns name, using the targets for the public zone and the private targets for the private zone.
+                items:
+                  type: string
+                type: array
+              providerRef:
+                description: reference to the DNSProvider to be used for the entry, overriding the selection by the longest matching domain
+                properties:
+                  name:
+                    description: name of the referenced DNSProvider object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSProvider object, defaults to the namespace of the entry
+                    type: string
+                required:
+                - name
+                type: object
+              providerSelector:
+                description: label selector restricting the DNSProviders to be used for the entry
+                properties:
+                  matchExpressions:
+                    description: matchExpressions is a list of label selector requirements. The requirements are ANDed.
+                    items:
+                      description: A label selector requirement is a selector that contains values, a key, and an operator that relates the key and values.
+                      properties:
+                        key:
+                          description: key is the label key that the selector applies to.
+                          type: string
+                        operator:
+                          description: operator represents a key's relationship to a set of values. Valid operators are In, NotIn, Exists and DoesNotExist.
+                          type: string
+                        values:
+                          description: values is an array of string values. If the operator is In or NotIn, the values array must be non-empty. If the operator is Exists or DoesNotExist, the values array must be empty. This array is replaced during a strategic merge patch.
+                          items:
+                            type: string
+                          type: array
+                      required:
+                      - key
+                      - operator
+                      type: object
+                    type: array
+                  matchLabels:
+                    additionalProperties:
+                      type: string
+                    description: matchLabels is a map of {key,value} pairs. A single {key,value} in the matchLabels map is equivalent to an element of matchExpressions, whose key field is "key", the operator is "In", and the values array contains only "value". The requirements are ANDed.
+                    type: object
+                type: object
               reference:
                 description: reference to base entry used to inherit attributes from
                 properties:
@@ -230,6 +277,9 @@ spec:
                 required:
                 - name
                 type: object
+              shared:
+                description: shared contributes the targets or texts to a record set shared with all other shared entries for the same dns name, possibly maintained with other owner ids
+                type: boolean
               targets:
                 description: target records (CNAME or A records), either text or targets must be specified
                 items:
@@ -249,6 +299,84 @@ spec:
             type: object
           status:
             properties:
+              additionalZones:
+                description: status of the hosted zones the entry is maintained in besides the zone of the assigned provider
+                items:
+                  properties:
+                    message:
+                      description: message describing the reason for the state
+                      type: string
+                    private:
+                      description: private is set for private hosted zones
+                      type: boolean
+                    provider:
+                      description: provider used for the hosted zone
+                      type: string
+                    providerType:
+                      description: provider type of the hosted zone
+                      type: string
+                    state:
+                      description: state of the entry in the hosted zone
+                      type: string
+                    targets:
+                      description: effective targets used for the hosted zone
+                      items:
+                        type: string
+                      type: array
+                    zone:
+                      description: zone id of the hosted zone
+                      type: string
+                  required:
+                  - zone
+                  type: object
+                type: array
+              conditions:
+                description: conditions of the entry (Ready, ProviderAssigned, OwnershipConfirmed, Throttled, RecordsInSync)
+                items:
+                  description: "Condition contains details for one aspect of the current state of this API Resource. --- This struct is intended for direct use as an array at the field path .status.conditions.  For example, type FooStatus struct{     // Represents the observations of a foo's current state.     // Known .status.conditions.type are: \"Available\", \"Progressing\", and \"Degraded\"     // +patchMergeKey=type     // +patchStrategy=merge     // +listType=map     // +listMapKey=type     Conditions []metav1.Condition `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\" protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n     // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition transitioned from one status to another. This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation that the condition was set based upon. For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date with respect to the current state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating the reason for the condition's last transition. Producers of specific condition types may define expected values and meanings for this field, and whether the values are considered a guaranteed API. The value should be a CamelCase string. This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase. --- Many .condition.type values are consistent across resources like Available, but because arbitrary conditions can be useful (see .node.status.conditions), the ability to deconflict is important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
               lastUpdateTime:
                 description: lastUpdateTime contains the timestamp of the last status update
                 format: date-time
@@ -341,6 +469,12 @@ spec:
               policy:
                 description: ZonePolicy specifies zone specific policy
                 properties:
+                  driftPolicy:
+                    description: DriftPolicy specifies the handling of records modified outside of the controller. `+"`"+`Correct`+"`"+` (default) restores the records of the entries, `+"`"+`Report`+"`"+` only reports the drift.
+                    enum:
+                    - Correct
+                    - Report
+                    type: string
                   zoneStateCacheTTL:
                     description: ZoneStateCacheTTL specifies the TTL for the zone state cache
                     type: string
@@ -417,6 +551,252 @@ status:
 	utils.Must(registry.RegisterCRD(data))
 	data = `
 
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.4.1
+  creationTimestamp: null
+  name: dnshostedzonerequests.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSHostedZoneRequest
+    listKind: DNSHostedZoneRequestList
+    plural: dnshostedzonerequests
+    shortNames:
+    - dnshzr
+    singular: dnshostedzonerequest
+  scope: Namespaced
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .spec.domainName
+      name: Domain
+      type: string
+    - jsonPath: .spec.providerRef.name
+      name: Provider
+      type: string
+    - jsonPath: .status.zoneID
+      name: Zone
+      type: string
+    - jsonPath: .status.parentZoneID
+      name: Parent
+      type: string
+    - jsonPath: .status.state
+      name: State
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: DNSHostedZoneRequest requests the creation of a hosted zone by a DNS provider. The zone is delegated by NS records in the parent zone, if the parent zone is served by a provider. Both are removed on deletion of the request.
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          spec:
+            properties:
+              delegationTTL:
+                description: TTL of the NS records delegating the zone in the parent zone (by default the default TTL of the provider of the parent zone is used)
+                format: int64
+                type: integer
+              domainName:
+                description: domain name of the hosted zone to create
+                type: string
+              providerRef:
+                description: reference to the DNSProvider used to create the hosted zone
+                properties:
+                  name:
+                    description: name of the referenced DNSProvider object
+                    type: string
+                  namespace:
+                    description: namespace of the referenced DNSProvider object, defaults to the namespace of the entry
+                    type: string
+                required:
+                - name
+                type: object
+            required:
+            - domainName
+            - providerRef
+            type: object
+          status:
+            properties:
+              domainName:
+                description: domain name of the created hosted zone
+                type: string
+              message:
+                description: message describing the reason for the state
+                type: string
+              nameServers:
+                description: name servers of the created hosted zone
+                items:
+                  type: string
+                type: array
+              observedGeneration:
+                format: int64
+                type: integer
+              parentProvider:
+                description: provider (namespace/name) used to maintain the NS delegation records
+                type: string
+              parentZoneID:
+                description: ID of the parent zone containing the NS delegation records
+                type: string
+              provider:
+                description: provider (namespace/name) used to create the hosted zone
+                type: string
+              providerType:
+                description: provider type of the created hosted zone
+                type: string
+              state:
+                description: state of the request
+                type: string
+              zoneID:
+                description: ID of the created hosted zone
+                type: string
+            type: object
+        required:
+        - spec
+        type: object
+    served: true
+    storage: true
+    subresources:
+      status: {}
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.4.1
+  creationTimestamp: null
+  name: dnshostedzones.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSHostedZone
+    listKind: DNSHostedZoneList
+    plural: dnshostedzones
+    shortNames:
+    - dnshz
+    singular: dnshostedzone
+  scope: Cluster
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .status.zoneID
+      name: Zone
+      type: string
+    - jsonPath: .status.providerType
+      name: Type
+      type: string
+    - jsonPath: .status.domainName
+      name: Domain
+      type: string
+    - jsonPath: .status.private
+      name: Private
+      type: boolean
+    - jsonPath: .status.recordCount
+      name: Records
+      type: integer
+    - jsonPath: .status.entryCount
+      name: Entries
+      type: integer
+    - jsonPath: .status.cacheState
+      name: Cache
+      type: string
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: DNSHostedZone is maintained by the DNS provisioning controller and reflects a hosted zone discovered by the DNS providers. It is read-only.
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          status:
+            properties:
+              cacheState:
+                description: CacheState is the state of the zone state cache (Disabled, Cached, Expired or Empty)
+                type: string
+              domainName:
+                description: Domain name of the zone
+                type: string
+              entryCount:
+                description: Number of DNS entries assigned to the zone
+                type: integer
+              forwardedDomains:
+                description: Forwarded domains of the zone (sub domains delegated to other name servers)
+                items:
+                  type: string
+                type: array
+              lastError:
+                description: LastError describes the error of the last failed zone reconciliation
+                type: string
+              lastFullReadTime:
+                description: LastFullReadTime contains the timestamp of the last complete read of the zone state
+                format: date-time
+                type: string
+              lastUpdateTime:
+                description: LastUpdateTime contains the timestamp of the last update
+                format: date-time
+                type: string
+              private:
+                description: Private indicates a private zone only visible in dedicated networks
+                type: boolean
+              providerType:
+                description: Provider type of the zone
+                type: string
+              recordCount:
+                description: Number of DNS record sets found in the zone on the last reconciliation
+                type: integer
+              zoneID:
+                description: ID of the zone
+                type: string
+            required:
+            - cacheState
+            - domainName
+            - entryCount
+            - providerType
+            - zoneID
+            type: object
+        required:
+        - status
+        type: object
+    served: true
+    storage: true
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -521,6 +901,53 @@ spec:
                   type: string
                 description: attribute values found in DNS
                 type: object
+              conditions:
+                description: conditions of the entry (Ready, ProviderAssigned, OwnershipConfirmed, Throttled, RecordsInSync)
+                items:
+                  description: "Condition contains details for one aspect of the current state of this API Resource. --- This struct is intended for direct use as an array at the field path .status.conditions.  For example, type FooStatus struct{     // Represents the observations of a foo's current state.     // Known .status.conditions.type are: \"Available\", \"Progressing\", and \"Degraded\"     // +patchMergeKey=type     // +patchStrategy=merge     // +listType=map     // +listMapKey=type     Conditions []metav1.Condition `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\" protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n     // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition transitioned from one status to another. This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation that the condition was set based upon. For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date with respect to the current state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating the reason for the condition's last transition. Producers of specific condition types may define expected values and meanings for this field, and whether the values are considered a guaranteed API. The value should be a CamelCase string. This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase. --- Many .condition.type values are consistent across resources like Available, but because arbitrary conditions can be useful (see .node.status.conditions), the ability to deconflict is important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
               firstFailedDNSLookup:
                 description: First failed DNS looup
                 format: date-time
@@ -631,6 +1058,11 @@ spec:
               active:
                 description: state of the ownerid for the DNS controller observing entry using this owner id (default:true)
                 type: boolean
+              adoptOwnerIds:
+                description: Optional list of owner ids whose DNS records are taken over by this owner id. Records owned by one of these ids are adopted by rewriting their owner meta data if they are claimed by a DNS entry using this owner id.
+                items:
+                  type: string
+                type: array
               dnsActivation:
                 description: Optional activation info for controlling the owner activation remotely via DNS TXT record
                 properties:
@@ -658,6 +1090,21 @@ spec:
               active:
                 description: state of the ownerid for the DNS controller observing entry using this owner id
                 type: boolean
+              adoption:
+                description: Progress of the adoption of DNS records owned by the owner ids to adopt
+                properties:
+                  adopted:
+                    description: number of DNS record sets already adopted by this owner id
+                    type: integer
+                  conflicts:
+                    description: DNS names owned by an owner id to adopt, but claimed by DNS entries with a different owner id
+                    items:
+                      type: string
+                    type: array
+                  pending:
+                    description: number of DNS record sets still owned by an owner id to adopt
+                    type: integer
+                type: object
               entries:
                 description: Entry statistic for this owner id
                 properties:
@@ -764,6 +1211,9 @@ spec:
                       type: string
                     type: array
                 type: object
+              mirrorGroup:
+                description: mirror group of the provider. Entries assigned to a provider of a mirror group are additionally maintained in the zones of all other providers of the same group serving the dns name.
+                type: string
               providerConfig:
                 description: optional additional provider specific configuration values
                 type: object
@@ -811,6 +1261,53 @@ spec:
             type: object
           status:
             properties:
+              conditions:
+                description: conditions of the provider (Ready, Throttled)
+                items:
+                  description: "Condition contains details for one aspect of the current state of this API Resource. --- This struct is intended for direct use as an array at the field path .status.conditions.  For example, type FooStatus struct{     // Represents the observations of a foo's current state.     // Known .status.conditions.type are: \"Available\", \"Progressing\", and \"Degraded\"     // +patchMergeKey=type     // +patchStrategy=merge     // +listType=map     // +listMapKey=type     Conditions []metav1.Condition `+"`"+`json:\"conditions,omitempty\" patchStrategy:\"merge\" patchMergeKey:\"type\" protobuf:\"bytes,1,rep,name=conditions\"`+"`"+` \n     // other fields }"
+                  properties:
+                    lastTransitionTime:
+                      description: lastTransitionTime is the last time the condition transitioned from one status to another. This should be when the underlying condition changed.  If that is not known, then using the time when the API field changed is acceptable.
+                      format: date-time
+                      type: string
+                    message:
+                      description: message is a human readable message indicating details about the transition. This may be an empty string.
+                      maxLength: 32768
+                      type: string
+                    observedGeneration:
+                      description: observedGeneration represents the .metadata.generation that the condition was set based upon. For instance, if .metadata.generation is currently 12, but the .status.conditions[x].observedGeneration is 9, the condition is out of date with respect to the current state of the instance.
+                      format: int64
+                      minimum: 0
+                      type: integer
+                    reason:
+                      description: reason contains a programmatic identifier indicating the reason for the condition's last transition. Producers of specific condition types may define expected values and meanings for this field, and whether the values are considered a guaranteed API. The value should be a CamelCase string. This field may not be empty.
+                      maxLength: 1024
+                      minLength: 1
+                      pattern: ^[A-Za-z]([A-Za-z0-9_,:]*[A-Za-z0-9_])?$
+                      type: string
+                    status:
+                      description: status of the condition, one of True, False, Unknown.
+                      enum:
+                      - "True"
+                      - "False"
+                      - Unknown
+                      type: string
+                    type:
+                      description: type of condition in CamelCase or in foo.example.com/CamelCase. --- Many .condition.type values are consistent across resources like Available, but because arbitrary conditions can be useful (see .node.status.conditions), the ability to deconflict is important. The regex it matches is (dns1123SubdomainFmt/)?(qualifiedNameFmt)
+                      maxLength: 316
+                      pattern: ^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*/)?(([A-Za-z0-9][-A-Za-z0-9_.]*)?[A-Za-z0-9])$
+                      type: string
+                  required:
+                  - lastTransitionTime
+                  - message
+                  - reason
+                  - status
+                  - type
+                  type: object
+                type: array
+                x-kubernetes-list-map-keys:
+                - type
+                x-kubernetes-list-type: map
               defaultTTL:
                 description: actually used default TTL for DNS entries
                 format: int64
@@ -887,6 +1384,123 @@ status:
 	utils.Must(registry.RegisterCRD(data))
 	data = `
 
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  annotations:
+    controller-gen.kubebuilder.io/version: v0.4.1
+  creationTimestamp: null
+  name: dnszoneconflictreports.dns.gardener.cloud
+spec:
+  group: dns.gardener.cloud
+  names:
+    kind: DNSZoneConflictReport
+    listKind: DNSZoneConflictReportList
+    plural: dnszoneconflictreports
+    shortNames:
+    - dnszcr
+    singular: dnszoneconflictreport
+  scope: Cluster
+  versions:
+  - additionalPrinterColumns:
+    - jsonPath: .report.zoneID
+      name: Zone
+      type: string
+    - jsonPath: .report.providerType
+      name: Type
+      type: string
+    - jsonPath: .report.domainName
+      name: Domain
+      type: string
+    - jsonPath: .report.count
+      name: Conflicts
+      type: integer
+    - jsonPath: .metadata.creationTimestamp
+      name: Age
+      type: date
+    name: v1alpha1
+    schema:
+      openAPIV3Schema:
+        description: DNSZoneConflictReport is maintained by the DNS provisioning controller and reports the DNS names of a hosted zone blocked by records of foreign owners.
+        properties:
+          apiVersion:
+            description: 'APIVersion defines the versioned schema of this representation of an object. Servers should convert recognized schemas to the latest internal value, and may reject unrecognized values. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#resources'
+            type: string
+          kind:
+            description: 'Kind is a string value representing the REST resource this object represents. Servers may infer this from the endpoint the client submits requests to. Cannot be updated. In CamelCase. More info: https://git.k8s.io/community/contributors/devel/sig-architecture/api-conventions.md#types-kinds'
+            type: string
+          metadata:
+            type: object
+          report:
+            properties:
+              conflicts:
+                description: Conflicts lists the blocked DNS names
+                items:
+                  properties:
+                    dnsName:
+                      description: DNSName is the blocked DNS name
+                      type: string
+                    entries:
+                      description: Entries lists the DNS entries (namespace/name) blocked by the record
+                      items:
+                        type: string
+                      type: array
+                    inactiveOwners:
+                      description: InactiveOwners lists DNSOwner objects of this controller for the owner id of the record, which are currently not active
+                      items:
+                        type: string
+                      type: array
+                    ownerId:
+                      description: OwnerId is the owner id of the blocking DNS record
+                      type: string
+                    since:
+                      description: Since is the time the conflict has been detected first
+                      format: date-time
+                      type: string
+                  required:
+                  - dnsName
+                  - ownerId
+                  - since
+                  type: object
+                type: array
+              count:
+                description: Number of conflicting DNS names
+                type: integer
+              domainName:
+                description: Domain name of the zone
+                type: string
+              lastUpdateTime:
+                description: LastUpdateTime contains the timestamp of the last report update
+                format: date-time
+                type: string
+              providerType:
+                description: Provider type of the zone
+                type: string
+              zoneID:
+                description: ID of the zone
+                type: string
+            required:
+            - count
+            - domainName
+            - providerType
+            - zoneID
+            type: object
+        required:
+        - report
+        type: object
+    served: true
+    storage: true
+status:
+  acceptedNames:
+    kind: ""
+    plural: ""
+  conditions: []
+  storedVersions: []
+  `
+	utils.Must(registry.RegisterCRD(data))
+	data = `
+
 ---
 apiVersion: apiextensions.k8s.io/v1
 kind: CustomResourceDefinition
@@ -916,6 +1530,17 @@ spec:
     - jsonPath: .status.notBefore
       name: SecretAge
       type: date
+    - jsonPath: .status.notAfter
+      name: Expiry
+      type: date
+    - jsonPath: .status.renewalTime
+      name: Renewal
+      priority: 2000
+      type: date
+    - jsonPath: .status.revoked
+      name: Revoked
+      priority: 2000
+      type: boolean
     name: v1alpha1
     schema:
       openAPIV3Schema:
@@ -936,9 +1561,24 @@ spec:
               domainName:
                 description: Domain name, used for building subject and DNS name
                 type: string
+              keySize:
+                description: 'Size of the private key (RSA: at least 2048, defaults to 2048; ECDSA: 256, 384 or 521, defaults to 256)'
+                type: integer
+              keyType:
+                description: Type of the private key (RSA or ECDSA, defaults to RSA)
+                enum:
+                - RSA
+                - ECDSA
+                type: string
               recreate:
                 description: Indicates if certificate should be recreated and replaced in the secret
                 type: boolean
+              renewBefore:
+                description: Duration before expiration the certificate is renewed (defaults to one third of the validity period)
+                type: string
+              revoked:
+                description: Indicates if the certificate is revoked. A revoked certificate is added to the revocation list and its secret is deleted. Resetting the flag creates a new certificate.
+                type: boolean
               secretName:
                 description: Name of the secret to store the client certificate
                 type: string
@@ -953,6 +1593,23 @@ spec:
             type: object
           status:
             properties:
+              issuedCertificates:
+                description: Serial numbers and expiration timestamps of all issued certificates, which are not expired yet. They are added to the revocation list if the certificate is revoked.
+                items:
+                  description: IssuedCertificate describes an issued certificate.
+                  properties:
+                    notAfter:
+                      description: Expiration timestamp of the certificate
+                      format: date-time
+                      type: string
+                    serialNumber:
+                      description: Serial number of the certificate
+                      type: string
+                  required:
+                  - notAfter
+                  - serialNumber
+                  type: object
+                type: array
               message:
                 description: In case of a configuration problem this field describes the reason
                 type: string
@@ -967,6 +1624,13 @@ spec:
               recreating:
                 description: Indicates if certificate should be recreated and replaced in the secret
                 type: boolean
+              renewalTime:
+                description: Scheduled time for the automatic renewal of the certificate
+                format: date-time
+                type: string
+              revoked:
+                description: Indicates if the certificate has been revoked
+                type: boolean
               serialNumber:
                 description: Serial number of the certificate
                 type: string
@@ -76,6 +76,22 @@ type DNSEntrySpec struct {
 	// target records (CNAME or A records), either text or targets must be specified
 	// +optional
 	Targets []string `json:"targets,omitempty"`
+	// target records used for private hosted zones (split-horizon). If set, the entry is
+	// maintained in a public and a private hosted zone serving the dns name, using the
+	// targets for the public zone and the private targets for the private zone.
+	// +optional
+	PrivateTargets []string `json:"privateTargets,omitempty"`
+	// shared contributes the targets or texts to a record set shared with all other
+	// shared entries for the same dns name, possibly maintained with other owner ids
+	// +optional
+	Shared *bool `json:"shared,omitempty"`
+	// reference to the DNSProvider to be used for the entry, overriding the
+	// selection by the longest matching domain
+	// +optional
+	ProviderRef *ProviderReference `json:"providerRef,omitempty"`
+	// label selector restricting the DNSProviders to be used for the entry
+	// +optional
+	ProviderSelector *metav1.LabelSelector `json:"providerSelector,omitempty"`
 }
 
 type DNSEntryStatus struct {
@@ -83,6 +99,32 @@ type DNSEntryStatus struct {
 	// effective targets generated for the entry
 	// +optional
 	Targets []string `json:"targets,omitempty"`
+	// status of the hosted zones the entry is maintained in besides the zone of the assigned provider
+	// +optional
+	AdditionalZones []DNSEntryZoneStatus `json:"additionalZones,omitempty"`
+}
+
+type DNSEntryZoneStatus struct {
+	// zone id of the hosted zone
+	Zone string `json:"zone"`
+	// provider used for the hosted zone
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// provider type of the hosted zone
+	// +optional
+	ProviderType string `json:"providerType,omitempty"`
+	// private is set for private hosted zones
+	// +optional
+	Private bool `json:"private,omitempty"`
+	// state of the entry in the hosted zone
+	// +optional
+	State string `json:"state"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// effective targets used for the hosted zone
+	// +optional
+	Targets []string `json:"targets,omitempty"`
 }
 
 type DNSBaseStatus struct {
@@ -109,6 +151,11 @@ type DNSBaseStatus struct {
 	// time to live used for the entry
 	// +optional
 	TTL *int64 `json:"ttl,omitempty"`
+	// conditions of the entry (Ready, ProviderAssigned, OwnershipConfirmed, Throttled, RecordsInSync)
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type EntryReference struct {
@@ -118,3 +165,11 @@ type EntryReference struct {
 	// +optional
 	Namespace string `json:"namespace,omitempty"`
 }
+
+type ProviderReference struct {
+	// name of the referenced DNSProvider object
+	Name string `json:"name"`
+	// namespace of the referenced DNSProvider object, defaults to the namespace of the entry
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+}
@@ -0,0 +1,95 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSHostedZoneList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSHostedZone `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=dnshostedzones,shortName=dnshz,singular=dnshostedzone
+// +kubebuilder:printcolumn:name=Zone,JSONPath=".status.zoneID",type=string
+// +kubebuilder:printcolumn:name=Type,JSONPath=".status.providerType",type=string
+// +kubebuilder:printcolumn:name=Domain,JSONPath=".status.domainName",type=string
+// +kubebuilder:printcolumn:name=Private,JSONPath=".status.private",type=boolean
+// +kubebuilder:printcolumn:name=Records,JSONPath=".status.recordCount",type=integer
+// +kubebuilder:printcolumn:name=Entries,JSONPath=".status.entryCount",type=integer
+// +kubebuilder:printcolumn:name=Cache,JSONPath=".status.cacheState",type=string
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSHostedZone is maintained by the DNS provisioning controller and reflects a
+// hosted zone discovered by the DNS providers. It is read-only.
+type DNSHostedZone struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Status            DNSHostedZoneStatus `json:"status"`
+}
+
+const (
+	// ZoneCacheStateDisabled indicates that the zone state is not cached.
+	ZoneCacheStateDisabled = "Disabled"
+	// ZoneCacheStateCached indicates a valid cached zone state.
+	ZoneCacheStateCached = "Cached"
+	// ZoneCacheStateExpired indicates a cached zone state to be read again on next use.
+	ZoneCacheStateExpired = "Expired"
+	// ZoneCacheStateEmpty indicates that there is no cached zone state.
+	ZoneCacheStateEmpty = "Empty"
+)
+
+type DNSHostedZoneStatus struct {
+	// ID of the zone
+	ZoneID string `json:"zoneID"`
+	// Provider type of the zone
+	ProviderType string `json:"providerType"`
+	// Domain name of the zone
+	DomainName string `json:"domainName"`
+	// Forwarded domains of the zone (sub domains delegated to other name servers)
+	// +optional
+	ForwardedDomains []string `json:"forwardedDomains,omitempty"`
+	// Private indicates a private zone only visible in dedicated networks
+	// +optional
+	Private bool `json:"private,omitempty"`
+	// Number of DNS record sets found in the zone on the last reconciliation
+	// +optional
+	RecordCount *int `json:"recordCount,omitempty"`
+	// Number of DNS entries assigned to the zone
+	EntryCount int `json:"entryCount"`
+	// LastFullReadTime contains the timestamp of the last complete read of the zone state
+	// +optional
+	LastFullReadTime *metav1.Time `json:"lastFullReadTime,omitempty"`
+	// CacheState is the state of the zone state cache (Disabled, Cached, Expired or Empty)
+	CacheState string `json:"cacheState"`
+	// LastError describes the error of the last failed zone reconciliation
+	// +optional
+	LastError *string `json:"lastError,omitempty"`
+	// LastUpdateTime contains the timestamp of the last update
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
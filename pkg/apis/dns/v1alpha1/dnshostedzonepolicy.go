@@ -72,8 +72,20 @@ type ZonePolicy struct {
 	// ZoneStateCacheTTL specifies the TTL for the zone state cache
 	// +optional
 	ZoneStateCacheTTL *metav1.Duration `json:"zoneStateCacheTTL,omitempty"`
+	// DriftPolicy specifies the handling of records modified outside of the controller.
+	// `Correct` (default) restores the records of the entries, `Report` only reports the drift.
+	// +optional
+	// +kubebuilder:validation:Enum=Correct;Report
+	DriftPolicy *string `json:"driftPolicy,omitempty"`
 }
 
+const (
+	// DriftPolicyCorrect restores drifted records on zone reconciliation.
+	DriftPolicyCorrect = "Correct"
+	// DriftPolicyReport only reports drifted records by events, metrics and entry conditions.
+	DriftPolicyReport = "Report"
+)
+
 type DNSHostedZonePolicyStatus struct {
 	// Number of zones this policy is applied to
 	// +optional
@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSHostedZoneRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSHostedZoneRequest `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,path=dnshostedzonerequests,shortName=dnshzr,singular=dnshostedzonerequest
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name=Domain,JSONPath=".spec.domainName",type=string
+// +kubebuilder:printcolumn:name=Provider,JSONPath=".spec.providerRef.name",type=string
+// +kubebuilder:printcolumn:name=Zone,JSONPath=".status.zoneID",type=string
+// +kubebuilder:printcolumn:name=Parent,JSONPath=".status.parentZoneID",type=string
+// +kubebuilder:printcolumn:name=State,JSONPath=".status.state",type=string
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSHostedZoneRequest requests the creation of a hosted zone by a DNS provider.
+// The zone is delegated by NS records in the parent zone, if the parent zone is
+// served by a provider. Both are removed on deletion of the request.
+type DNSHostedZoneRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Spec              DNSHostedZoneRequestSpec `json:"spec"`
+	// +optional
+	Status DNSHostedZoneRequestStatus `json:"status,omitempty"`
+}
+
+type DNSHostedZoneRequestSpec struct {
+	// domain name of the hosted zone to create
+	DomainName string `json:"domainName"`
+	// reference to the DNSProvider used to create the hosted zone
+	ProviderRef ProviderReference `json:"providerRef"`
+	// TTL of the NS records delegating the zone in the parent zone
+	// (by default the default TTL of the provider of the parent zone is used)
+	// +optional
+	DelegationTTL *int64 `json:"delegationTTL,omitempty"`
+}
+
+type DNSHostedZoneRequestStatus struct {
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// state of the request
+	// +optional
+	State string `json:"state"`
+	// message describing the reason for the state
+	// +optional
+	Message *string `json:"message,omitempty"`
+	// domain name of the created hosted zone
+	// +optional
+	DomainName string `json:"domainName,omitempty"`
+	// ID of the created hosted zone
+	// +optional
+	ZoneID string `json:"zoneID,omitempty"`
+	// provider type of the created hosted zone
+	// +optional
+	ProviderType string `json:"providerType,omitempty"`
+	// provider (namespace/name) used to create the hosted zone
+	// +optional
+	Provider string `json:"provider,omitempty"`
+	// name servers of the created hosted zone
+	// +optional
+	NameServers []string `json:"nameServers,omitempty"`
+	// ID of the parent zone containing the NS delegation records
+	// +optional
+	ParentZoneID string `json:"parentZoneID,omitempty"`
+	// provider (namespace/name) used to maintain the NS delegation records
+	// +optional
+	ParentProvider string `json:"parentProvider,omitempty"`
+}
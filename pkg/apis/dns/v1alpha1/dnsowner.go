@@ -64,6 +64,12 @@ type DNSOwnerSpec struct {
 	// +optional
 	// optional time this owner should be active if active flag is not false
 	ValidUntil *metav1.Time `json:"validUntil,omitempty"`
+
+	// Optional list of owner ids whose DNS records are taken over by this owner id.
+	// Records owned by one of these ids are adopted by rewriting their owner meta data
+	// if they are claimed by a DNS entry using this owner id.
+	// +optional
+	AdoptOwnerIds []string `json:"adoptOwnerIds,omitempty"`
 }
 
 // DNSActivation carries the optinal informatio required to control the
@@ -84,6 +90,23 @@ type DNSOwnerStatus struct {
 	// Entry statistic for this owner id
 	// +optional
 	Entries DNSOwnerStatusEntries `json:"entries,omitempty"`
+	// Progress of the adoption of DNS records owned by the owner ids to adopt
+	// +optional
+	Adoption *DNSOwnerAdoptionStatus `json:"adoption,omitempty"`
+}
+
+// DNSOwnerAdoptionStatus describes the progress of the takeover of DNS records
+// owned by other owner ids.
+type DNSOwnerAdoptionStatus struct {
+	// number of DNS record sets still owned by an owner id to adopt
+	// +optional
+	Pending int `json:"pending"`
+	// number of DNS record sets already adopted by this owner id
+	// +optional
+	Adopted int `json:"adopted"`
+	// DNS names owned by an owner id to adopt, but claimed by DNS entries with a different owner id
+	// +optional
+	Conflicts []string `json:"conflicts,omitempty"`
 }
 
 type DNSOwnerStatusEntries struct {
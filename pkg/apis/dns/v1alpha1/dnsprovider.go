@@ -78,6 +78,11 @@ type DNSProviderSpec struct {
 	// rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// mirror group of the provider. Entries assigned to a provider of a mirror group
+	// are additionally maintained in the zones of all other providers of the same group
+	// serving the dns name.
+	// +optional
+	MirrorGroup string `json:"mirrorGroup,omitempty"`
 }
 
 type RateLimit struct {
@@ -120,6 +125,11 @@ type DNSProviderStatus struct {
 	// actually used rate limit for create/update operations on DNSEntries assigned to this provider
 	// +optional
 	RateLimit *RateLimit `json:"rateLimit,omitempty"`
+	// conditions of the provider (Ready, Throttled)
+	// +optional
+	// +listType=map
+	// +listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
 type DNSSelectionStatus struct {
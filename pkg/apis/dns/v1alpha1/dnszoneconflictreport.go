@@ -0,0 +1,83 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type DNSZoneConflictReportList struct {
+	metav1.TypeMeta `json:",inline"`
+	// Standard list metadata
+	// More info: http://releases.k8s.io/HEAD/docs/devel/api-conventions.md#metadata
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DNSZoneConflictReport `json:"items"`
+}
+
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,path=dnszoneconflictreports,shortName=dnszcr,singular=dnszoneconflictreport
+// +kubebuilder:printcolumn:name=Zone,JSONPath=".report.zoneID",type=string
+// +kubebuilder:printcolumn:name=Type,JSONPath=".report.providerType",type=string
+// +kubebuilder:printcolumn:name=Domain,JSONPath=".report.domainName",type=string
+// +kubebuilder:printcolumn:name=Conflicts,JSONPath=".report.count",type=integer
+// +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// DNSZoneConflictReport is maintained by the DNS provisioning controller and
+// reports the DNS names of a hosted zone blocked by records of foreign owners.
+type DNSZoneConflictReport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+	Report            DNSZoneConflictReportData `json:"report"`
+}
+
+type DNSZoneConflictReportData struct {
+	// ID of the zone
+	ZoneID string `json:"zoneID"`
+	// Provider type of the zone
+	ProviderType string `json:"providerType"`
+	// Domain name of the zone
+	DomainName string `json:"domainName"`
+	// Number of conflicting DNS names
+	Count int `json:"count"`
+	// Conflicts lists the blocked DNS names
+	// +optional
+	Conflicts []DNSRecordConflict `json:"conflicts,omitempty"`
+	// LastUpdateTime contains the timestamp of the last report update
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+type DNSRecordConflict struct {
+	// DNSName is the blocked DNS name
+	DNSName string `json:"dnsName"`
+	// OwnerId is the owner id of the blocking DNS record
+	OwnerId string `json:"ownerId"`
+	// InactiveOwners lists DNSOwner objects of this controller for
+	// the owner id of the record, which are currently not active
+	// +optional
+	InactiveOwners []string `json:"inactiveOwners,omitempty"`
+	// Entries lists the DNS entries (namespace/name) blocked by the record
+	// +optional
+	Entries []string `json:"entries,omitempty"`
+	// Since is the time the conflict has been detected first
+	Since metav1.Time `json:"since"`
+}
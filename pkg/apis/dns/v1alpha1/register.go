@@ -34,6 +34,10 @@ const (
 	DNSAnnotationKind       = "DNSAnnotation"
 	DNSHostedZonePolicyKind = "DNSHostedZonePolicy"
 
+	DNSZoneConflictReportKind = "DNSZoneConflictReport"
+	DNSHostedZoneKind         = "DNSHostedZone"
+	DNSHostedZoneRequestKind  = "DNSHostedZoneRequest"
+
 	RemoteAccessCertificateKind = "RemoteAccessCertificate"
 )
 
@@ -70,6 +74,12 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&DNSAnnotationList{},
 		&DNSHostedZonePolicy{},
 		&DNSHostedZonePolicyList{},
+		&DNSZoneConflictReport{},
+		&DNSZoneConflictReportList{},
+		&DNSHostedZone{},
+		&DNSHostedZoneList{},
+		&DNSHostedZoneRequest{},
+		&DNSHostedZoneRequestList{},
 		&RemoteAccessCertificate{},
 		&RemoteAccessCertificateList{},
 	)
@@ -37,6 +37,9 @@ type RemoteAccessCertificateList struct {
 // +kubebuilder:printcolumn:name=Type,JSONPath=".spec.type",type=string
 // +kubebuilder:printcolumn:name=Age,JSONPath=".metadata.creationTimestamp",type=date
 // +kubebuilder:printcolumn:name=SecretAge,JSONPath=".status.notBefore",type=date
+// +kubebuilder:printcolumn:name=Expiry,JSONPath=".status.notAfter",type=date
+// +kubebuilder:printcolumn:name=Renewal,JSONPath=".status.renewalTime",type=date,priority=2000
+// +kubebuilder:printcolumn:name=Revoked,JSONPath=".status.revoked",type=boolean,priority=2000
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
@@ -60,8 +63,32 @@ type RemoteAccessCertificateSpec struct {
 	// Indicates if certificate should be recreated and replaced in the secret
 	// +optional
 	Recreate bool `json:"recreate,omitempty"`
+	// Type of the private key (RSA or ECDSA, defaults to RSA)
+	// +kubebuilder:validation:Enum=RSA;ECDSA
+	// +optional
+	KeyType RemoteAccessCertificateKeyType `json:"keyType,omitempty"`
+	// Size of the private key (RSA: at least 2048, defaults to 2048; ECDSA: 256, 384 or 521, defaults to 256)
+	// +optional
+	KeySize int `json:"keySize,omitempty"`
+	// Duration before expiration the certificate is renewed (defaults to one third of the validity period)
+	// +optional
+	RenewBefore *metav1.Duration `json:"renewBefore,omitempty"`
+	// Indicates if the certificate is revoked. A revoked certificate is added to the revocation list
+	// and its secret is deleted. Resetting the flag creates a new certificate.
+	// +optional
+	Revoked bool `json:"revoked,omitempty"`
 }
 
+// RemoteAccessCertificateKeyType is a string alias.
+type RemoteAccessCertificateKeyType string
+
+const (
+	// RSAKeyType specifies a RSA private key
+	RSAKeyType RemoteAccessCertificateKeyType = "RSA"
+	// ECDSAKeyType specifies an ECDSA private key
+	ECDSAKeyType RemoteAccessCertificateKeyType = "ECDSA"
+)
+
 // RemoteAccessCertificateType is a string alias.
 type RemoteAccessCertificateType string
 
@@ -88,4 +115,22 @@ type RemoteAccessCertificateStatus struct {
 	// Indicates if certificate should be recreated and replaced in the secret
 	// +optional
 	Recreating bool `json:"recreating,omitempty"`
+	// Scheduled time for the automatic renewal of the certificate
+	// +optional
+	RenewalTime *metav1.Time `json:"renewalTime,omitempty"`
+	// Indicates if the certificate has been revoked
+	// +optional
+	Revoked bool `json:"revoked,omitempty"`
+	// Serial numbers and expiration timestamps of all issued certificates, which are not expired yet.
+	// They are added to the revocation list if the certificate is revoked.
+	// +optional
+	IssuedCertificates []IssuedCertificate `json:"issuedCertificates,omitempty"`
+}
+
+// IssuedCertificate describes an issued certificate.
+type IssuedCertificate struct {
+	// Serial number of the certificate
+	SerialNumber string `json:"serialNumber"`
+	// Expiration timestamp of the certificate
+	NotAfter metav1.Time `json:"notAfter"`
 }
@@ -22,3 +22,17 @@ const STATE_INVALID = "Invalid"
 const STATE_STALE = "Stale"
 const STATE_READY = "Ready"
 const STATE_DELETING = "Deleting"
+
+// Condition types used in the status of DNSEntry and DNSProvider objects.
+const (
+	// ConditionReady indicates that the object is ready.
+	ConditionReady = "Ready"
+	// ConditionProviderAssigned indicates that a responsible provider has been found for the entry.
+	ConditionProviderAssigned = "ProviderAssigned"
+	// ConditionOwnershipConfirmed indicates that the DNS records of the entry are not claimed by a foreign owner or entry.
+	ConditionOwnershipConfirmed = "OwnershipConfirmed"
+	// ConditionThrottled indicates that changes are delayed by the rate limiter of the provider.
+	ConditionThrottled = "Throttled"
+	// ConditionRecordsInSync indicates that the DNS records in the hosted zone match the entry.
+	ConditionRecordsInSync = "RecordsInSync"
+)
@@ -22,8 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -181,6 +181,13 @@ func (in *DNSBaseStatus) DeepCopyInto(out *DNSBaseStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -288,6 +295,26 @@ func (in *DNSEntrySpec) DeepCopyInto(out *DNSEntrySpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.PrivateTargets != nil {
+		in, out := &in.PrivateTargets, &out.PrivateTargets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Shared != nil {
+		in, out := &in.Shared, &out.Shared
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(ProviderReference)
+		**out = **in
+	}
+	if in.ProviderSelector != nil {
+		in, out := &in.ProviderSelector, &out.ProviderSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -310,6 +337,13 @@ func (in *DNSEntryStatus) DeepCopyInto(out *DNSEntryStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AdditionalZones != nil {
+		in, out := &in.AdditionalZones, &out.AdditionalZones
+		*out = make([]DNSEntryZoneStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -323,6 +357,92 @@ func (in *DNSEntryStatus) DeepCopy() *DNSEntryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSEntryZoneStatus) DeepCopyInto(out *DNSEntryZoneStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.Targets != nil {
+		in, out := &in.Targets, &out.Targets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSEntryZoneStatus.
+func (in *DNSEntryZoneStatus) DeepCopy() *DNSEntryZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSEntryZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZone) DeepCopyInto(out *DNSHostedZone) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZone.
+func (in *DNSHostedZone) DeepCopy() *DNSHostedZone {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZone)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZone) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneList) DeepCopyInto(out *DNSHostedZoneList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSHostedZone, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneList.
+func (in *DNSHostedZoneList) DeepCopy() *DNSHostedZoneList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZoneList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSHostedZonePolicy) DeepCopyInto(out *DNSHostedZonePolicy) {
 	*out = *in
@@ -437,6 +557,154 @@ func (in *DNSHostedZonePolicyStatus) DeepCopy() *DNSHostedZonePolicyStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneRequest) DeepCopyInto(out *DNSHostedZoneRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneRequest.
+func (in *DNSHostedZoneRequest) DeepCopy() *DNSHostedZoneRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZoneRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneRequestList) DeepCopyInto(out *DNSHostedZoneRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSHostedZoneRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneRequestList.
+func (in *DNSHostedZoneRequestList) DeepCopy() *DNSHostedZoneRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSHostedZoneRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneRequestSpec) DeepCopyInto(out *DNSHostedZoneRequestSpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	if in.DelegationTTL != nil {
+		in, out := &in.DelegationTTL, &out.DelegationTTL
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneRequestSpec.
+func (in *DNSHostedZoneRequestSpec) DeepCopy() *DNSHostedZoneRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneRequestStatus) DeepCopyInto(out *DNSHostedZoneRequestStatus) {
+	*out = *in
+	if in.Message != nil {
+		in, out := &in.Message, &out.Message
+		*out = new(string)
+		**out = **in
+	}
+	if in.NameServers != nil {
+		in, out := &in.NameServers, &out.NameServers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneRequestStatus.
+func (in *DNSHostedZoneRequestStatus) DeepCopy() *DNSHostedZoneRequestStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneRequestStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSHostedZoneStatus) DeepCopyInto(out *DNSHostedZoneStatus) {
+	*out = *in
+	if in.ForwardedDomains != nil {
+		in, out := &in.ForwardedDomains, &out.ForwardedDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RecordCount != nil {
+		in, out := &in.RecordCount, &out.RecordCount
+		*out = new(int)
+		**out = **in
+	}
+	if in.LastFullReadTime != nil {
+		in, out := &in.LastFullReadTime, &out.LastFullReadTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastError != nil {
+		in, out := &in.LastError, &out.LastError
+		*out = new(string)
+		**out = **in
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSHostedZoneStatus.
+func (in *DNSHostedZoneStatus) DeepCopy() *DNSHostedZoneStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSHostedZoneStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSLock) DeepCopyInto(out *DNSLock) {
 	*out = *in
@@ -592,6 +860,27 @@ func (in *DNSOwner) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSOwnerAdoptionStatus) DeepCopyInto(out *DNSOwnerAdoptionStatus) {
+	*out = *in
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSOwnerAdoptionStatus.
+func (in *DNSOwnerAdoptionStatus) DeepCopy() *DNSOwnerAdoptionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSOwnerAdoptionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSOwnerList) DeepCopyInto(out *DNSOwnerList) {
 	*out = *in
@@ -642,6 +931,11 @@ func (in *DNSOwnerSpec) DeepCopyInto(out *DNSOwnerSpec) {
 		in, out := &in.ValidUntil, &out.ValidUntil
 		*out = (*in).DeepCopy()
 	}
+	if in.AdoptOwnerIds != nil {
+		in, out := &in.AdoptOwnerIds, &out.AdoptOwnerIds
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -664,6 +958,11 @@ func (in *DNSOwnerStatus) DeepCopyInto(out *DNSOwnerStatus) {
 		**out = **in
 	}
 	in.Entries.DeepCopyInto(&out.Entries)
+	if in.Adoption != nil {
+		in, out := &in.Adoption, &out.Adoption
+		*out = new(DNSOwnerAdoptionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -771,7 +1070,7 @@ func (in *DNSProviderSpec) DeepCopyInto(out *DNSProviderSpec) {
 	}
 	if in.SecretRef != nil {
 		in, out := &in.SecretRef, &out.SecretRef
-		*out = new(v1.SecretReference)
+		*out = new(corev1.SecretReference)
 		**out = **in
 	}
 	if in.Domains != nil {
@@ -831,6 +1130,13 @@ func (in *DNSProviderStatus) DeepCopyInto(out *DNSProviderStatus) {
 		*out = new(RateLimit)
 		**out = **in
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -844,6 +1150,33 @@ func (in *DNSProviderStatus) DeepCopy() *DNSProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSRecordConflict) DeepCopyInto(out *DNSRecordConflict) {
+	*out = *in
+	if in.InactiveOwners != nil {
+		in, out := &in.InactiveOwners, &out.InactiveOwners
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Entries != nil {
+		in, out := &in.Entries, &out.Entries
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.Since.DeepCopyInto(&out.Since)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSRecordConflict.
+func (in *DNSRecordConflict) DeepCopy() *DNSRecordConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSRecordConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *DNSSelection) DeepCopyInto(out *DNSSelection) {
 	*out = *in
@@ -896,6 +1229,93 @@ func (in *DNSSelectionStatus) DeepCopy() *DNSSelectionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneConflictReport) DeepCopyInto(out *DNSZoneConflictReport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Report.DeepCopyInto(&out.Report)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneConflictReport.
+func (in *DNSZoneConflictReport) DeepCopy() *DNSZoneConflictReport {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneConflictReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneConflictReport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneConflictReportData) DeepCopyInto(out *DNSZoneConflictReportData) {
+	*out = *in
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]DNSRecordConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneConflictReportData.
+func (in *DNSZoneConflictReportData) DeepCopy() *DNSZoneConflictReportData {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneConflictReportData)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DNSZoneConflictReportList) DeepCopyInto(out *DNSZoneConflictReportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DNSZoneConflictReport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DNSZoneConflictReportList.
+func (in *DNSZoneConflictReportList) DeepCopy() *DNSZoneConflictReportList {
+	if in == nil {
+		return nil
+	}
+	out := new(DNSZoneConflictReportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DNSZoneConflictReportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EntryReference) DeepCopyInto(out *EntryReference) {
 	*out = *in
@@ -912,6 +1332,39 @@ func (in *EntryReference) DeepCopy() *EntryReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *IssuedCertificate) DeepCopyInto(out *IssuedCertificate) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new IssuedCertificate.
+func (in *IssuedCertificate) DeepCopy() *IssuedCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(IssuedCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProviderReference) DeepCopyInto(out *ProviderReference) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProviderReference.
+func (in *ProviderReference) DeepCopy() *ProviderReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ProviderReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RateLimit) DeepCopyInto(out *RateLimit) {
 	*out = *in
@@ -933,7 +1386,7 @@ func (in *RemoteAccessCertificate) DeepCopyInto(out *RemoteAccessCertificate) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 	return
 }
@@ -992,6 +1445,11 @@ func (in *RemoteAccessCertificateList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemoteAccessCertificateSpec) DeepCopyInto(out *RemoteAccessCertificateSpec) {
 	*out = *in
+	if in.RenewBefore != nil {
+		in, out := &in.RenewBefore, &out.RenewBefore
+		*out = new(v1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -1021,6 +1479,17 @@ func (in *RemoteAccessCertificateStatus) DeepCopyInto(out *RemoteAccessCertifica
 		*out = new(string)
 		**out = **in
 	}
+	if in.RenewalTime != nil {
+		in, out := &in.RenewalTime, &out.RenewalTime
+		*out = (*in).DeepCopy()
+	}
+	if in.IssuedCertificates != nil {
+		in, out := &in.IssuedCertificates, &out.IssuedCertificates
+		*out = make([]IssuedCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -1071,7 +1540,12 @@ func (in *ZonePolicy) DeepCopyInto(out *ZonePolicy) {
 	*out = *in
 	if in.ZoneStateCacheTTL != nil {
 		in, out := &in.ZoneStateCacheTTL, &out.ZoneStateCacheTTL
-		*out = new(metav1.Duration)
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.DriftPolicy != nil {
+		in, out := &in.DriftPolicy, &out.DriftPolicy
+		*out = new(string)
 		**out = **in
 	}
 	return
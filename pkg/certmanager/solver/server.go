@@ -0,0 +1,185 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package solver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Handler serves the solver as aggregated API (group <groupName>, version v1alpha1,
+// resource <solverName>) called by cert-manager via the kube-apiserver.
+type Handler struct {
+	logger       logger.LogContext
+	groupName    string
+	solverName   string
+	solver       *Solver
+	clientAuth   bool
+	allowedNames utils.StringSet
+}
+
+var _ http.Handler = &Handler{}
+
+// NewHandler creates the HTTP handler for the solver API. With client authentication,
+// only requests proxied by the kube-apiserver with a verified client certificate are
+// accepted. If allowed names are given, the common name of the certificate must be one
+// of them. The certificate itself must be verified by the TLS configuration of the server.
+func NewHandler(logger logger.LogContext, groupName, solverName string, solver *Solver, clientAuth bool, allowedNames ...string) *Handler {
+	return &Handler{
+		logger:       logger,
+		groupName:    groupName,
+		solverName:   solverName,
+		solver:       solver,
+		clientAuth:   clientAuth,
+		allowedNames: utils.NewStringSet(allowedNames...),
+	}
+}
+
+func (this *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == "/healthz" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	if err := this.authenticate(r); err != nil {
+		this.logger.Warnf("rejecting request %s %s: %s", r.Method, r.URL.Path, err)
+		this.writeStatus(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	groupVersion := this.groupName + "/" + SolverVersion
+	switch path {
+	case "/apis":
+		this.writeJSON(w, http.StatusOK, &metav1.APIGroupList{
+			TypeMeta: metav1.TypeMeta{Kind: "APIGroupList", APIVersion: "v1"},
+			Groups:   []metav1.APIGroup{this.apiGroup()},
+		})
+	case "/apis/" + this.groupName:
+		group := this.apiGroup()
+		group.TypeMeta = metav1.TypeMeta{Kind: "APIGroup", APIVersion: "v1"}
+		this.writeJSON(w, http.StatusOK, &group)
+	case "/apis/" + groupVersion:
+		this.writeJSON(w, http.StatusOK, &metav1.APIResourceList{
+			TypeMeta:     metav1.TypeMeta{Kind: "APIResourceList", APIVersion: "v1"},
+			GroupVersion: groupVersion,
+			APIResources: []metav1.APIResource{{
+				Name:         this.solverName,
+				SingularName: this.solverName,
+				Namespaced:   false,
+				Kind:         PayloadKind,
+				Verbs:        metav1.Verbs{"create"},
+			}},
+		})
+	case "/apis/" + groupVersion + "/" + this.solverName:
+		if r.Method != http.MethodPost {
+			this.writeStatus(w, http.StatusMethodNotAllowed, fmt.Sprintf("method %s not allowed", r.Method))
+			return
+		}
+		this.handleChallenge(w, r)
+	default:
+		this.writeStatus(w, http.StatusNotFound, fmt.Sprintf("%s not found", r.URL.Path))
+	}
+}
+
+func (this *Handler) apiGroup() metav1.APIGroup {
+	version := metav1.GroupVersionForDiscovery{
+		GroupVersion: this.groupName + "/" + SolverVersion,
+		Version:      SolverVersion,
+	}
+	return metav1.APIGroup{
+		Name:             this.groupName,
+		Versions:         []metav1.GroupVersionForDiscovery{version},
+		PreferredVersion: version,
+	}
+}
+
+// authenticate checks the client certificate of the kube-apiserver proxying the request.
+func (this *Handler) authenticate(r *http.Request) error {
+	if !this.clientAuth {
+		return nil
+	}
+	if r.TLS == nil || len(r.TLS.VerifiedChains) == 0 {
+		return fmt.Errorf("no verified client certificate")
+	}
+	cn := r.TLS.VerifiedChains[0][0].Subject.CommonName
+	if len(this.allowedNames) > 0 && !this.allowedNames.Contains(cn) {
+		return fmt.Errorf("client certificate common name %q not allowed", cn)
+	}
+	return nil
+}
+
+func (this *Handler) handleChallenge(w http.ResponseWriter, r *http.Request) {
+	payload := &ChallengePayload{}
+	if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+		this.writeStatus(w, http.StatusBadRequest, fmt.Sprintf("invalid challenge payload: %s", err))
+		return
+	}
+	if payload.Request == nil {
+		this.writeStatus(w, http.StatusBadRequest, "challenge payload without request")
+		return
+	}
+	req := payload.Request
+	logger := this.logger.NewContext("challenge", string(req.UID))
+	logger.Infof("%s challenge for %s (%s)", req.Action, req.DNSName, challengeFQDN(req))
+
+	var err error
+	switch req.Action {
+	case ActionPresent:
+		err = this.solver.Present(r.Context(), logger, req)
+	case ActionCleanUp:
+		err = this.solver.CleanUp(r.Context(), logger, req)
+	default:
+		err = fmt.Errorf("unknown action %q", req.Action)
+	}
+
+	response := &ChallengeResponse{UID: req.UID, Success: err == nil}
+	if err != nil {
+		logger.Errorf("%s challenge for %s failed: %s", req.Action, req.DNSName, err)
+		response.Result = &metav1.Status{
+			Status:  metav1.StatusFailure,
+			Message: err.Error(),
+			Reason:  metav1.StatusReasonInternalError,
+			Code:    http.StatusInternalServerError,
+		}
+	}
+	payload.Response = response
+	payload.TypeMeta = metav1.TypeMeta{Kind: PayloadKind, APIVersion: PayloadAPIVersion}
+	this.writeJSON(w, http.StatusCreated, payload)
+}
+
+func (this *Handler) writeStatus(w http.ResponseWriter, code int, msg string) {
+	this.writeJSON(w, code, &metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  msg,
+		Code:     int32(code),
+	})
+}
+
+func (this *Handler) writeJSON(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		this.logger.Warnf("cannot write response: %s", err)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package solver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	// LabelChallenge marks the DNSEntries managed by the solver.
+	LabelChallenge = dns.ANNOTATION_GROUP + "/acme-challenge"
+	// AnnotationFQDN contains the challenge record name of a DNSEntry managed by the solver.
+	AnnotationFQDN = dns.ANNOTATION_GROUP + "/acme-challenge-fqdn"
+
+	defaultTTL = 60
+	maxRetries = 10
+)
+
+// Solver presents DNS-01 challenges by maintaining TXT DNSEntries. The records are
+// provisioned by the DNS controller with the credentials of the responsible DNSProvider.
+// All challenge keys for the same record name are kept in a single entry, as ACME
+// may require several TXT values at the same time (e.g. for a domain and its wildcard).
+// The entries are shared, so that challenges for the same record name presented in
+// different namespaces contribute to the same TXT record set.
+type Solver struct {
+	client                   versioned.Interface
+	clusterResourceNamespace string
+}
+
+// New creates a solver using the given client to maintain DNSEntries. The cluster
+// resource namespace is the namespace cert-manager uses as resource namespace for
+// challenges of ClusterIssuers.
+func New(client versioned.Interface, clusterResourceNamespace string) *Solver {
+	return &Solver{client: client, clusterResourceNamespace: clusterResourceNamespace}
+}
+
+// Present adds the key of a challenge to the TXT entry of its record name.
+func (this *Solver) Present(ctx context.Context, logger logger.LogContext, req *ChallengeRequest) error {
+	cfg, err := parseConfig(req)
+	if err != nil {
+		return err
+	}
+	namespace, name, fqdn := this.entryName(cfg, req)
+	entries := this.client.DnsV1alpha1().DNSEntries(namespace)
+	for i := 0; i < maxRetries; i++ {
+		entry, err := entries.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			entry = newEntry(cfg, namespace, name, fqdn, req.Key)
+			_, err = entries.Create(ctx, entry, metav1.CreateOptions{})
+			if errors.IsAlreadyExists(err) {
+				continue
+			}
+			if err == nil {
+				logger.Infof("created challenge entry %s/%s for %s", namespace, name, fqdn)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+		if entry.DeletionTimestamp != nil {
+			return fmt.Errorf("challenge entry %s/%s is being deleted", namespace, name)
+		}
+		if contains(entry.Spec.Text, req.Key) && isShared(entry) {
+			return nil
+		}
+		if !contains(entry.Spec.Text, req.Key) {
+			entry.Spec.Text = append(entry.Spec.Text, req.Key)
+		}
+		shared := true
+		entry.Spec.Shared = &shared
+		_, err = entries.Update(ctx, entry, metav1.UpdateOptions{})
+		if errors.IsConflict(err) {
+			continue
+		}
+		if err == nil {
+			logger.Infof("added key to challenge entry %s/%s for %s (%d keys)", namespace, name, fqdn, len(entry.Spec.Text))
+		}
+		return err
+	}
+	return fmt.Errorf("too many conflicts updating challenge entry %s/%s", namespace, name)
+}
+
+// CleanUp removes the key of a challenge from the TXT entry of its record name.
+// The entry is deleted together with the last key.
+func (this *Solver) CleanUp(ctx context.Context, logger logger.LogContext, req *ChallengeRequest) error {
+	cfg, err := parseConfig(req)
+	if err != nil {
+		return err
+	}
+	namespace, name, fqdn := this.entryName(cfg, req)
+	entries := this.client.DnsV1alpha1().DNSEntries(namespace)
+	for i := 0; i < maxRetries; i++ {
+		entry, err := entries.Get(ctx, name, metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if !contains(entry.Spec.Text, req.Key) {
+			return nil
+		}
+		if len(entry.Spec.Text) == 1 {
+			rv := entry.ResourceVersion
+			err = entries.Delete(ctx, name, metav1.DeleteOptions{Preconditions: &metav1.Preconditions{ResourceVersion: &rv}})
+			if errors.IsConflict(err) {
+				continue
+			}
+			if err == nil || errors.IsNotFound(err) {
+				logger.Infof("deleted challenge entry %s/%s for %s", namespace, name, fqdn)
+				return nil
+			}
+			return err
+		}
+		entry.Spec.Text = remove(entry.Spec.Text, req.Key)
+		_, err = entries.Update(ctx, entry, metav1.UpdateOptions{})
+		if errors.IsConflict(err) {
+			continue
+		}
+		if err == nil {
+			logger.Infof("removed key from challenge entry %s/%s for %s (%d keys)", namespace, name, fqdn, len(entry.Spec.Text))
+		}
+		return err
+	}
+	return fmt.Errorf("too many conflicts updating challenge entry %s/%s", namespace, name)
+}
+
+// entryName returns namespace and name of the entry for the record name of a challenge.
+// The name is derived from the record name, so that all keys for it end up in the same entry.
+// The namespace of the solver config is only used for challenges of ClusterIssuers, challenges
+// of namespaced Issuers always use the resource namespace of the challenge.
+func (this *Solver) entryName(cfg *SolverConfig, req *ChallengeRequest) (string, string, string) {
+	namespace := req.ResourceNamespace
+	if cfg.Namespace != "" && req.ResourceNamespace == this.clusterResourceNamespace {
+		namespace = cfg.Namespace
+	}
+	fqdn := challengeFQDN(req)
+	sum := sha256.Sum256([]byte(fqdn))
+	return namespace, "acme-challenge-" + hex.EncodeToString(sum[:])[:16], fqdn
+}
+
+func challengeFQDN(req *ChallengeRequest) string {
+	fqdn := req.ResolvedFQDN
+	if fqdn == "" {
+		fqdn = "_acme-challenge." + strings.TrimPrefix(req.DNSName, "*.")
+	}
+	return strings.ToLower(strings.TrimSuffix(fqdn, "."))
+}
+
+func newEntry(cfg *SolverConfig, namespace, name, fqdn, key string) *api.DNSEntry {
+	ttl := int64(defaultTTL)
+	shared := true
+	if cfg.TTL != nil {
+		ttl = *cfg.TTL
+	}
+	entry := &api.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{LabelChallenge: "true"},
+			Annotations: map[string]string{
+				AnnotationFQDN:                  fqdn,
+				dns.NOT_RATE_LIMITED_ANNOTATION: "true",
+			},
+		},
+		Spec: api.DNSEntrySpec{
+			DNSName: fqdn,
+			OwnerId: cfg.OwnerId,
+			TTL:     &ttl,
+			Text:    []string{key},
+			Shared:  &shared,
+		},
+	}
+	if cfg.Class != "" {
+		entry.Annotations[dns.CLASS_ANNOTATION] = cfg.Class
+	}
+	return entry
+}
+
+func parseConfig(req *ChallengeRequest) (*SolverConfig, error) {
+	cfg := &SolverConfig{}
+	if req.Config == nil {
+		return cfg, nil
+	}
+	if err := json.Unmarshal(*req.Config, cfg); err != nil {
+		return nil, fmt.Errorf("invalid solver config: %s", err)
+	}
+	if cfg.TTL != nil && *cfg.TTL <= 0 {
+		return nil, fmt.Errorf("invalid solver config: ttl must be greater than zero")
+	}
+	return cfg, nil
+}
+
+func isShared(entry *api.DNSEntry) bool {
+	return entry.Spec.Shared != nil && *entry.Spec.Shared
+}
+
+func contains(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func remove(list []string, value string) []string {
+	var result []string
+	for _, v := range list {
+		if v != value {
+			result = append(result, v)
+		}
+	}
+	return result
+}
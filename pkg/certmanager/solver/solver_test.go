@@ -0,0 +1,203 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package solver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/fake"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func challenge(action, key string) *ChallengeRequest {
+	config := json.RawMessage(`{"class":"gardendns","ttl":30}`)
+	return &ChallengeRequest{
+		UID:               "uid",
+		Action:            action,
+		Type:              "dns-01",
+		DNSName:           "*.example.com",
+		Key:               key,
+		ResourceNamespace: "cert-manager",
+		ResolvedFQDN:      "_acme-challenge.example.com.",
+		ResolvedZone:      "example.com.",
+		Config:            &config,
+	}
+}
+
+func TestPresentAndCleanUpSharedRecordName(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	solver := New(client, "cert-manager")
+	ctx := context.Background()
+	log := logger.New()
+
+	for _, key := range []string{"key1", "key2", "key1"} {
+		if err := solver.Present(ctx, log, challenge(ActionPresent, key)); err != nil {
+			t.Fatalf("present %s failed: %s", key, err)
+		}
+	}
+	list, err := client.DnsV1alpha1().DNSEntries("cert-manager").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("expected one entry, got %d", len(list.Items))
+	}
+	entry := list.Items[0]
+	if entry.Spec.DNSName != "_acme-challenge.example.com" {
+		t.Errorf("unexpected dns name %q", entry.Spec.DNSName)
+	}
+	if !reflect.DeepEqual(entry.Spec.Text, []string{"key1", "key2"}) {
+		t.Errorf("unexpected text %v", entry.Spec.Text)
+	}
+	if entry.Spec.TTL == nil || *entry.Spec.TTL != 30 {
+		t.Errorf("unexpected ttl %v", entry.Spec.TTL)
+	}
+	if entry.Annotations[dns.CLASS_ANNOTATION] != "gardendns" {
+		t.Errorf("missing class annotation")
+	}
+	if entry.Spec.Shared == nil || !*entry.Spec.Shared {
+		t.Errorf("entry not shared")
+	}
+
+	if err := solver.CleanUp(ctx, log, challenge(ActionCleanUp, "key1")); err != nil {
+		t.Fatalf("cleanup failed: %s", err)
+	}
+	current, err := client.DnsV1alpha1().DNSEntries("cert-manager").Get(ctx, entry.Name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(current.Spec.Text, []string{"key2"}) {
+		t.Errorf("unexpected text after cleanup %v", current.Spec.Text)
+	}
+
+	if err := solver.CleanUp(ctx, log, challenge(ActionCleanUp, "key2")); err != nil {
+		t.Fatalf("cleanup failed: %s", err)
+	}
+	if _, err := client.DnsV1alpha1().DNSEntries("cert-manager").Get(ctx, entry.Name, metav1.GetOptions{}); !errors.IsNotFound(err) {
+		t.Errorf("expected entry to be deleted, got %v", err)
+	}
+	if err := solver.CleanUp(ctx, log, challenge(ActionCleanUp, "key2")); err != nil {
+		t.Errorf("repeated cleanup failed: %s", err)
+	}
+}
+
+func TestEntryNamespace(t *testing.T) {
+	solver := New(fake.NewSimpleClientset(), "cert-manager")
+	withNamespace := func(resourceNamespace string) *ChallengeRequest {
+		req := challenge(ActionPresent, "key1")
+		req.ResourceNamespace = resourceNamespace
+		return req
+	}
+	for _, c := range []struct {
+		name      string
+		cfg       *SolverConfig
+		req       *ChallengeRequest
+		namespace string
+	}{
+		{name: "cluster issuer", cfg: &SolverConfig{Namespace: "dns"}, req: withNamespace("cert-manager"), namespace: "dns"},
+		{name: "cluster issuer without namespace", cfg: &SolverConfig{}, req: withNamespace("cert-manager"), namespace: "cert-manager"},
+		{name: "issuer", cfg: &SolverConfig{Namespace: "dns"}, req: withNamespace("team-a"), namespace: "team-a"},
+	} {
+		namespace, _, _ := solver.entryName(c.cfg, c.req)
+		if namespace != c.namespace {
+			t.Errorf("%s: expected namespace %q, got %q", c.name, c.namespace, namespace)
+		}
+	}
+}
+
+func TestPresentSharesExistingEntry(t *testing.T) {
+	client := fake.NewSimpleClientset()
+	solver := New(client, "cert-manager")
+	ctx := context.Background()
+	log := logger.New()
+
+	req := challenge(ActionPresent, "key1")
+	namespace, name, fqdn := solver.entryName(&SolverConfig{}, req)
+	entry := newEntry(&SolverConfig{}, namespace, name, fqdn, "key1")
+	entry.Spec.Shared = nil
+	if _, err := client.DnsV1alpha1().DNSEntries(namespace).Create(ctx, entry, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := solver.Present(ctx, log, req); err != nil {
+		t.Fatal(err)
+	}
+	current, err := client.DnsV1alpha1().DNSEntries(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if current.Spec.Shared == nil || !*current.Spec.Shared || !reflect.DeepEqual(current.Spec.Text, []string{"key1"}) {
+		t.Errorf("unexpected spec %#v", current.Spec)
+	}
+}
+
+func TestHandlerChallenge(t *testing.T) {
+	handler := NewHandler(logger.New(), "acme.example.com", "gardener-dns", New(fake.NewSimpleClientset(), "cert-manager"), false)
+
+	body, _ := json.Marshal(&ChallengePayload{
+		TypeMeta: metav1.TypeMeta{Kind: PayloadKind, APIVersion: PayloadAPIVersion},
+		Request:  challenge(ActionPresent, "key1"),
+	})
+	req := httptest.NewRequest(http.MethodPost, "/apis/acme.example.com/v1alpha1/gardener-dns", bytes.NewReader(body))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("unexpected status code %d", rec.Code)
+	}
+	result := &ChallengePayload{}
+	if err := json.Unmarshal(rec.Body.Bytes(), result); err != nil {
+		t.Fatal(err)
+	}
+	if result.Response == nil || !result.Response.Success || result.Response.UID != "uid" {
+		t.Errorf("unexpected response %#v", result.Response)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/apis/acme.example.com/v1alpha1", nil))
+	resources := &metav1.APIResourceList{}
+	if err := json.Unmarshal(rec.Body.Bytes(), resources); err != nil {
+		t.Fatal(err)
+	}
+	if len(resources.APIResources) != 1 || resources.APIResources[0].Name != "gardener-dns" {
+		t.Errorf("unexpected discovery %#v", resources)
+	}
+}
+
+func TestHandlerClientAuthentication(t *testing.T) {
+	handler := NewHandler(logger.New(), "acme.example.com", "gardener-dns", New(fake.NewSimpleClientset(), "cert-manager"), true)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/apis/acme.example.com/v1alpha1/gardener-dns", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected request without client certificate to be rejected, got status code %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("unexpected health status code %d", rec.Code)
+	}
+}
@@ -0,0 +1,82 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package solver
+
+import (
+	"encoding/json"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// The types in this file mirror the ChallengePayload API (acme.cert-manager.io/v1alpha1)
+// used by cert-manager to call external DNS-01 webhook solvers.
+
+const (
+	// PayloadAPIVersion is the API version of the challenge payload.
+	PayloadAPIVersion = "acme.cert-manager.io/v1alpha1"
+	// PayloadKind is the kind of the challenge payload.
+	PayloadKind = "ChallengePayload"
+
+	// SolverVersion is the version of the API group served for the solver.
+	SolverVersion = "v1alpha1"
+
+	ActionPresent = "Present"
+	ActionCleanUp = "CleanUp"
+)
+
+// ChallengePayload is sent by cert-manager to present or clean up a challenge.
+// The response is returned in the same object.
+type ChallengePayload struct {
+	metav1.TypeMeta `json:",inline"`
+
+	Request  *ChallengeRequest  `json:"request,omitempty"`
+	Response *ChallengeResponse `json:"response,omitempty"`
+}
+
+// ChallengeRequest describes a DNS-01 challenge to be presented or cleaned up.
+type ChallengeRequest struct {
+	UID               types.UID        `json:"uid"`
+	Action            string           `json:"action"`
+	Type              string           `json:"type"`
+	DNSName           string           `json:"dnsName"`
+	Key               string           `json:"key"`
+	ResourceNamespace string           `json:"resourceNamespace"`
+	ResolvedFQDN      string           `json:"resolvedFQDN,omitempty"`
+	ResolvedZone      string           `json:"resolvedZone,omitempty"`
+	Config            *json.RawMessage `json:"config,omitempty"`
+}
+
+// ChallengeResponse reports the result of a challenge request.
+type ChallengeResponse struct {
+	UID     types.UID      `json:"uid"`
+	Success bool           `json:"success"`
+	Result  *metav1.Status `json:"status,omitempty"`
+}
+
+// SolverConfig is the solver configuration of the issuer (field config of the webhook solver).
+type SolverConfig struct {
+	// Namespace of the challenge DNSEntries (default: resource namespace of the challenge).
+	// It is only used for ClusterIssuers, entries for Issuers are always created in their namespace.
+	Namespace string `json:"namespace,omitempty"`
+	// Class is the dns class of the challenge DNSEntries
+	Class string `json:"class,omitempty"`
+	// OwnerId is the owner id of the challenge DNSEntries
+	OwnerId *string `json:"ownerId,omitempty"`
+	// TTL of the TXT records
+	TTL *int64 `json:"ttl,omitempty"`
+}
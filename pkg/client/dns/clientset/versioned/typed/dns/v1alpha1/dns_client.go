@@ -28,10 +28,13 @@ type DnsV1alpha1Interface interface {
 	RESTClient() rest.Interface
 	DNSAnnotationsGetter
 	DNSEntriesGetter
+	DNSHostedZonesGetter
 	DNSHostedZonePoliciesGetter
+	DNSHostedZoneRequestsGetter
 	DNSLocksGetter
 	DNSOwnersGetter
 	DNSProvidersGetter
+	DNSZoneConflictReportsGetter
 	RemoteAccessCertificatesGetter
 }
 
@@ -48,10 +51,18 @@ func (c *DnsV1alpha1Client) DNSEntries(namespace string) DNSEntryInterface {
 	return newDNSEntries(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSHostedZones(namespace string) DNSHostedZoneInterface {
+	return newDNSHostedZones(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) DNSHostedZonePolicies(namespace string) DNSHostedZonePolicyInterface {
 	return newDNSHostedZonePolicies(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSHostedZoneRequests(namespace string) DNSHostedZoneRequestInterface {
+	return newDNSHostedZoneRequests(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) DNSLocks(namespace string) DNSLockInterface {
 	return newDNSLocks(c, namespace)
 }
@@ -64,6 +75,10 @@ func (c *DnsV1alpha1Client) DNSProviders(namespace string) DNSProviderInterface
 	return newDNSProviders(c, namespace)
 }
 
+func (c *DnsV1alpha1Client) DNSZoneConflictReports(namespace string) DNSZoneConflictReportInterface {
+	return newDNSZoneConflictReports(c, namespace)
+}
+
 func (c *DnsV1alpha1Client) RemoteAccessCertificates(namespace string) RemoteAccessCertificateInterface {
 	return newRemoteAccessCertificates(c, namespace)
 }
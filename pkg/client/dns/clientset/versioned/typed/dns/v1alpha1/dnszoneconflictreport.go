@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	scheme "github.com/gardener/external-dns-management/pkg/client/dns/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// DNSZoneConflictReportsGetter has a method to return a DNSZoneConflictReportInterface.
+// A group's client should implement this interface.
+type DNSZoneConflictReportsGetter interface {
+	DNSZoneConflictReports(namespace string) DNSZoneConflictReportInterface
+}
+
+// DNSZoneConflictReportInterface has methods to work with DNSZoneConflictReport resources.
+type DNSZoneConflictReportInterface interface {
+	Create(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.CreateOptions) (*v1alpha1.DNSZoneConflictReport, error)
+	Update(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.UpdateOptions) (*v1alpha1.DNSZoneConflictReport, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.DNSZoneConflictReport, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.DNSZoneConflictReportList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSZoneConflictReport, err error)
+	DNSZoneConflictReportExpansion
+}
+
+// dNSZoneConflictReports implements DNSZoneConflictReportInterface
+type dNSZoneConflictReports struct {
+	client rest.Interface
+	ns     string
+}
+
+// newDNSZoneConflictReports returns a DNSZoneConflictReports
+func newDNSZoneConflictReports(c *DnsV1alpha1Client, namespace string) *dNSZoneConflictReports {
+	return &dNSZoneConflictReports{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the dNSZoneConflictReport, and returns the corresponding dNSZoneConflictReport object, and an error if there is any.
+func (c *dNSZoneConflictReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	result = &v1alpha1.DNSZoneConflictReport{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of DNSZoneConflictReports that match those selectors.
+func (c *dNSZoneConflictReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DNSZoneConflictReportList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.DNSZoneConflictReportList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested dNSZoneConflictReports.
+func (c *dNSZoneConflictReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a dNSZoneConflictReport and creates it.  Returns the server's representation of the dNSZoneConflictReport, and an error, if there is any.
+func (c *dNSZoneConflictReports) Create(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.CreateOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	result = &v1alpha1.DNSZoneConflictReport{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(dNSZoneConflictReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a dNSZoneConflictReport and updates it. Returns the server's representation of the dNSZoneConflictReport, and an error, if there is any.
+func (c *dNSZoneConflictReports) Update(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.UpdateOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	result = &v1alpha1.DNSZoneConflictReport{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		Name(dNSZoneConflictReport.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(dNSZoneConflictReport).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the dNSZoneConflictReport and deletes it. Returns an error if one occurs.
+func (c *dNSZoneConflictReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *dNSZoneConflictReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched dNSZoneConflictReport.
+func (c *dNSZoneConflictReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	result = &v1alpha1.DNSZoneConflictReport{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("dnszoneconflictreports").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
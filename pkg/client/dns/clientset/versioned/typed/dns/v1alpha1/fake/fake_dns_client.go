@@ -36,10 +36,18 @@ func (c *FakeDnsV1alpha1) DNSEntries(namespace string) v1alpha1.DNSEntryInterfac
 	return &FakeDNSEntries{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSHostedZones(namespace string) v1alpha1.DNSHostedZoneInterface {
+	return &FakeDNSHostedZones{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) DNSHostedZonePolicies(namespace string) v1alpha1.DNSHostedZonePolicyInterface {
 	return &FakeDNSHostedZonePolicies{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSHostedZoneRequests(namespace string) v1alpha1.DNSHostedZoneRequestInterface {
+	return &FakeDNSHostedZoneRequests{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) DNSLocks(namespace string) v1alpha1.DNSLockInterface {
 	return &FakeDNSLocks{c, namespace}
 }
@@ -52,6 +60,10 @@ func (c *FakeDnsV1alpha1) DNSProviders(namespace string) v1alpha1.DNSProviderInt
 	return &FakeDNSProviders{c, namespace}
 }
 
+func (c *FakeDnsV1alpha1) DNSZoneConflictReports(namespace string) v1alpha1.DNSZoneConflictReportInterface {
+	return &FakeDNSZoneConflictReports{c, namespace}
+}
+
 func (c *FakeDnsV1alpha1) RemoteAccessCertificates(namespace string) v1alpha1.RemoteAccessCertificateInterface {
 	return &FakeRemoteAccessCertificates{c, namespace}
 }
@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSHostedZones implements DNSHostedZoneInterface
+type FakeDNSHostedZones struct {
+	Fake *FakeDnsV1alpha1
+	ns   string
+}
+
+var dnshostedzonesResource = schema.GroupVersionResource{Group: "dns.gardener.cloud", Version: "v1alpha1", Resource: "dnshostedzones"}
+
+var dnshostedzonesKind = schema.GroupVersionKind{Group: "dns.gardener.cloud", Version: "v1alpha1", Kind: "DNSHostedZone"}
+
+// Get takes name of the dNSHostedZone, and returns the corresponding dNSHostedZone object, and an error if there is any.
+func (c *FakeDNSHostedZones) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DNSHostedZone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dnshostedzonesResource, c.ns, name), &v1alpha1.DNSHostedZone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZone), err
+}
+
+// List takes label and field selectors, and returns the list of DNSHostedZones that match those selectors.
+func (c *FakeDNSHostedZones) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DNSHostedZoneList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dnshostedzonesResource, dnshostedzonesKind, c.ns, opts), &v1alpha1.DNSHostedZoneList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.DNSHostedZoneList{ListMeta: obj.(*v1alpha1.DNSHostedZoneList).ListMeta}
+	for _, item := range obj.(*v1alpha1.DNSHostedZoneList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSHostedZones.
+func (c *FakeDNSHostedZones) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dnshostedzonesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dNSHostedZone and creates it.  Returns the server's representation of the dNSHostedZone, and an error, if there is any.
+func (c *FakeDNSHostedZones) Create(ctx context.Context, dNSHostedZone *v1alpha1.DNSHostedZone, opts v1.CreateOptions) (result *v1alpha1.DNSHostedZone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dnshostedzonesResource, c.ns, dNSHostedZone), &v1alpha1.DNSHostedZone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZone), err
+}
+
+// Update takes the representation of a dNSHostedZone and updates it. Returns the server's representation of the dNSHostedZone, and an error, if there is any.
+func (c *FakeDNSHostedZones) Update(ctx context.Context, dNSHostedZone *v1alpha1.DNSHostedZone, opts v1.UpdateOptions) (result *v1alpha1.DNSHostedZone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dnshostedzonesResource, c.ns, dNSHostedZone), &v1alpha1.DNSHostedZone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZone), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDNSHostedZones) UpdateStatus(ctx context.Context, dNSHostedZone *v1alpha1.DNSHostedZone, opts v1.UpdateOptions) (*v1alpha1.DNSHostedZone, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(dnshostedzonesResource, "status", c.ns, dNSHostedZone), &v1alpha1.DNSHostedZone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZone), err
+}
+
+// Delete takes name of the dNSHostedZone and deletes it. Returns an error if one occurs.
+func (c *FakeDNSHostedZones) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(dnshostedzonesResource, c.ns, name), &v1alpha1.DNSHostedZone{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSHostedZones) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dnshostedzonesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.DNSHostedZoneList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSHostedZone.
+func (c *FakeDNSHostedZones) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSHostedZone, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnshostedzonesResource, c.ns, name, pt, data, subresources...), &v1alpha1.DNSHostedZone{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZone), err
+}
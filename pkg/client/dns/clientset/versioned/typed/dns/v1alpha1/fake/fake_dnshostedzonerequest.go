@@ -0,0 +1,142 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSHostedZoneRequests implements DNSHostedZoneRequestInterface
+type FakeDNSHostedZoneRequests struct {
+	Fake *FakeDnsV1alpha1
+	ns   string
+}
+
+var dnshostedzonerequestsResource = schema.GroupVersionResource{Group: "dns.gardener.cloud", Version: "v1alpha1", Resource: "dnshostedzonerequests"}
+
+var dnshostedzonerequestsKind = schema.GroupVersionKind{Group: "dns.gardener.cloud", Version: "v1alpha1", Kind: "DNSHostedZoneRequest"}
+
+// Get takes name of the dNSHostedZoneRequest, and returns the corresponding dNSHostedZoneRequest object, and an error if there is any.
+func (c *FakeDNSHostedZoneRequests) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DNSHostedZoneRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dnshostedzonerequestsResource, c.ns, name), &v1alpha1.DNSHostedZoneRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), err
+}
+
+// List takes label and field selectors, and returns the list of DNSHostedZoneRequests that match those selectors.
+func (c *FakeDNSHostedZoneRequests) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DNSHostedZoneRequestList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dnshostedzonerequestsResource, dnshostedzonerequestsKind, c.ns, opts), &v1alpha1.DNSHostedZoneRequestList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.DNSHostedZoneRequestList{ListMeta: obj.(*v1alpha1.DNSHostedZoneRequestList).ListMeta}
+	for _, item := range obj.(*v1alpha1.DNSHostedZoneRequestList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSHostedZoneRequests.
+func (c *FakeDNSHostedZoneRequests) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dnshostedzonerequestsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dNSHostedZoneRequest and creates it.  Returns the server's representation of the dNSHostedZoneRequest, and an error, if there is any.
+func (c *FakeDNSHostedZoneRequests) Create(ctx context.Context, dNSHostedZoneRequest *v1alpha1.DNSHostedZoneRequest, opts v1.CreateOptions) (result *v1alpha1.DNSHostedZoneRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dnshostedzonerequestsResource, c.ns, dNSHostedZoneRequest), &v1alpha1.DNSHostedZoneRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), err
+}
+
+// Update takes the representation of a dNSHostedZoneRequest and updates it. Returns the server's representation of the dNSHostedZoneRequest, and an error, if there is any.
+func (c *FakeDNSHostedZoneRequests) Update(ctx context.Context, dNSHostedZoneRequest *v1alpha1.DNSHostedZoneRequest, opts v1.UpdateOptions) (result *v1alpha1.DNSHostedZoneRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dnshostedzonerequestsResource, c.ns, dNSHostedZoneRequest), &v1alpha1.DNSHostedZoneRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeDNSHostedZoneRequests) UpdateStatus(ctx context.Context, dNSHostedZoneRequest *v1alpha1.DNSHostedZoneRequest, opts v1.UpdateOptions) (*v1alpha1.DNSHostedZoneRequest, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(dnshostedzonerequestsResource, "status", c.ns, dNSHostedZoneRequest), &v1alpha1.DNSHostedZoneRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), err
+}
+
+// Delete takes name of the dNSHostedZoneRequest and deletes it. Returns an error if one occurs.
+func (c *FakeDNSHostedZoneRequests) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(dnshostedzonerequestsResource, c.ns, name), &v1alpha1.DNSHostedZoneRequest{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSHostedZoneRequests) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dnshostedzonerequestsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.DNSHostedZoneRequestList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSHostedZoneRequest.
+func (c *FakeDNSHostedZoneRequests) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSHostedZoneRequest, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnshostedzonerequestsResource, c.ns, name, pt, data, subresources...), &v1alpha1.DNSHostedZoneRequest{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), err
+}
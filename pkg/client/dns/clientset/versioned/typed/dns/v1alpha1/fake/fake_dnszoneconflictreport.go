@@ -0,0 +1,130 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeDNSZoneConflictReports implements DNSZoneConflictReportInterface
+type FakeDNSZoneConflictReports struct {
+	Fake *FakeDnsV1alpha1
+	ns   string
+}
+
+var dnszoneconflictreportsResource = schema.GroupVersionResource{Group: "dns.gardener.cloud", Version: "v1alpha1", Resource: "dnszoneconflictreports"}
+
+var dnszoneconflictreportsKind = schema.GroupVersionKind{Group: "dns.gardener.cloud", Version: "v1alpha1", Kind: "DNSZoneConflictReport"}
+
+// Get takes name of the dNSZoneConflictReport, and returns the corresponding dNSZoneConflictReport object, and an error if there is any.
+func (c *FakeDNSZoneConflictReports) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(dnszoneconflictreportsResource, c.ns, name), &v1alpha1.DNSZoneConflictReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSZoneConflictReport), err
+}
+
+// List takes label and field selectors, and returns the list of DNSZoneConflictReports that match those selectors.
+func (c *FakeDNSZoneConflictReports) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.DNSZoneConflictReportList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(dnszoneconflictreportsResource, dnszoneconflictreportsKind, c.ns, opts), &v1alpha1.DNSZoneConflictReportList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.DNSZoneConflictReportList{ListMeta: obj.(*v1alpha1.DNSZoneConflictReportList).ListMeta}
+	for _, item := range obj.(*v1alpha1.DNSZoneConflictReportList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested dNSZoneConflictReports.
+func (c *FakeDNSZoneConflictReports) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(dnszoneconflictreportsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a dNSZoneConflictReport and creates it.  Returns the server's representation of the dNSZoneConflictReport, and an error, if there is any.
+func (c *FakeDNSZoneConflictReports) Create(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.CreateOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(dnszoneconflictreportsResource, c.ns, dNSZoneConflictReport), &v1alpha1.DNSZoneConflictReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSZoneConflictReport), err
+}
+
+// Update takes the representation of a dNSZoneConflictReport and updates it. Returns the server's representation of the dNSZoneConflictReport, and an error, if there is any.
+func (c *FakeDNSZoneConflictReports) Update(ctx context.Context, dNSZoneConflictReport *v1alpha1.DNSZoneConflictReport, opts v1.UpdateOptions) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(dnszoneconflictreportsResource, c.ns, dNSZoneConflictReport), &v1alpha1.DNSZoneConflictReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSZoneConflictReport), err
+}
+
+// Delete takes name of the dNSZoneConflictReport and deletes it. Returns an error if one occurs.
+func (c *FakeDNSZoneConflictReports) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(dnszoneconflictreportsResource, c.ns, name), &v1alpha1.DNSZoneConflictReport{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeDNSZoneConflictReports) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(dnszoneconflictreportsResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.DNSZoneConflictReportList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched dNSZoneConflictReport.
+func (c *FakeDNSZoneConflictReports) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.DNSZoneConflictReport, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(dnszoneconflictreportsResource, c.ns, name, pt, data, subresources...), &v1alpha1.DNSZoneConflictReport{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.DNSZoneConflictReport), err
+}
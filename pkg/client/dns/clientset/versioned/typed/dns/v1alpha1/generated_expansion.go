@@ -22,12 +22,18 @@ type DNSAnnotationExpansion interface{}
 
 type DNSEntryExpansion interface{}
 
+type DNSHostedZoneExpansion interface{}
+
 type DNSHostedZonePolicyExpansion interface{}
 
+type DNSHostedZoneRequestExpansion interface{}
+
 type DNSLockExpansion interface{}
 
 type DNSOwnerExpansion interface{}
 
 type DNSProviderExpansion interface{}
 
+type DNSZoneConflictReportExpansion interface{}
+
 type RemoteAccessCertificateExpansion interface{}
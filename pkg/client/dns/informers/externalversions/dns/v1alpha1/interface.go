@@ -28,14 +28,20 @@ type Interface interface {
 	DNSAnnotations() DNSAnnotationInformer
 	// DNSEntries returns a DNSEntryInformer.
 	DNSEntries() DNSEntryInformer
+	// DNSHostedZones returns a DNSHostedZoneInformer.
+	DNSHostedZones() DNSHostedZoneInformer
 	// DNSHostedZonePolicies returns a DNSHostedZonePolicyInformer.
 	DNSHostedZonePolicies() DNSHostedZonePolicyInformer
+	// DNSHostedZoneRequests returns a DNSHostedZoneRequestInformer.
+	DNSHostedZoneRequests() DNSHostedZoneRequestInformer
 	// DNSLocks returns a DNSLockInformer.
 	DNSLocks() DNSLockInformer
 	// DNSOwners returns a DNSOwnerInformer.
 	DNSOwners() DNSOwnerInformer
 	// DNSProviders returns a DNSProviderInformer.
 	DNSProviders() DNSProviderInformer
+	// DNSZoneConflictReports returns a DNSZoneConflictReportInformer.
+	DNSZoneConflictReports() DNSZoneConflictReportInformer
 	// RemoteAccessCertificates returns a RemoteAccessCertificateInformer.
 	RemoteAccessCertificates() RemoteAccessCertificateInformer
 }
@@ -61,11 +67,21 @@ func (v *version) DNSEntries() DNSEntryInformer {
 	return &dNSEntryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSHostedZones returns a DNSHostedZoneInformer.
+func (v *version) DNSHostedZones() DNSHostedZoneInformer {
+	return &dNSHostedZoneInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSHostedZonePolicies returns a DNSHostedZonePolicyInformer.
 func (v *version) DNSHostedZonePolicies() DNSHostedZonePolicyInformer {
 	return &dNSHostedZonePolicyInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSHostedZoneRequests returns a DNSHostedZoneRequestInformer.
+func (v *version) DNSHostedZoneRequests() DNSHostedZoneRequestInformer {
+	return &dNSHostedZoneRequestInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // DNSLocks returns a DNSLockInformer.
 func (v *version) DNSLocks() DNSLockInformer {
 	return &dNSLockInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -81,6 +97,11 @@ func (v *version) DNSProviders() DNSProviderInformer {
 	return &dNSProviderInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
 
+// DNSZoneConflictReports returns a DNSZoneConflictReportInformer.
+func (v *version) DNSZoneConflictReports() DNSZoneConflictReportInformer {
+	return &dNSZoneConflictReportInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
 // RemoteAccessCertificates returns a RemoteAccessCertificateInformer.
 func (v *version) RemoteAccessCertificates() RemoteAccessCertificateInformer {
 	return &remoteAccessCertificateInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
@@ -57,14 +57,20 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSAnnotations().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsentries"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSEntries().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnshostedzones"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSHostedZones().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnshostedzonepolicies"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSHostedZonePolicies().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnshostedzonerequests"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSHostedZoneRequests().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnslocks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSLocks().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsowners"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSOwners().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("dnsproviders"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSProviders().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("dnszoneconflictreports"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().DNSZoneConflictReports().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("remoteaccesscertificates"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Dns().V1alpha1().RemoteAccessCertificates().Informer()}, nil
 
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DNSHostedZoneLister helps list DNSHostedZones.
+// All objects returned here must be treated as read-only.
+type DNSHostedZoneLister interface {
+	// List lists all DNSHostedZones in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZone, err error)
+	// DNSHostedZones returns an object that can list and get DNSHostedZones.
+	DNSHostedZones(namespace string) DNSHostedZoneNamespaceLister
+	DNSHostedZoneListerExpansion
+}
+
+// dNSHostedZoneLister implements the DNSHostedZoneLister interface.
+type dNSHostedZoneLister struct {
+	indexer cache.Indexer
+}
+
+// NewDNSHostedZoneLister returns a new DNSHostedZoneLister.
+func NewDNSHostedZoneLister(indexer cache.Indexer) DNSHostedZoneLister {
+	return &dNSHostedZoneLister{indexer: indexer}
+}
+
+// List lists all DNSHostedZones in the indexer.
+func (s *dNSHostedZoneLister) List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZone, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSHostedZone))
+	})
+	return ret, err
+}
+
+// DNSHostedZones returns an object that can list and get DNSHostedZones.
+func (s *dNSHostedZoneLister) DNSHostedZones(namespace string) DNSHostedZoneNamespaceLister {
+	return dNSHostedZoneNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DNSHostedZoneNamespaceLister helps list and get DNSHostedZones.
+// All objects returned here must be treated as read-only.
+type DNSHostedZoneNamespaceLister interface {
+	// List lists all DNSHostedZones in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZone, err error)
+	// Get retrieves the DNSHostedZone from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DNSHostedZone, error)
+	DNSHostedZoneNamespaceListerExpansion
+}
+
+// dNSHostedZoneNamespaceLister implements the DNSHostedZoneNamespaceLister
+// interface.
+type dNSHostedZoneNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DNSHostedZones in the indexer for a given namespace.
+func (s dNSHostedZoneNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZone, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSHostedZone))
+	})
+	return ret, err
+}
+
+// Get retrieves the DNSHostedZone from the indexer for a given namespace and name.
+func (s dNSHostedZoneNamespaceLister) Get(name string) (*v1alpha1.DNSHostedZone, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("dnshostedzone"), name)
+	}
+	return obj.(*v1alpha1.DNSHostedZone), nil
+}
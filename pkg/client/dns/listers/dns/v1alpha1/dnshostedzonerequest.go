@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DNSHostedZoneRequestLister helps list DNSHostedZoneRequests.
+// All objects returned here must be treated as read-only.
+type DNSHostedZoneRequestLister interface {
+	// List lists all DNSHostedZoneRequests in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZoneRequest, err error)
+	// DNSHostedZoneRequests returns an object that can list and get DNSHostedZoneRequests.
+	DNSHostedZoneRequests(namespace string) DNSHostedZoneRequestNamespaceLister
+	DNSHostedZoneRequestListerExpansion
+}
+
+// dNSHostedZoneRequestLister implements the DNSHostedZoneRequestLister interface.
+type dNSHostedZoneRequestLister struct {
+	indexer cache.Indexer
+}
+
+// NewDNSHostedZoneRequestLister returns a new DNSHostedZoneRequestLister.
+func NewDNSHostedZoneRequestLister(indexer cache.Indexer) DNSHostedZoneRequestLister {
+	return &dNSHostedZoneRequestLister{indexer: indexer}
+}
+
+// List lists all DNSHostedZoneRequests in the indexer.
+func (s *dNSHostedZoneRequestLister) List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZoneRequest, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSHostedZoneRequest))
+	})
+	return ret, err
+}
+
+// DNSHostedZoneRequests returns an object that can list and get DNSHostedZoneRequests.
+func (s *dNSHostedZoneRequestLister) DNSHostedZoneRequests(namespace string) DNSHostedZoneRequestNamespaceLister {
+	return dNSHostedZoneRequestNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DNSHostedZoneRequestNamespaceLister helps list and get DNSHostedZoneRequests.
+// All objects returned here must be treated as read-only.
+type DNSHostedZoneRequestNamespaceLister interface {
+	// List lists all DNSHostedZoneRequests in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZoneRequest, err error)
+	// Get retrieves the DNSHostedZoneRequest from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DNSHostedZoneRequest, error)
+	DNSHostedZoneRequestNamespaceListerExpansion
+}
+
+// dNSHostedZoneRequestNamespaceLister implements the DNSHostedZoneRequestNamespaceLister
+// interface.
+type dNSHostedZoneRequestNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DNSHostedZoneRequests in the indexer for a given namespace.
+func (s dNSHostedZoneRequestNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DNSHostedZoneRequest, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSHostedZoneRequest))
+	})
+	return ret, err
+}
+
+// Get retrieves the DNSHostedZoneRequest from the indexer for a given namespace and name.
+func (s dNSHostedZoneRequestNamespaceLister) Get(name string) (*v1alpha1.DNSHostedZoneRequest, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("dnshostedzonerequest"), name)
+	}
+	return obj.(*v1alpha1.DNSHostedZoneRequest), nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright (c) 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1alpha1 "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// DNSZoneConflictReportLister helps list DNSZoneConflictReports.
+// All objects returned here must be treated as read-only.
+type DNSZoneConflictReportLister interface {
+	// List lists all DNSZoneConflictReports in the indexer.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSZoneConflictReport, err error)
+	// DNSZoneConflictReports returns an object that can list and get DNSZoneConflictReports.
+	DNSZoneConflictReports(namespace string) DNSZoneConflictReportNamespaceLister
+	DNSZoneConflictReportListerExpansion
+}
+
+// dNSZoneConflictReportLister implements the DNSZoneConflictReportLister interface.
+type dNSZoneConflictReportLister struct {
+	indexer cache.Indexer
+}
+
+// NewDNSZoneConflictReportLister returns a new DNSZoneConflictReportLister.
+func NewDNSZoneConflictReportLister(indexer cache.Indexer) DNSZoneConflictReportLister {
+	return &dNSZoneConflictReportLister{indexer: indexer}
+}
+
+// List lists all DNSZoneConflictReports in the indexer.
+func (s *dNSZoneConflictReportLister) List(selector labels.Selector) (ret []*v1alpha1.DNSZoneConflictReport, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSZoneConflictReport))
+	})
+	return ret, err
+}
+
+// DNSZoneConflictReports returns an object that can list and get DNSZoneConflictReports.
+func (s *dNSZoneConflictReportLister) DNSZoneConflictReports(namespace string) DNSZoneConflictReportNamespaceLister {
+	return dNSZoneConflictReportNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// DNSZoneConflictReportNamespaceLister helps list and get DNSZoneConflictReports.
+// All objects returned here must be treated as read-only.
+type DNSZoneConflictReportNamespaceLister interface {
+	// List lists all DNSZoneConflictReports in the indexer for a given namespace.
+	// Objects returned here must be treated as read-only.
+	List(selector labels.Selector) (ret []*v1alpha1.DNSZoneConflictReport, err error)
+	// Get retrieves the DNSZoneConflictReport from the indexer for a given namespace and name.
+	// Objects returned here must be treated as read-only.
+	Get(name string) (*v1alpha1.DNSZoneConflictReport, error)
+	DNSZoneConflictReportNamespaceListerExpansion
+}
+
+// dNSZoneConflictReportNamespaceLister implements the DNSZoneConflictReportNamespaceLister
+// interface.
+type dNSZoneConflictReportNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all DNSZoneConflictReports in the indexer for a given namespace.
+func (s dNSZoneConflictReportNamespaceLister) List(selector labels.Selector) (ret []*v1alpha1.DNSZoneConflictReport, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1alpha1.DNSZoneConflictReport))
+	})
+	return ret, err
+}
+
+// Get retrieves the DNSZoneConflictReport from the indexer for a given namespace and name.
+func (s dNSZoneConflictReportNamespaceLister) Get(name string) (*v1alpha1.DNSZoneConflictReport, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1alpha1.Resource("dnszoneconflictreport"), name)
+	}
+	return obj.(*v1alpha1.DNSZoneConflictReport), nil
+}
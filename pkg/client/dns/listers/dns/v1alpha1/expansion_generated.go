@@ -34,6 +34,14 @@ type DNSEntryListerExpansion interface{}
 // DNSEntryNamespaceLister.
 type DNSEntryNamespaceListerExpansion interface{}
 
+// DNSHostedZoneListerExpansion allows custom methods to be added to
+// DNSHostedZoneLister.
+type DNSHostedZoneListerExpansion interface{}
+
+// DNSHostedZoneNamespaceListerExpansion allows custom methods to be added to
+// DNSHostedZoneNamespaceLister.
+type DNSHostedZoneNamespaceListerExpansion interface{}
+
 // DNSHostedZonePolicyListerExpansion allows custom methods to be added to
 // DNSHostedZonePolicyLister.
 type DNSHostedZonePolicyListerExpansion interface{}
@@ -42,6 +50,14 @@ type DNSHostedZonePolicyListerExpansion interface{}
 // DNSHostedZonePolicyNamespaceLister.
 type DNSHostedZonePolicyNamespaceListerExpansion interface{}
 
+// DNSHostedZoneRequestListerExpansion allows custom methods to be added to
+// DNSHostedZoneRequestLister.
+type DNSHostedZoneRequestListerExpansion interface{}
+
+// DNSHostedZoneRequestNamespaceListerExpansion allows custom methods to be added to
+// DNSHostedZoneRequestNamespaceLister.
+type DNSHostedZoneRequestNamespaceListerExpansion interface{}
+
 // DNSLockListerExpansion allows custom methods to be added to
 // DNSLockLister.
 type DNSLockListerExpansion interface{}
@@ -66,6 +82,14 @@ type DNSProviderListerExpansion interface{}
 // DNSProviderNamespaceLister.
 type DNSProviderNamespaceListerExpansion interface{}
 
+// DNSZoneConflictReportListerExpansion allows custom methods to be added to
+// DNSZoneConflictReportLister.
+type DNSZoneConflictReportListerExpansion interface{}
+
+// DNSZoneConflictReportNamespaceListerExpansion allows custom methods to be added to
+// DNSZoneConflictReportNamespaceLister.
+type DNSZoneConflictReportNamespaceListerExpansion interface{}
+
 // RemoteAccessCertificateListerExpansion allows custom methods to be added to
 // RemoteAccessCertificateLister.
 type RemoteAccessCertificateListerExpansion interface{}
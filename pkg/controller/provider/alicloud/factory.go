@@ -29,8 +29,16 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 	Burst:   1,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	CredentialKeys: []provider.CredentialKey{
+		provider.RequiredKey("ACCESS_KEY_ID", "accessKeyID"),
+		provider.RequiredKey("ACCESS_KEY_SECRET", "accessKeySecret"),
+	},
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler, true).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
@@ -34,9 +34,22 @@ var advancedDefaults = provider.AdvancedOptions{
 	MaxRetries: 7,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	Config: &AWSConfig{},
+	CredentialKeys: []provider.CredentialKey{
+		provider.OptionalKey("AWS_ACCESS_KEY_ID", "accessKeyID"),
+		provider.OptionalKey("AWS_SECRET_ACCESS_KEY", "secretAccessKey"),
+		provider.OptionalKey("AWS_SESSION_TOKEN"),
+		provider.OptionalKey("AWS_REGION", "region"),
+		provider.OptionalKey("AWS_USE_CREDENTIALS_CHAIN"),
+	},
+	Validate: validateCredentials,
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
@@ -19,6 +19,7 @@ package aws
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -27,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/errors"
@@ -47,6 +49,8 @@ type AWSConfig struct {
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.ZoneManager = &Handler{}
+var _ provider.DedicatedRecordTypeSupport = &Handler{}
 
 func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	advancedConfig := c.Options.AdvancedOptions.GetAdvancedConfig()
@@ -114,7 +118,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h.r53 = route53.New(sess)
 
 	forwardedDomains := provider.NewForwardedDomainsHandlerData()
-	h.cache, err = provider.NewZoneCache(c.CacheConfig, c.Metrics, forwardedDomains, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(c.CacheConfig, c.Metrics, forwardedDomains, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +236,40 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+	dnssets := dns.DNSSets{}
+	for name := range names {
+		for _, n := range dns.ProviderNames(name, zone.Domain()) {
+			start := dns.AlignHostname(n)
+			inp := (&route53.ListResourceRecordSetsInput{MaxItems: aws.String("100")}).SetHostedZoneId(zone.Id()).SetStartRecordName(start)
+			h.config.RateLimiter.Accept()
+			out, err := h.r53.ListResourceRecordSets(inp)
+			h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_LISTRECORDS, 1)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range out.ResourceRecordSets {
+				if dns.NormalizeHostname(aws.StringValue(r.Name)) != n || !dns.SupportedRecordType(aws.StringValue(r.Type)) {
+					continue
+				}
+				var rs *dns.RecordSet
+				if isAliasTarget(r) {
+					rs = buildRecordSetFromAliasTarget(r)
+				} else {
+					rs = buildRecordSet(r)
+				}
+				dnssets.AddRecordSetFromProvider(aws.StringValue(r.Name), rs)
+			}
+		}
+	}
+	for name := range dnssets {
+		if !names.Contains(name) {
+			delete(dnssets, name)
+		}
+	}
+	return dnssets, nil
+}
+
 func (h *Handler) handleRecordSets(zone provider.DNSHostedZone, f func(rs *route53.ResourceRecordSet)) ([]string, error) {
 	rt := provider.M_LISTRECORDS
 	inp := (&route53.ListResourceRecordSetsInput{MaxItems: aws.String("300")}).SetHostedZoneId(zone.Id())
@@ -395,7 +433,7 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 
 	dnssets := dns.DNSSets{}
 	aggr := func(r *route53.ResourceRecordSet) {
-		if dns.SupportedRecordType(aws.StringValue(r.Type)) {
+		if dns.SupportedRecordType(aws.StringValue(r.Type)) || aws.StringValue(r.Type) == recordType {
 			var rs *dns.RecordSet
 			if isAliasTarget(r) {
 				rs = buildRecordSetFromAliasTarget(r)
@@ -416,6 +454,12 @@ func (h *Handler) GetRecordSet(zone provider.DNSHostedZone, dnsName, recordType
 	return nil, nil
 }
 
+// SupportsRecordType reports the record types supported for dedicated record sets.
+// NS record sets are used to delegate hosted zones.
+func (h *Handler) SupportsRecordType(rtype string) bool {
+	return rtype == dns.RS_TXT || rtype == dns.RS_NS
+}
+
 func (h *Handler) CreateOrUpdateRecordSet(logger logger.LogContext, zone provider.DNSHostedZone, old, new provider.DedicatedRecordSet) error {
 	return h.executeRecordSetChange(route53.ChangeActionUpsert, logger, zone, new)
 }
@@ -432,3 +476,104 @@ func (h *Handler) executeRecordSetChange(action string, logger logger.LogContext
 	exec.addChange(action, &provider.ChangeRequest{Type: rs.Type}, dnsset)
 	return exec.submitChanges(h.config.Metrics)
 }
+
+func (h *Handler) CreateZone(logger logger.LogContext, domain, reference string) (string, error) {
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.CreateHostedZone(&route53.CreateHostedZoneInput{
+		CallerReference: aws.String(reference),
+		Name:            aws.String(dns.AlignHostname(domain)),
+		HostedZoneConfig: &route53.HostedZoneConfig{
+			Comment: aws.String("created by dns-controller-manager"),
+		},
+	})
+	h.config.Metrics.AddGenericRequests(provider.M_CREATEZONE, 1)
+	if err != nil {
+		if a, ok := err.(awserr.Error); ok && a.Code() == route53.ErrCodeHostedZoneAlreadyExists {
+			// zone has already been created with this caller reference
+			return h.findZoneByReference(domain, reference)
+		}
+		return "", err
+	}
+	id := shortZoneId(aws.StringValue(out.HostedZone.Id))
+	logger.Infof("created hosted zone %s for %s", id, domain)
+	h.cache.InvalidateZones()
+	return id, nil
+}
+
+func (h *Handler) findZoneByReference(domain, reference string) (string, error) {
+	name := dns.AlignHostname(domain)
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.ListHostedZonesByName(&route53.ListHostedZonesByNameInput{DNSName: aws.String(name)})
+	h.config.Metrics.AddGenericRequests(provider.M_LISTZONES, 1)
+	if err != nil {
+		return "", err
+	}
+	for _, z := range out.HostedZones {
+		if aws.StringValue(z.Name) != name {
+			break
+		}
+		if aws.StringValue(z.CallerReference) == reference {
+			return shortZoneId(aws.StringValue(z.Id)), nil
+		}
+	}
+	return "", fmt.Errorf("hosted zone for %s with caller reference %s not found", domain, reference)
+}
+
+func (h *Handler) GetNameServers(zoneid string) ([]string, error) {
+	h.config.RateLimiter.Accept()
+	out, err := h.r53.GetHostedZone(&route53.GetHostedZoneInput{Id: aws.String(zoneid)})
+	h.config.Metrics.AddZoneRequests(zoneid, provider.M_GETZONE, 1)
+	if err != nil {
+		return nil, err
+	}
+	if out.DelegationSet == nil {
+		return nil, fmt.Errorf("no delegation set for hosted zone %s", zoneid)
+	}
+	return aws.StringValueSlice(out.DelegationSet.NameServers), nil
+}
+
+func (h *Handler) DeleteZone(logger logger.LogContext, zoneid string) error {
+	h.config.RateLimiter.Accept()
+	_, err := h.r53.DeleteHostedZone(&route53.DeleteHostedZoneInput{Id: aws.String(zoneid)})
+	h.config.Metrics.AddZoneRequests(zoneid, provider.M_DELETEZONE, 1)
+	if err != nil {
+		if a, ok := err.(awserr.Error); ok && a.Code() == route53.ErrCodeNoSuchHostedZone {
+			return nil
+		}
+		return err
+	}
+	logger.Infof("deleted hosted zone %s", zoneid)
+	h.cache.InvalidateZones()
+	return nil
+}
+
+func shortZoneId(id string) string {
+	comp := strings.Split(id, "/")
+	return comp[len(comp)-1]
+}
+
+// validateCredentials checks the credentials depending on the usage of the credentials chain.
+func validateCredentials(_ interface{}, props utils.Properties) error {
+	useCredentialsChain := false
+	if value := props["AWS_USE_CREDENTIALS_CHAIN"]; value != "" {
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid value for AWS_USE_CREDENTIALS_CHAIN: %s", err)
+		}
+		useCredentialsChain = b
+	}
+	hasAccessKey := props["AWS_ACCESS_KEY_ID"] != "" || props["accessKeyID"] != ""
+	if useCredentialsChain {
+		if hasAccessKey {
+			return fmt.Errorf("explicit credentials (AWS_ACCESS_KEY_ID or accessKeyID) cannot be used together with AWS_USE_CREDENTIALS_CHAIN=true")
+		}
+		return nil
+	}
+	if !hasAccessKey {
+		return fmt.Errorf("'AWS_ACCESS_KEY_ID' or 'accessKeyID' required in secret")
+	}
+	if props["AWS_SECRET_ACCESS_KEY"] == "" && props["secretAccessKey"] == "" {
+		return fmt.Errorf("'AWS_SECRET_ACCESS_KEY' or 'secretAccessKey' required in secret")
+	}
+	return nil
+}
@@ -17,6 +17,7 @@
 package azureprivate
 
 import (
+	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
@@ -29,8 +30,13 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 	Burst:   10,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	CredentialKeys: utils.CredentialKeys,
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
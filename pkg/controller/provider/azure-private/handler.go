@@ -24,6 +24,7 @@ import (
 
 	azure "github.com/Azure/azure-sdk-for-go/services/privatedns/mgmt/2018-09-01/privatedns"
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	utils2 "github.com/gardener/controller-manager-library/pkg/utils"
 
 	"github.com/gardener/external-dns-management/pkg/controller/provider/azure/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
@@ -73,7 +74,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	h.zonesClient = &zonesClient
 	h.recordsClient = &recordsClient
 
-	h.cache, err = provider.NewZoneCache(c.CacheConfig, c.Metrics, nil, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(c.CacheConfig, c.Metrics, nil, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -141,41 +142,79 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	for ; results.NotDone(); results.Next() {
 		count++
 		item := results.Value()
-		// We expect recordName.DNSZone. However Azure only return recordName . Reverse is dropZoneName() needed for calls to Azure
-		fullName := fmt.Sprintf("%s.%s", *item.Name, zoneName)
+		addRecordSets(dnssets, zoneName, item)
+	}
+	pages := count / 100
+	if pages > 0 {
+		h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_PLISTRECORDS, count/100)
+	}
+	return provider.NewDNSZoneState(dnssets), nil
+}
 
-		if item.ARecords != nil {
-			rs := dns.NewRecordSet(dns.RS_A, *item.TTL, nil)
-			for _, record := range *item.ARecords {
-				rs.Add(&dns.Record{Value: *record.Ipv4Address})
+// getZoneStateForNames reads the record sets of the given DNS names (including their
+// meta data records) by getting the record sets of all supported record types.
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils2.StringSet) (dns.DNSSets, error) {
+	dnssets := dns.DNSSets{}
+	resourceGroup, zoneName := utils.SplitZoneID(zone.Id())
+	for name := range names {
+		for _, n := range dns.ProviderNames(name, zone.Domain()) {
+			relativeName, ok := utils.DropZoneName(n, zoneName)
+			if !ok {
+				continue
+			}
+			for _, recordType := range []azure.RecordType{azure.A, azure.CNAME, azure.TXT} {
+				h.config.RateLimiter.Accept()
+				item, err := h.recordsClient.Get(h.ctx, resourceGroup, zoneName, recordType, relativeName)
+				h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_LISTRECORDS, 1)
+				if err != nil {
+					if utils.IsNotFound(err) {
+						continue
+					}
+					return nil, perrs.WrapfAsHandlerError(err, "Getting %s record set %s in zone %s failed", recordType, n, zoneName)
+				}
+				addRecordSets(dnssets, zoneName, item)
 			}
-			dnssets.AddRecordSetFromProvider(fullName, rs)
 		}
+	}
+	for name := range dnssets {
+		if !names.Contains(name) {
+			delete(dnssets, name)
+		}
+	}
+	return dnssets, nil
+}
+
+// addRecordSets adds the supported record sets of an Azure record set.
+func addRecordSets(dnssets dns.DNSSets, zoneName string, item azure.RecordSet) {
+	// We expect recordName.DNSZone. However Azure only return recordName . Reverse is dropZoneName() needed for calls to Azure
+	fullName := fmt.Sprintf("%s.%s", *item.Name, zoneName)
 
-		if item.CnameRecord != nil {
-			rs := dns.NewRecordSet(dns.RS_CNAME, *item.TTL, nil)
-			rs.Add(&dns.Record{Value: *item.CnameRecord.Cname})
-			dnssets.AddRecordSetFromProvider(fullName, rs)
+	if item.ARecords != nil {
+		rs := dns.NewRecordSet(dns.RS_A, *item.TTL, nil)
+		for _, record := range *item.ARecords {
+			rs.Add(&dns.Record{Value: *record.Ipv4Address})
 		}
+		dnssets.AddRecordSetFromProvider(fullName, rs)
+	}
 
-		if item.TxtRecords != nil {
-			rs := dns.NewRecordSet(dns.RS_TXT, *item.TTL, nil)
-			for _, record := range *item.TxtRecords {
-				quoted := strings.Join(*record.Value, "\n")
-				// AzureDNS stores values unquoted, but it is expected to be quoted in dns.Record
-				if len(quoted) > 0 && quoted[0] != '"' && quoted[len(quoted)-1] != '"' {
-					quoted = strconv.Quote(quoted)
-				}
-				rs.Add(&dns.Record{Value: quoted})
+	if item.CnameRecord != nil {
+		rs := dns.NewRecordSet(dns.RS_CNAME, *item.TTL, nil)
+		rs.Add(&dns.Record{Value: *item.CnameRecord.Cname})
+		dnssets.AddRecordSetFromProvider(fullName, rs)
+	}
+
+	if item.TxtRecords != nil {
+		rs := dns.NewRecordSet(dns.RS_TXT, *item.TTL, nil)
+		for _, record := range *item.TxtRecords {
+			quoted := strings.Join(*record.Value, "\n")
+			// AzureDNS stores values unquoted, but it is expected to be quoted in dns.Record
+			if len(quoted) > 0 && quoted[0] != '"' && quoted[len(quoted)-1] != '"' {
+				quoted = strconv.Quote(quoted)
 			}
-			dnssets.AddRecordSetFromProvider(fullName, rs)
+			rs.Add(&dns.Record{Value: quoted})
 		}
+		dnssets.AddRecordSetFromProvider(fullName, rs)
 	}
-	pages := count / 100
-	if pages > 0 {
-		h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_PLISTRECORDS, count/100)
-	}
-	return provider.NewDNSZoneState(dnssets), nil
 }
 
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
@@ -17,7 +17,9 @@
 package utils
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strings"
 
@@ -46,6 +48,15 @@ func DropZoneName(dnsName, zoneName string) (string, bool) {
 	return dnsName[:end], true
 }
 
+// IsNotFound checks whether an Azure request failed because the resource does not exist.
+func IsNotFound(err error) bool {
+	var detailed autorest.DetailedError
+	if errors.As(err, &detailed) {
+		return detailed.StatusCode == http.StatusNotFound
+	}
+	return false
+}
+
 // MakeZoneID creates zone ID from resource group and name
 func MakeZoneID(resourceGroup, zoneName string) string {
 	return resourceGroup + "/" + zoneName
@@ -60,6 +71,14 @@ func SplitZoneID(zoneid string) (string, string) {
 	return parts[0], parts[1]
 }
 
+// CredentialKeys are the keys of the credentials secret used by GetSubscriptionIDAndAuthorizer.
+var CredentialKeys = []provider.CredentialKey{
+	provider.RequiredKey("AZURE_SUBSCRIPTION_ID", "subscriptionID"),
+	provider.RequiredKey("AZURE_CLIENT_ID", "clientID"),
+	provider.RequiredKey("AZURE_CLIENT_SECRET", "clientSecret"),
+	provider.RequiredKey("AZURE_TENANT_ID", "tenantID"),
+}
+
 // GetSubscriptionIDAndAuthorizer extracts credentials from config
 func GetSubscriptionIDAndAuthorizer(c *provider.DNSHandlerConfig) (subscriptionID string, authorizer autorest.Authorizer, err error) {
 	subscriptionID, err = c.GetRequiredProperty("AZURE_SUBSCRIPTION_ID", "subscriptionID")
@@ -16,7 +16,13 @@
 
 package utils
 
-import "testing"
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/Azure/go-autorest/autorest"
+)
 
 func TestDropZoneName(t *testing.T) {
 	table := []struct {
@@ -41,3 +47,17 @@ func TestDropZoneName(t *testing.T) {
 		}
 	}
 }
+
+func TestIsNotFound(t *testing.T) {
+	notFound := autorest.NewErrorWithError(fmt.Errorf("not found"), "dns.RecordSetsClient", "Get", &http.Response{StatusCode: http.StatusNotFound}, "Failure responding to request")
+	if !IsNotFound(notFound) {
+		t.Errorf("expected not found error")
+	}
+	if !IsNotFound(fmt.Errorf("wrapped: %w", notFound)) {
+		t.Errorf("expected wrapped not found error")
+	}
+	forbidden := autorest.NewErrorWithError(fmt.Errorf("forbidden"), "dns.RecordSetsClient", "Get", &http.Response{StatusCode: http.StatusForbidden}, "Failure responding to request")
+	if IsNotFound(forbidden) || IsNotFound(fmt.Errorf("other")) {
+		t.Errorf("unexpected not found error")
+	}
+}
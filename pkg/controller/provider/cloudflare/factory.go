@@ -29,8 +29,15 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 	Burst:   10,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	CredentialKeys: []provider.CredentialKey{
+		provider.RequiredKey("CLOUDFLARE_API_TOKEN", "apiToken"),
+	},
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
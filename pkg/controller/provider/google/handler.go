@@ -30,6 +30,7 @@ import (
 	"golang.org/x/oauth2/google"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 
@@ -87,7 +88,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 	}
 
 	forwardedDomains := provider.NewForwardedDomainsHandlerData()
-	h.cache, err = provider.NewZoneCache(config.CacheConfig, config.Metrics, forwardedDomains, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(config.CacheConfig, config.Metrics, forwardedDomains, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -178,13 +179,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	dnssets := dns.DNSSets{}
 
 	f := func(r *googledns.ResourceRecordSet) {
-		if dns.SupportedRecordType(r.Type) {
-			rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
-			for _, rr := range r.Rrdatas {
-				rs.Add(&dns.Record{Value: rr})
-			}
-			dnssets.AddRecordSetFromProvider(r.Name, rs)
-		}
+		addRecordSet(dnssets, r)
 	}
 
 	forwarded, err := h.handleRecordSets(zone, f)
@@ -196,6 +191,46 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// getZoneStateForNames reads the record sets of the given DNS names (including their
+// meta data records) by filtering the record sets of the zone by name.
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+	dnssets := dns.DNSSets{}
+	projectID, zoneName := SplitZoneID(zone.Id())
+	for name := range names {
+		for _, n := range dns.ProviderNames(name, zone.Domain()) {
+			aggr := func(resp *googledns.ResourceRecordSetsListResponse) error {
+				h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_LISTRECORDS, 1)
+				for _, r := range resp.Rrsets {
+					if dns.NormalizeHostname(r.Name) == n {
+						addRecordSet(dnssets, r)
+					}
+				}
+				return nil
+			}
+			h.config.RateLimiter.Accept()
+			if err := h.service.ResourceRecordSets.List(projectID, zoneName).Name(dns.AlignHostname(n)).Pages(h.ctx, aggr); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for name := range dnssets {
+		if !names.Contains(name) {
+			delete(dnssets, name)
+		}
+	}
+	return dnssets, nil
+}
+
+func addRecordSet(dnssets dns.DNSSets, r *googledns.ResourceRecordSet) {
+	if dns.SupportedRecordType(r.Type) {
+		rs := dns.NewRecordSet(r.Type, r.Ttl, nil)
+		for _, rr := range r.Rrdatas {
+			rs.Add(&dns.Record{Value: rr})
+		}
+		dnssets.AddRecordSetFromProvider(r.Name, rs)
+	}
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
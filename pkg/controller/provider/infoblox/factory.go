@@ -24,7 +24,26 @@ import (
 
 const TYPE_CODE = "infoblox-dns"
 
-var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler)
+var configSchema = provider.ProviderConfigSchema{
+	Config: &InfobloxConfig{},
+	CredentialKeys: []provider.CredentialKey{
+		provider.RequiredKey("USERNAME", "username"),
+		provider.RequiredKey("PASSWORD", "password"),
+		provider.OptionalKey("VERSION", "version"),
+		provider.OptionalKey("VIEW", "view"),
+		provider.OptionalKey("HOST", "host"),
+		provider.OptionalKey("PORT", "port"),
+		provider.OptionalKey("HTTP_POOL_CONNECTIONS", "http_pool_connections", "httpPoolConnections"),
+		provider.OptionalKey("HTTP_REQUEST_TIMEOUT", "http_request_timeout", "httpRequestTimeout"),
+		provider.OptionalKey("PROXY_URL", "proxy_url", "proxyUrl"),
+		provider.OptionalKey("CA_CERT", "ca_cert", "caCert"),
+		provider.OptionalKey("SSL_VERIFY", "ssl_verify", "sslVerify"),
+	},
+	Validate: validateConfig,
+}
+
+var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
@@ -25,8 +25,10 @@ import (
 	"net/url"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/raw"
@@ -325,3 +327,41 @@ func (h *Handler) DeleteRecordSet(logger logger.LogContext, zone provider.DNSHos
 	}
 	return nil
 }
+
+// validateConfig checks that the host is given and settings are not defined both in the provider config and the secret.
+func validateConfig(cfg interface{}, props utils.Properties) error {
+	infobloxConfig, _ := cfg.(*InfobloxConfig)
+	if infobloxConfig == nil {
+		infobloxConfig = &InfobloxConfig{}
+	}
+	inSecret := func(keys ...string) bool {
+		for _, k := range keys {
+			if strings.TrimSpace(props[k]) != "" {
+				return true
+			}
+		}
+		return false
+	}
+	if (infobloxConfig.Host == nil || *infobloxConfig.Host == "") && !inSecret("HOST", "host") {
+		return fmt.Errorf("'HOST' or 'host' required in secret or providerConfig")
+	}
+	both := []string{}
+	check := func(inConfig bool, keys ...string) {
+		if inConfig && inSecret(keys...) {
+			both = append(both, keys[0])
+		}
+	}
+	check(infobloxConfig.Host != nil && *infobloxConfig.Host != "", "HOST", "host")
+	check(infobloxConfig.Port != nil && *infobloxConfig.Port != 0, "PORT", "port")
+	check(infobloxConfig.Version != nil && *infobloxConfig.Version != "", "VERSION", "version")
+	check(infobloxConfig.View != nil && *infobloxConfig.View != "", "VIEW", "view")
+	check(infobloxConfig.CaCert != nil && *infobloxConfig.CaCert != "", "CA_CERT", "ca_cert", "caCert")
+	check(infobloxConfig.ProxyURL != nil && *infobloxConfig.ProxyURL != "", "PROXY_URL", "proxy_url", "proxyUrl")
+	check(infobloxConfig.PoolConnections != nil && *infobloxConfig.PoolConnections != 0, "HTTP_POOL_CONNECTIONS", "http_pool_connections", "httpPoolConnections")
+	check(infobloxConfig.RequestTimeout != nil && *infobloxConfig.RequestTimeout != 0, "HTTP_REQUEST_TIMEOUT", "http_request_timeout", "httpRequestTimeout")
+	check(infobloxConfig.SSLVerify != nil && !*infobloxConfig.SSLVerify, "SSL_VERIFY", "ssl_verify", "sslVerify")
+	if len(both) > 0 {
+		return fmt.Errorf("properties defined in secret and provider config: %s", strings.Join(both, ", "))
+	}
+	return nil
+}
@@ -22,8 +22,10 @@ import (
 	"fmt"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"k8s.io/client-go/util/flowcontrol"
 
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 )
 
@@ -51,6 +53,7 @@ type MockConfig struct {
 }
 
 var _ provider.DNSHandler = &Handler{}
+var _ provider.ZoneManager = &Handler{}
 
 // TestMock allows tests to access mocked DNSHosted Zones
 var TestMock = map[string]*provider.InMemory{}
@@ -85,7 +88,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		}
 	}
 
-	h.cache, err = provider.NewZoneCache(config.CacheConfig, config.Metrics, nil, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(config.CacheConfig, config.Metrics, nil, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +122,11 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return h.mock.CloneZoneState(zone)
 }
 
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+	h.config.RateLimiter.Accept()
+	return h.mock.GetDNSSets(zone.Id(), names)
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
@@ -162,3 +170,33 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+func (h *Handler) CreateZone(logger logger.LogContext, domain, reference string) (string, error) {
+	domain = dns.NormalizeHostname(domain)
+	zoneID := h.mockConfig.Name + ":" + domain
+	hostedZone := provider.NewDNSHostedZone(h.ProviderType(), zoneID, domain, "", []string{}, false)
+	if h.mock.AddZone(hostedZone) {
+		logger.Infof("Providing mock DNSZone %s[%s]", domain, zoneID)
+		h.cache.InvalidateZones()
+	}
+	return zoneID, nil
+}
+
+func (h *Handler) GetNameServers(zoneid string) ([]string, error) {
+	zone := h.mock.FindHostedZone(zoneid)
+	if zone == nil {
+		return nil, fmt.Errorf("DNSZone %s not hosted", zoneid)
+	}
+	return []string{"ns1." + zone.Domain(), "ns2." + zone.Domain()}, nil
+}
+
+func (h *Handler) DeleteZone(logger logger.LogContext, zoneid string) error {
+	zone := h.mock.FindHostedZone(zoneid)
+	if zone == nil {
+		return nil
+	}
+	h.mock.DeleteZone(zone)
+	h.cache.InvalidateZones()
+	logger.Infof("Deleted mock DNSZone %s[%s]", zone.Domain(), zoneid)
+	return nil
+}
@@ -29,8 +29,32 @@ var rateLimiterDefaults = provider.RateLimiterOptions{
 	Burst:   20,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	CredentialKeys: []provider.CredentialKey{
+		provider.RequiredKey("OS_AUTH_URL"),
+		provider.OptionalKey("OS_APPLICATION_CREDENTIAL_ID", "applicationCredentialID"),
+		provider.OptionalKey("OS_APPLICATION_CREDENTIAL_NAME", "applicationCredentialName"),
+		provider.OptionalKey("OS_APPLICATION_CREDENTIAL_SECRET", "applicationCredentialSecret"),
+		provider.OptionalKey("OS_USERNAME", "username"),
+		provider.OptionalKey("OS_PASSWORD", "password"),
+		provider.OptionalKey("OS_DOMAIN_NAME", "domainName"),
+		provider.OptionalKey("OS_DOMAIN_ID", "domainID"),
+		provider.OptionalKey("OS_PROJECT_NAME", "tenantName"),
+		provider.OptionalKey("OS_PROJECT_ID", "tenantID"),
+		provider.OptionalKey("OS_USER_DOMAIN_NAME", "userDomainName"),
+		provider.OptionalKey("OS_USER_DOMAIN_ID", "userDomainID"),
+		provider.OptionalKey("OS_REGION_NAME"),
+		provider.OptionalKey("CACERT", "caCert"),
+		provider.OptionalKey("CLIENTCERT", "clientCert"),
+		provider.OptionalKey("CLIENTKEY", "clientKey"),
+		provider.OptionalKey("INSECURE", "insecure"),
+	},
+	Validate: validateCredentials,
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
-	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults))
+	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.SetRateLimiterOptions(rateLimiterDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
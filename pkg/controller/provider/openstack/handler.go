@@ -22,6 +22,7 @@ import (
 	"strings"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/recordsets"
 	"github.com/gophercloud/gophercloud/openstack/dns/v2/zones"
 	"github.com/gophercloud/utils/openstack/clientconfig"
@@ -61,7 +62,7 @@ func NewHandler(config *provider.DNSHandlerConfig) (provider.DNSHandler, error)
 		client:            designateClient{serviceClient: serviceClient, metrics: config.Metrics},
 	}
 
-	h.cache, err = provider.NewZoneCache(config.CacheConfig, config.Metrics, nil, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(config.CacheConfig, config.Metrics, nil, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -206,18 +207,7 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	dnssets := dns.DNSSets{}
 
 	recordSetHandler := func(recordSet *recordsets.RecordSet) error {
-		switch recordSet.Type {
-		case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT:
-			rs := dns.NewRecordSet(recordSet.Type, int64(recordSet.TTL), nil)
-			for _, record := range recordSet.Records {
-				value := record
-				if recordSet.Type == dns.RS_CNAME {
-					value = dns.NormalizeHostname(value)
-				}
-				rs.Add(&dns.Record{Value: value})
-			}
-			dnssets.AddRecordSetFromProvider(recordSet.Name, rs)
-		}
+		addRecordSet(dnssets, recordSet)
 		return nil
 	}
 
@@ -229,6 +219,45 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// getZoneStateForNames reads the record sets of the given DNS names (including their
+// meta data records) by filtering the record sets of the zone by name.
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+	dnssets := dns.DNSSets{}
+	recordSetHandler := func(recordSet *recordsets.RecordSet) error {
+		addRecordSet(dnssets, recordSet)
+		return nil
+	}
+	for name := range names {
+		for _, n := range dns.ProviderNames(name, zone.Domain()) {
+			h.config.RateLimiter.Accept()
+			if err := h.client.ForEachRecordSetFilterByTypeAndName(zone.Id(), "", dns.AlignHostname(n), recordSetHandler); err != nil {
+				return nil, fmt.Errorf("Listing DNS record sets of %s failed for %s. Details: %s", n, zone.Id(), err.Error())
+			}
+		}
+	}
+	for name := range dnssets {
+		if !names.Contains(name) {
+			delete(dnssets, name)
+		}
+	}
+	return dnssets, nil
+}
+
+func addRecordSet(dnssets dns.DNSSets, recordSet *recordsets.RecordSet) {
+	switch recordSet.Type {
+	case dns.RS_A, dns.RS_AAAA, dns.RS_CNAME, dns.RS_TXT:
+		rs := dns.NewRecordSet(recordSet.Type, int64(recordSet.TTL), nil)
+		for _, record := range recordSet.Records {
+			value := record
+			if recordSet.Type == dns.RS_CNAME {
+				value = dns.NormalizeHostname(value)
+			}
+			rs.Add(&dns.Record{Value: value})
+		}
+		dnssets.AddRecordSetFromProvider(recordSet.Name, rs)
+	}
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
@@ -280,3 +309,35 @@ func (h *Handler) executeRequests(logger logger.LogContext, zone provider.DNSHos
 
 	return nil
 }
+
+// validateCredentials checks that either application credentials or username and password are given.
+func validateCredentials(_ interface{}, props utils.Properties) error {
+	get := func(keys ...string) string {
+		for _, k := range keys {
+			if v := strings.TrimSpace(props[k]); v != "" {
+				return v
+			}
+		}
+		return ""
+	}
+	applicationCredentialID := get("OS_APPLICATION_CREDENTIAL_ID", "applicationCredentialID")
+	applicationCredentialName := get("OS_APPLICATION_CREDENTIAL_NAME", "applicationCredentialName")
+	username := get("OS_USERNAME", "username")
+	password := get("OS_PASSWORD", "password")
+	if applicationCredentialID != "" || applicationCredentialName != "" {
+		if get("OS_APPLICATION_CREDENTIAL_SECRET", "applicationCredentialSecret") == "" {
+			return fmt.Errorf("'OS_APPLICATION_CREDENTIAL_SECRET' (or 'applicationCredentialSecret') is required if 'OS_APPLICATION_CREDENTIAL_ID' or 'OS_APPLICATION_CREDENTIAL_NAME' is given")
+		}
+		if applicationCredentialID == "" && username == "" {
+			return fmt.Errorf("'OS_USERNAME' (or 'username') is required if 'OS_APPLICATION_CREDENTIAL_NAME' is given")
+		}
+		if password != "" {
+			return fmt.Errorf("'OS_PASSWORD' (or 'password') is not allowed if application credentials are used")
+		}
+		return nil
+	}
+	if username == "" || password == "" {
+		return fmt.Errorf("'OS_USERNAME' (or 'username') and 'OS_PASSWORD' (or 'password') are required if application credentials are not used")
+	}
+	return nil
+}
@@ -26,6 +26,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
@@ -203,7 +204,7 @@ func newMockHandler(mockZones ...*zones.Zone) *Handler {
 	}
 
 	cacheConfig := provider.NewTestZoneCacheConfig(60*time.Second, 0*time.Second)
-	cache, _ := provider.NewZoneCache(*cacheConfig, mockMetrics, nil, h.getZones, h.getZoneState)
+	cache, _ := provider.NewIncrementalZoneCache(*cacheConfig, mockMetrics, nil, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	h.cache = cache
 	h.config.Options = &provider.FactoryOptions{
 		GenericFactoryOptions: provider.GenericFactoryOptions{},
@@ -355,6 +356,13 @@ func TestGetZoneStateAndExecuteRequests(t *testing.T) {
 	actualDnssets := zoneState2.GetDNSSets()
 	Ω(actualDnssets).Should(Equal(expectedDnssets))
 
+	partialDnssets, err := h.getZoneStateForNames(hostedZone, utils.NewStringSet("sub1.z1.test", "sub3.z1.test", "missing.z1.test"))
+	Ω(err).Should(BeNil(), "getZoneStateForNames failed")
+	Ω(partialDnssets).Should(Equal(dns.DNSSets{
+		"sub1.z1.test": expectedDnssets["sub1.z1.test"],
+		"sub3.z1.test": expectedDnssets["sub3.z1.test"],
+	}))
+
 	tlog := logger.New()
 	reqs := []*provider.ChangeRequest{
 		{
@@ -19,6 +19,7 @@ package remote
 import (
 	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	corev1 "k8s.io/api/core/v1"
 )
 
 const TYPE_CODE = "remote"
@@ -34,9 +35,21 @@ var advancedDefaults = provider.AdvancedOptions{
 	MaxRetries: 7,
 }
 
+var configSchema = provider.ProviderConfigSchema{
+	CredentialKeys: []provider.CredentialKey{
+		provider.RequiredKey("REMOTE_ENDPOINT", "remoteEndpoint"),
+		provider.OptionalKey("SERVER_CA_CERT", "ca.crt"),
+		provider.RequiredKey("CLIENT_CERT", corev1.TLSCertKey),
+		provider.RequiredKey("CLIENT_KEY", corev1.TLSPrivateKeyKey),
+		provider.RequiredKey("NAMESPACE", "namespace"),
+		provider.OptionalKey("OVERRIDE_SERVER_NAME", "overrideServerName"),
+	},
+}
+
 var Factory = provider.NewDNSHandlerFactory(TYPE_CODE, NewHandler).
 	SetGenericFactoryOptionDefaults(provider.GenericFactoryOptionDefaults.
-		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults))
+		SetRateLimiterOptions(rateLimiterDefaults).SetAdvancedOptions(advancedDefaults)).
+	SetProviderConfigSchema(configSchema)
 
 func init() {
 	compound.MustRegister(Factory)
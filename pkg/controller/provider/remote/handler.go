@@ -28,6 +28,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/route53"
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
@@ -50,6 +51,7 @@ type Handler struct {
 	client          common.RemoteProviderClient
 	sess            *session.Session
 	r53             *route53.Route53
+	watches         *zoneWatches
 }
 
 var _ provider.DNSHandler = &Handler{}
@@ -62,6 +64,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 		DefaultDNSHandler: provider.NewDefaultDNSHandler(TYPE_CODE),
 		config:            *c,
 		clientID:          getClientID(),
+		watches:           newZoneWatches(),
 	}
 
 	serverEndpoint, err := c.GetRequiredProperty("REMOTE_ENDPOINT", "remoteEndpoint")
@@ -101,7 +104,7 @@ func NewHandler(c *provider.DNSHandlerConfig) (provider.DNSHandler, error) {
 	}
 	h.client = common.NewRemoteProviderClient(h.connection)
 
-	h.cache, err = provider.NewZoneCache(c.CacheConfig, c.Metrics, nil, h.getZones, h.getZoneState)
+	h.cache, err = provider.NewIncrementalZoneCache(c.CacheConfig, c.Metrics, nil, h.getZones, h.getZoneState, h.getZoneStateForNames)
 	if err != nil {
 		return nil, err
 	}
@@ -147,12 +150,29 @@ func (h *Handler) loadTLSCredentials(serverCA_PEM, clientCert_PEM, clientKey_PEM
 }
 
 func (h *Handler) Release() {
+	h.watches.stopAll()
 	h.cache.Release()
 	if h.connection != nil {
+		h.logout()
 		h.connection.Close()
 	}
 }
 
+// logout ends the session on the server (best effort, old servers do not support it)
+func (h *Handler) logout() {
+	if h.currentToken == "" {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if _, err := h.client.Logout(ctx, &common.LogoutRequest{Token: h.currentToken}); err != nil {
+		if s, ok := status.FromError(err); !ok || s.Code() != codes.Unimplemented {
+			h.config.Logger.Infof("logout failed: %s", err)
+		}
+	}
+	h.currentToken = ""
+}
+
 func (h *Handler) GetZones() (provider.DNSHostedZones, error) {
 	return h.cache.GetZones()
 }
@@ -219,6 +239,7 @@ func (h *Handler) getZones(cache provider.ZoneCache) (provider.DNSHostedZones, e
 		hostedZone := provider.NewDNSHostedZone(h.ProviderType(), z.Id, dns.NormalizeHostname(z.Domain), z.Key, z.ForwardedDomain, z.PrivateZone)
 		zones = append(zones, hostedZone)
 	}
+	h.watches.retain(zones)
 	return zones, nil
 }
 
@@ -227,6 +248,11 @@ func (h *Handler) GetZoneState(zone provider.DNSHostedZone) (provider.DNSZoneSta
 }
 
 func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneCache) (provider.DNSZoneState, error) {
+	if sets := h.watches.getDNSSets(zone.Id()); sets != nil {
+		// zone state is kept up to date by the WatchZone stream
+		return provider.NewDNSZoneState(sets), nil
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
 	defer cancel()
 
@@ -243,10 +269,38 @@ func (h *Handler) getZoneState(zone provider.DNSHostedZone, cache provider.ZoneC
 	}
 
 	dnssets := conversion.UnmarshalDNSSets(remoteState.DnsSets)
+	h.startWatch(zone)
 
 	return provider.NewDNSZoneState(dnssets), nil
 }
 
+// getZoneStateForNames reads the DNS sets of the given DNS names only.
+// Servers without support for partial reads return the full zone state, which is filtered here.
+func (h *Handler) getZoneStateForNames(zone provider.DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Second)
+	defer cancel()
+
+	var remoteState *common.ZoneState
+	err := h.retryOnInvalidTokenError(ctx, func(token string) error {
+		var err error
+		h.config.RateLimiter.Accept()
+		remoteState, err = h.client.GetZoneState(ctx, &common.GetZoneStateRequest{Token: token, Zoneid: zone.Id(), Names: names.AsArray()})
+		h.config.Metrics.AddZoneRequests(zone.Id(), provider.M_LISTRECORDS, 1)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dnssets := conversion.UnmarshalDNSSets(remoteState.DnsSets)
+	for name := range dnssets {
+		if !names.Contains(name) {
+			delete(dnssets, name)
+		}
+	}
+	return dnssets, nil
+}
+
 func (h *Handler) ReportZoneStateConflict(zone provider.DNSHostedZone, err error) bool {
 	return h.cache.ReportZoneStateConflict(zone, err)
 }
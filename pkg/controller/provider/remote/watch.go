@@ -0,0 +1,223 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const watchRetryPeriod = 10 * time.Second
+
+// zoneWatch mirrors the state of a remote zone as reported by the WatchZone stream.
+type zoneWatch struct {
+	lock   sync.Mutex
+	zone   provider.DNSHostedZone
+	ctx    context.Context
+	cancel context.CancelFunc
+	sets   dns.DNSSets
+	synced bool
+}
+
+// zoneWatches keeps the active zone watches of a remote handler.
+// If the remote server does not support watching zones, the handler
+// falls back to polling the zone states.
+type zoneWatches struct {
+	lock        sync.Mutex
+	unsupported bool
+	watches     map[string]*zoneWatch
+}
+
+func newZoneWatches() *zoneWatches {
+	return &zoneWatches{watches: map[string]*zoneWatch{}}
+}
+
+// getDNSSets returns a copy of the mirrored zone state or nil if the zone is not in sync.
+func (this *zoneWatches) getDNSSets(zoneid string) dns.DNSSets {
+	this.lock.Lock()
+	w := this.watches[zoneid]
+	this.lock.Unlock()
+	if w == nil {
+		return nil
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	if !w.synced {
+		return nil
+	}
+	return w.sets.Clone()
+}
+
+func (this *zoneWatches) add(zone provider.DNSHostedZone) *zoneWatch {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.unsupported || this.watches[zone.Id()] != nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	w := &zoneWatch{zone: zone, ctx: ctx, cancel: cancel}
+	this.watches[zone.Id()] = w
+	return w
+}
+
+func (this *zoneWatches) remove(w *zoneWatch) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.watches[w.zone.Id()] == w {
+		delete(this.watches, w.zone.Id())
+	}
+	w.cancel()
+}
+
+func (this *zoneWatches) setUnsupported() {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.unsupported = true
+	for id, w := range this.watches {
+		w.cancel()
+		delete(this.watches, id)
+	}
+}
+
+// retain stops the watches of all zones not contained in the given zones.
+func (this *zoneWatches) retain(zones provider.DNSHostedZones) {
+	ids := utils.StringSet{}
+	for _, z := range zones {
+		ids.Add(z.Id())
+	}
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	for id, w := range this.watches {
+		if !ids.Contains(id) {
+			w.cancel()
+			delete(this.watches, id)
+		}
+	}
+}
+
+func (this *zoneWatches) stopAll() {
+	this.retain(nil)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// startWatch starts watching the given zone, if it is not already watched.
+func (h *Handler) startWatch(zone provider.DNSHostedZone) {
+	w := h.watches.add(zone)
+	if w == nil {
+		return
+	}
+	go h.runWatch(w)
+}
+
+func (h *Handler) runWatch(w *zoneWatch) {
+	log := h.config.Logger.NewContext("zone", w.zone.Id())
+	log.Infof("start watching remote zone")
+	for {
+		err := h.watchZone(log, w)
+		w.lock.Lock()
+		w.synced = false
+		w.lock.Unlock()
+
+		select {
+		case <-w.ctx.Done():
+			log.Infof("stopped watching remote zone")
+			return
+		default:
+		}
+		if status.Code(err) == codes.Unimplemented {
+			log.Infof("remote server does not support watching zones -> polling zone states")
+			h.watches.setUnsupported()
+			return
+		}
+		log.Warnf("watching remote zone failed: %s (retrying in %s)", err, watchRetryPeriod)
+		select {
+		case <-w.ctx.Done():
+			log.Infof("stopped watching remote zone")
+			return
+		case <-time.After(watchRetryPeriod):
+		}
+	}
+}
+
+func (h *Handler) watchZone(log logger.LogContext, w *zoneWatch) error {
+	var stream common.RemoteProvider_WatchZoneClient
+	var update *common.ZoneUpdate
+	err := h.retryOnInvalidTokenError(w.ctx, func(token string) error {
+		var err error
+		h.config.RateLimiter.Accept()
+		stream, err = h.client.WatchZone(w.ctx, &common.WatchZoneRequest{Token: token, Zoneid: w.zone.Id()})
+		h.config.Metrics.AddZoneRequests(w.zone.Id(), provider.M_LISTRECORDS, 1)
+		if err != nil {
+			return err
+		}
+		// errors of the server are only reported on receiving
+		update, err = stream.Recv()
+		return err
+	})
+	for err == nil {
+		h.applyZoneUpdate(log, w, update)
+		update, err = stream.Recv()
+	}
+	return err
+}
+
+// applyZoneUpdate updates the mirrored zone state and propagates the changed
+// DNS sets to the zone cache.
+func (h *Handler) applyZoneUpdate(log logger.LogContext, w *zoneWatch, update *common.ZoneUpdate) {
+	w.lock.Lock()
+	var names utils.StringSet
+	if update.Snapshot != nil {
+		sets := conversion.UnmarshalDNSSets(update.Snapshot.DnsSets)
+		names = utils.StringSet{}
+		for name := range w.sets {
+			names.Add(name)
+		}
+		for name := range sets {
+			names.Add(name)
+		}
+		w.sets = sets
+		w.synced = true
+		log.Infof("received remote zone snapshot with %d DNS sets", len(sets))
+	} else {
+		if w.sets == nil {
+			w.sets = dns.DNSSets{}
+		}
+		names = conversion.ApplyDNSSetsDelta(w.sets, update.Change)
+		log.Infof("received %d changed remote record sets", len(update.Change))
+	}
+	delta := &provider.ZoneStateDelta{Names: names, DNSSets: dns.DNSSets{}}
+	for name := range names {
+		if set := w.sets[name]; set != nil {
+			delta.DNSSets[name] = set.Clone()
+		}
+	}
+	w.lock.Unlock()
+
+	h.cache.ApplyDelta(w.zone, delta)
+}
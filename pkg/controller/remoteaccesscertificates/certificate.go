@@ -17,6 +17,9 @@
 package remoteaccesscertificates
 
 import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
@@ -27,6 +30,17 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/utils/pkiutil"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+const (
+	defaultRSAKeySize   = 2048
+	minRSAKeySize       = 2048
+	maxRSAKeySize       = 8192
+	defaultECDSAKeySize = 256
+
+	ecPrivateKeyBlockType = "EC PRIVATE KEY"
 )
 
 // CertData contains the created certificate
@@ -47,14 +61,84 @@ func CreateSubject(commonName string) pkix.Name {
 	}
 }
 
+// KeySpec describes the private key of a certificate.
+type KeySpec struct {
+	Type api.RemoteAccessCertificateKeyType
+	Size int
+}
+
+// NewKeySpec validates the key type and size and applies the defaults.
+func NewKeySpec(keyType api.RemoteAccessCertificateKeyType, size int) (KeySpec, error) {
+	switch keyType {
+	case "", api.RSAKeyType:
+		if size == 0 {
+			size = defaultRSAKeySize
+		}
+		if size < minRSAKeySize || size > maxRSAKeySize {
+			return KeySpec{}, fmt.Errorf("invalid RSA key size %d (must be between %d and %d)", size, minRSAKeySize, maxRSAKeySize)
+		}
+		return KeySpec{Type: api.RSAKeyType, Size: size}, nil
+	case api.ECDSAKeyType:
+		if size == 0 {
+			size = defaultECDSAKeySize
+		}
+		if size != 256 && size != 384 && size != 521 {
+			return KeySpec{}, fmt.Errorf("invalid ECDSA key size %d (must be 256, 384 or 521)", size)
+		}
+		return KeySpec{Type: api.ECDSAKeyType, Size: size}, nil
+	default:
+		return KeySpec{}, fmt.Errorf("invalid key type %q", keyType)
+	}
+}
+
+// generateKey creates a private key and returns it together with its PEM encoding.
+func (s KeySpec) generateKey() (crypto.Signer, []byte, error) {
+	switch s.Type {
+	case api.ECDSAKeyType:
+		var curve elliptic.Curve
+		switch s.Size {
+		case 384:
+			curve = elliptic.P384()
+		case 521:
+			curve = elliptic.P521()
+		default:
+			curve = elliptic.P256()
+		}
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyBytes, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, nil, err
+		}
+		return key, pem.EncodeToMemory(&pem.Block{Type: ecPrivateKeyBlockType, Bytes: keyBytes}), nil
+	default:
+		key, err := rsa.GenerateKey(rand.Reader, s.Size)
+		if err != nil {
+			return nil, nil, err
+		}
+		keyBytes := x509.MarshalPKCS1PrivateKey(key)
+		return key, pem.EncodeToMemory(&pem.Block{Type: pkiutil.RSAPrivateKeyBlockType, Bytes: keyBytes}), nil
+	}
+}
+
+// NewSerialNumber creates a random serial number.
+// Serial numbers must be unique, as they are used to revoke certificates.
+func NewSerialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 127))
+}
+
 // CreateCertificate creates a client or server TLS certificate.
 func CreateCertificate(caCert *x509.Certificate, caPrivateKey *rsa.PrivateKey, subject pkix.Name, dnsName string,
-	days int, serialNumber int64, isServer bool) (*CertData, error) {
-	key, _ := rsa.GenerateKey(rand.Reader, 1024)
+	days int, keySpec KeySpec, serialNumber *big.Int, isServer bool) (*CertData, error) {
+	key, tlsKey, err := keySpec.generateKey()
+	if err != nil {
+		return nil, err
+	}
 
 	csrTemplate := x509.CertificateRequest{
-		Subject:            subject,
-		SignatureAlgorithm: x509.SHA256WithRSA,
+		Subject: subject,
 	}
 	csrBytes, err := x509.CreateCertificateRequest(rand.Reader, &csrTemplate, key)
 	if err != nil {
@@ -69,14 +153,12 @@ func CreateCertificate(caCert *x509.Certificate, caPrivateKey *rsa.PrivateKey, s
 	if isServer {
 		extKeyUsage = x509.ExtKeyUsageServerAuth
 	}
+	// the signature algorithm is chosen according to the key of the CA
 	crtTemplate := x509.Certificate{
-		Signature:          csr.Signature,
-		SignatureAlgorithm: csr.SignatureAlgorithm,
-
 		PublicKeyAlgorithm: csr.PublicKeyAlgorithm,
 		PublicKey:          csr.PublicKey,
 
-		SerialNumber: big.NewInt(serialNumber),
+		SerialNumber: serialNumber,
 		Issuer:       caCert.Subject,
 		Subject:      subject,
 		NotBefore:    time.Now(),
@@ -96,8 +178,6 @@ func CreateCertificate(caCert *x509.Certificate, caPrivateKey *rsa.PrivateKey, s
 		return nil, err
 	}
 
-	keyBytes := x509.MarshalPKCS1PrivateKey(key)
-	tlsKey := pem.EncodeToMemory(&pem.Block{Type: pkiutil.RSAPrivateKeyBlockType, Bytes: keyBytes})
 	tlsCrt := pem.EncodeToMemory(&pem.Block{Type: pkiutil.CertificateBlockType, Bytes: crtBytes})
 	caCrt := pkiutil.EncodeCertPEM(caCert)
 
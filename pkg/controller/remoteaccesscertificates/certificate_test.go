@@ -0,0 +1,125 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remoteaccesscertificates
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"math/big"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func createTestCA(t *testing.T) (*x509.Certificate, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               CreateSubject("test-ca"),
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ca, key
+}
+
+func TestCreateCertificate(t *testing.T) {
+	ca, caKey := createTestCA(t)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+
+	for _, spec := range []struct {
+		keyType api.RemoteAccessCertificateKeyType
+		size    int
+	}{
+		{"", 0},
+		{api.RSAKeyType, 3072},
+		{api.ECDSAKeyType, 0},
+		{api.ECDSAKeyType, 384},
+	} {
+		keySpec, err := NewKeySpec(spec.keyType, spec.size)
+		if err != nil {
+			t.Fatalf("%s/%d: %s", spec.keyType, spec.size, err)
+		}
+		serial, err := NewSerialNumber()
+		if err != nil {
+			t.Fatal(err)
+		}
+		cdata, err := CreateCertificate(ca, caKey, CreateSubject("ns.client"), "client", 10, keySpec, serial, false)
+		if err != nil {
+			t.Fatalf("%s/%d: %s", spec.keyType, spec.size, err)
+		}
+		if _, err := tls.X509KeyPair(cdata.TLSCrt, cdata.TLSKey); err != nil {
+			t.Errorf("%s/%d: invalid key pair: %s", spec.keyType, spec.size, err)
+		}
+		if cdata.Certificate.SerialNumber.Cmp(serial) != 0 {
+			t.Errorf("%s/%d: unexpected serial number", spec.keyType, spec.size)
+		}
+		_, err = cdata.Certificate.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+		if err != nil {
+			t.Errorf("%s/%d: verification failed: %s", spec.keyType, spec.size, err)
+		}
+	}
+}
+
+func TestInvalidKeySpec(t *testing.T) {
+	for _, spec := range []struct {
+		keyType api.RemoteAccessCertificateKeyType
+		size    int
+	}{
+		{api.RSAKeyType, 1024},
+		{api.ECDSAKeyType, 2048},
+		{"DSA", 0},
+	} {
+		if _, err := NewKeySpec(spec.keyType, spec.size); err == nil {
+			t.Errorf("%s/%d: expected error", spec.keyType, spec.size)
+		}
+	}
+}
+
+func TestRenewalTime(t *testing.T) {
+	notBefore := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	notAfter := notBefore.Add(90 * 24 * time.Hour)
+
+	if r := RenewalTime(notBefore, notAfter, nil); !r.Equal(notBefore.Add(60 * 24 * time.Hour)) {
+		t.Errorf("unexpected default renewal time %s", r)
+	}
+	if r := RenewalTime(notBefore, notAfter, &metav1.Duration{Duration: 7 * 24 * time.Hour}); !r.Equal(notAfter.Add(-7 * 24 * time.Hour)) {
+		t.Errorf("unexpected renewal time %s", r)
+	}
+	if r := RenewalTime(notBefore, notAfter, &metav1.Duration{Duration: 100 * 24 * time.Hour}); !r.Equal(notBefore.Add(60 * 24 * time.Hour)) {
+		t.Errorf("unexpected renewal time for too long duration %s", r)
+	}
+}
@@ -22,13 +22,14 @@ import (
 	"crypto/x509"
 	"fmt"
 	"io/ioutil"
+	"strings"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/config"
 	"github.com/gardener/controller-manager-library/pkg/resources/apiextensions"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
-	"go.uber.org/atomic"
+	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -61,13 +62,15 @@ func init() {
 }
 
 type Config struct {
-	caKeyFile  string
-	caCertFile string
+	caKeyFile      string
+	caCertFile     string
+	revocationList string
 }
 
 func (r *Config) AddOptionsToSet(set config.OptionSet) {
 	set.AddStringOption(&r.caKeyFile, OPT_REMOTE_ACCESS_CAKEY, "", "", "filename for private key of client CA")
 	set.AddStringOption(&r.caCertFile, provider.OPT_REMOTE_ACCESS_CACERT, "", "", "filename for certificate of client CA")
+	set.AddStringOption(&r.revocationList, provider.OPT_REMOTE_ACCESS_REVOCATION_LIST, "", "", "name of secret (<namespace>/<name>) to store revoked client certificates")
 }
 
 func (r *Config) Evaluate() error {
@@ -82,8 +85,7 @@ type reconciler struct {
 	config             *Config
 	clientCACert       *x509.Certificate
 	clientCAPrivateKey *rsa.PrivateKey
-
-	nextSerialNumber atomic.Int64
+	revocationListName resources.ObjectName
 }
 
 var _ reconcile.Interface = &reconciler{}
@@ -110,6 +112,14 @@ func Create(controller controller.Interface) (reconcile.Interface, error) {
 	if err != nil {
 		return nil, err
 	}
+	var revocationListName resources.ObjectName
+	if config.revocationList != "" {
+		parts := strings.Split(config.revocationList, "/")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid format for %s: expected '<namespace>/<name>'", provider.OPT_REMOTE_ACCESS_REVOCATION_LIST)
+		}
+		revocationListName = resources.NewObjectName(parts[0], parts[1])
+	}
 	return &reconciler{
 		controller:         controller,
 		secretResources:    secretResources,
@@ -117,6 +127,7 @@ func Create(controller controller.Interface) (reconcile.Interface, error) {
 		config:             config,
 		clientCACert:       caCert,
 		clientCAPrivateKey: caPrivateKey,
+		revocationListName: revocationListName,
 	}, nil
 }
 
@@ -149,31 +160,46 @@ func loadClientCA(config *Config) (*x509.Certificate, *rsa.PrivateKey, error) {
 }
 
 func (r *reconciler) Setup() {
-	r.nextSerialNumber.Store(time.Now().Unix())
 	r.controller.Infof("### setup remote client certificate")
 	res, _ := r.controller.GetMainCluster().Resources().GetByExample(&api.RemoteAccessCertificate{})
 	list, _ := res.ListCached(labels.Everything())
 	dnsutils.ProcessElements(list, func(e resources.Object) {
-		r.reconcile(r.controller, e)
+		_, _ = r.reconcile(r.controller, e)
 	}, 1)
 }
 
 ///////////////////////////////////////////////////////////////////////////////
 
 func (r *reconciler) Reconcile(logger logger.LogContext, obj resources.Object) reconcile.Status {
-	err := r.reconcile(logger, obj)
-	return reconcile.DelayOnError(logger, err)
+	if r.needsRevocationOnDelete(obj.Data().(*api.RemoteAccessCertificate)) {
+		if err := r.controller.SetFinalizer(obj); err != nil {
+			return reconcile.Delay(logger, err)
+		}
+	}
+	renewAfter, err := r.reconcile(logger, obj)
+	if err != nil {
+		return reconcile.Delay(logger, err)
+	}
+	if renewAfter > 0 {
+		return reconcile.RescheduleAfter(logger, renewAfter)
+	}
+	return reconcile.Succeeded(logger)
 }
 
-func (r *reconciler) reconcile(logger logger.LogContext, obj resources.Object) error {
+// reconcile creates, renews or revokes the certificate and returns the duration until the next renewal.
+func (r *reconciler) reconcile(logger logger.LogContext, obj resources.Object) (time.Duration, error) {
 	cert := obj.Data().(*api.RemoteAccessCertificate)
 
+	if cert.Spec.Revoked {
+		return 0, r.revoke(logger, cert)
+	}
+
 	hasSecret := cert.Status.NotBefore != nil
 	if hasSecret {
 		_, err := r.getSecret(cert.Namespace, cert.Spec.SecretName)
 		if err != nil {
 			if !errors.IsNotFound(err) {
-				return err
+				return 0, err
 			}
 			hasSecret = false
 		}
@@ -181,50 +207,89 @@ func (r *reconciler) reconcile(logger logger.LogContext, obj resources.Object) e
 
 	if hasSecret && cert.Spec.Recreate {
 		logger.Infof("prepare for recreating certificate secret")
-		return r.resetForCertificatRecreation(cert)
+		return 0, r.resetForCertificatRecreation(cert)
 	}
 
 	if hasSecret && !cert.Status.Recreating {
-		return nil
+		renewalTime := RenewalTime(cert.Status.NotBefore.Time, cert.Status.NotAfter.Time, cert.Spec.RenewBefore)
+		if d := time.Until(renewalTime); d > 0 {
+			return d, r.updateRenewalTime(cert, renewalTime)
+		}
+		logger.Infof("renewing certificate (expiration %s)", cert.Status.NotAfter.Time)
+	}
+
+	keySpec, err := NewKeySpec(cert.Spec.KeyType, cert.Spec.KeySize)
+	if err != nil {
+		return 0, r.updateMessage(cert, err)
 	}
 
+	var cdata *CertData
 	switch cert.Spec.Type {
 	case api.ServerType:
-		return r.createServerCertificate(cert)
+		cdata, err = r.createServerCertificate(cert, keySpec)
 	case api.ClientType:
-		return r.createClientCertificate(cert)
+		cdata, err = r.createClientCertificate(cert, keySpec)
 	default:
-		return fmt.Errorf("invalid .spec.type %s", cert.Spec.Type)
+		return 0, fmt.Errorf("invalid .spec.type %s", cert.Spec.Type)
+	}
+	if err != nil {
+		return 0, err
+	}
+	return time.Until(RenewalTime(cdata.Certificate.NotBefore, cdata.Certificate.NotAfter, cert.Spec.RenewBefore)), nil
+}
+
+// RenewalTime calculates the time for the renewal of a certificate.
+// Without explicit duration, the certificate is renewed after two thirds of its validity period.
+func RenewalTime(notBefore, notAfter time.Time, renewBefore *metav1.Duration) time.Time {
+	validity := notAfter.Sub(notBefore)
+	before := validity / 3
+	if renewBefore != nil && renewBefore.Duration > 0 && renewBefore.Duration < validity {
+		before = renewBefore.Duration
+	}
+	return notAfter.Add(-before)
+}
+
+func commonName(cert *api.RemoteAccessCertificate) string {
+	if cert.Spec.Type == api.ClientType {
+		return cert.Namespace + "." + cert.Spec.DomainName
 	}
+	return cert.Spec.DomainName
 }
 
-func (r *reconciler) createServerCertificate(cert *api.RemoteAccessCertificate) error {
-	subject := CreateSubject(cert.Spec.DomainName)
+func (r *reconciler) createServerCertificate(cert *api.RemoteAccessCertificate, keySpec KeySpec) (*CertData, error) {
+	subject := CreateSubject(commonName(cert))
 
+	serialNumber, err := NewSerialNumber()
+	if err != nil {
+		return nil, err
+	}
 	cdata, err := CreateCertificate(r.clientCACert, r.clientCAPrivateKey, subject, cert.Spec.DomainName,
-		cert.Spec.Days, r.nextSerialNumber.Inc(), true)
+		cert.Spec.Days, keySpec, serialNumber, true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return r.writeSecretAndStatus(cert, cdata, nil)
+	return cdata, r.writeSecretAndStatus(cert, cdata, nil)
 }
 
-func (r *reconciler) createClientCertificate(cert *api.RemoteAccessCertificate) error {
-	commonName := cert.Namespace + "." + cert.Spec.DomainName
-	subject := CreateSubject(commonName)
+func (r *reconciler) createClientCertificate(cert *api.RemoteAccessCertificate, keySpec KeySpec) (*CertData, error) {
+	subject := CreateSubject(commonName(cert))
 
+	serialNumber, err := NewSerialNumber()
+	if err != nil {
+		return nil, err
+	}
 	cdata, err := CreateCertificate(r.clientCACert, r.clientCAPrivateKey, subject, cert.Spec.DomainName,
-		cert.Spec.Days, r.nextSerialNumber.Inc(), false)
+		cert.Spec.Days, keySpec, serialNumber, false)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	additionalData := map[string][]byte{
 		"NAMESPACE": []byte(cert.Namespace),
 	}
 
-	return r.writeSecretAndStatus(cert, cdata, additionalData)
+	return cdata, r.writeSecretAndStatus(cert, cdata, additionalData)
 }
 
 func (r *reconciler) writeSecretAndStatus(cert *api.RemoteAccessCertificate, cdata *CertData, additionalData map[string][]byte) error {
@@ -262,9 +327,13 @@ func (r *reconciler) writeSecretAndStatus(cert *api.RemoteAccessCertificate, cda
 		o.Status.NotBefore = &metav1.Time{Time: cdata.Certificate.NotBefore}
 		sn := cdata.Certificate.SerialNumber.String()
 		o.Status.SerialNumber = &sn
+		o.Status.IssuedCertificates = addIssuedCertificate(o.Status.IssuedCertificates,
+			api.IssuedCertificate{SerialNumber: sn, NotAfter: *o.Status.NotAfter}, time.Now())
+		o.Status.RenewalTime = &metav1.Time{Time: RenewalTime(cdata.Certificate.NotBefore, cdata.Certificate.NotAfter, o.Spec.RenewBefore)}
 		mod.Modify(true)
 		mod.AssureStringValue(&o.Status.Message, "")
 		mod.AssureBoolValue(&o.Status.Recreating, false)
+		mod.AssureBoolValue(&o.Status.Revoked, false)
 		return mod.IsModified(), nil
 	})
 
@@ -288,10 +357,153 @@ func (r *reconciler) resetForCertificatRecreation(certobj *api.RemoteAccessCerti
 	return err
 }
 
+func (r *reconciler) updateRenewalTime(cert *api.RemoteAccessCertificate, renewalTime time.Time) error {
+	if cert.Status.RenewalTime != nil && cert.Status.RenewalTime.Time.Equal(renewalTime) {
+		return nil
+	}
+	_, _, err := r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
+		o := data.(*api.RemoteAccessCertificate)
+		o.Status.RenewalTime = &metav1.Time{Time: renewalTime}
+		return true, nil
+	})
+	return err
+}
+
+func (r *reconciler) updateMessage(cert *api.RemoteAccessCertificate, err error) error {
+	_, _, err2 := r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
+		o := data.(*api.RemoteAccessCertificate)
+		mod := utils.ModificationState{}
+		mod.AssureStringValue(&o.Status.Message, err.Error())
+		return mod.IsModified(), nil
+	})
+	if err2 != nil {
+		return err2
+	}
+	return err
+}
+
+// revoke adds the certificate to the revocation list and deletes its secret.
+func (r *reconciler) revoke(logger logger.LogContext, cert *api.RemoteAccessCertificate) error {
+	if cert.Status.Revoked {
+		return nil
+	}
+	if err := r.addToRevocationList(logger, cert); err != nil {
+		return r.updateMessage(cert, err)
+	}
+	if err := r.deleteSecret(cert.Namespace, cert.Spec.SecretName); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	logger.Infof("certificate revoked")
+	_, _, err := r.certResources.ModifyStatus(cert, func(data resources.ObjectData) (bool, error) {
+		o := data.(*api.RemoteAccessCertificate)
+		mod := utils.ModificationState{}
+		mod.AssureBoolValue(&o.Status.Revoked, true)
+		mod.AssureStringValue(&o.Status.Message, "certificate revoked")
+		if o.Status.RenewalTime != nil {
+			o.Status.RenewalTime = nil
+			mod.Modify(true)
+		}
+		return mod.IsModified(), nil
+	})
+	return err
+}
+
+// addIssuedCertificate adds a new issued certificate to the list and removes all expired ones.
+func addIssuedCertificate(issued []api.IssuedCertificate, cert api.IssuedCertificate, now time.Time) []api.IssuedCertificate {
+	var result []api.IssuedCertificate
+	for _, c := range issued {
+		if c.NotAfter.Time.After(now) && c.SerialNumber != cert.SerialNumber {
+			result = append(result, c)
+		}
+	}
+	return append(result, cert)
+}
+
+// certificatesToRevoke returns all issued certificates of the object, which may still be in use.
+// Besides the current certificate, this includes all earlier certificates replaced by renewal or recreation.
+func certificatesToRevoke(cert *api.RemoteAccessCertificate) []api.IssuedCertificate {
+	certs := cert.Status.IssuedCertificates
+	if cert.Status.SerialNumber != nil && cert.Status.NotAfter != nil {
+		// status written before the issued certificates have been recorded
+		certs = addIssuedCertificate(certs, api.IssuedCertificate{
+			SerialNumber: *cert.Status.SerialNumber,
+			NotAfter:     *cert.Status.NotAfter,
+		}, time.Time{})
+	}
+	return certs
+}
+
+func (r *reconciler) addToRevocationList(logger logger.LogContext, cert *api.RemoteAccessCertificate) error {
+	certs := certificatesToRevoke(cert)
+	if len(certs) == 0 {
+		// no certificate issued yet
+		return nil
+	}
+	if r.revocationListName == nil {
+		return fmt.Errorf("cannot revoke certificate: missing option %s", provider.OPT_REMOTE_ACCESS_REVOCATION_LIST)
+	}
+
+	secret, err := r.getSecret(r.revocationListName.Namespace(), r.revocationListName.Name())
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		secret = &corev1.Secret{}
+		secret.Namespace = r.revocationListName.Namespace()
+		secret.Name = r.revocationListName.Name()
+		secret.Type = corev1.SecretTypeOpaque
+	}
+	list, err := embed.RevocationListFromSecret(secret)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	mod := list.Prune(now)
+	var serials []string
+	for _, c := range certs {
+		if !c.NotAfter.Time.After(now) {
+			continue
+		}
+		serials = append(serials, c.SerialNumber)
+		mod = list.Add(embed.RevokedCertificate{
+			SerialNumber:   c.SerialNumber,
+			CommonName:     commonName(cert),
+			NotAfter:       c.NotAfter,
+			RevocationTime: metav1.Time{Time: now},
+		}) || mod
+	}
+	if !mod {
+		return nil
+	}
+	data, err := list.Marshal()
+	if err != nil {
+		return err
+	}
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[embed.RevocationListKey] = data
+	if _, err := r.secretResources.CreateOrUpdate(secret); err != nil {
+		return fmt.Errorf("cannot update revocation list %s: %w", r.revocationListName, err)
+	}
+	logger.Infof("added certificates with serial numbers %s to revocation list %s", strings.Join(serials, ", "), r.revocationListName)
+	return nil
+}
+
 func (r *reconciler) Delete(logger logger.LogContext, obj resources.Object) reconcile.Status {
 	cert := obj.Data().(*api.RemoteAccessCertificate)
+	if r.needsRevocationOnDelete(cert) {
+		// certificates of deleted objects must not be usable anymore
+		if err := r.addToRevocationList(logger, cert); err != nil {
+			return reconcile.Delay(logger, err)
+		}
+	}
 	r.deleteSecret(cert.Namespace, cert.Spec.SecretName)
-	return reconcile.Succeeded(logger)
+	return reconcile.DelayOnError(logger, r.controller.RemoveFinalizer(obj))
+}
+
+func (r *reconciler) needsRevocationOnDelete(cert *api.RemoteAccessCertificate) bool {
+	return cert.Spec.Type == api.ClientType && !cert.Status.Revoked && r.revocationListName != nil
 }
 
 func (r *reconciler) Deleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
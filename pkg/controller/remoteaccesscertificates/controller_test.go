@@ -0,0 +1,64 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package remoteaccesscertificates
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+func TestCertificatesToRevoke(t *testing.T) {
+	now := time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)
+	issued := func(sn string, notAfter time.Time) api.IssuedCertificate {
+		return api.IssuedCertificate{SerialNumber: sn, NotAfter: metav1.Time{Time: notAfter}}
+	}
+
+	// renewals keep the earlier certificates until they are expired
+	var certs []api.IssuedCertificate
+	certs = addIssuedCertificate(certs, issued("1", now.Add(time.Hour)), now)
+	certs = addIssuedCertificate(certs, issued("2", now.Add(2*time.Hour)), now)
+	certs = addIssuedCertificate(certs, issued("3", now.Add(3*time.Hour)), now.Add(90*time.Minute))
+	expected := []api.IssuedCertificate{issued("2", now.Add(2*time.Hour)), issued("3", now.Add(3*time.Hour))}
+	if !reflect.DeepEqual(certs, expected) {
+		t.Errorf("expected %v, got %v", expected, certs)
+	}
+
+	cert := &api.RemoteAccessCertificate{}
+	if revoke := certificatesToRevoke(cert); len(revoke) != 0 {
+		t.Errorf("expected no certificates without issued certificate, got %v", revoke)
+	}
+
+	// status without issued certificates
+	sn := "4"
+	cert.Status.SerialNumber = &sn
+	cert.Status.NotAfter = &metav1.Time{Time: now.Add(4 * time.Hour)}
+	expected = []api.IssuedCertificate{issued("4", now.Add(4*time.Hour))}
+	if revoke := certificatesToRevoke(cert); !reflect.DeepEqual(revoke, expected) {
+		t.Errorf("expected %v, got %v", expected, revoke)
+	}
+
+	cert.Status.IssuedCertificates = append(certs, issued("4", now.Add(4*time.Hour)))
+	expected = []api.IssuedCertificate{issued("2", now.Add(2*time.Hour)), issued("3", now.Add(3*time.Hour)), issued("4", now.Add(4*time.Hour))}
+	if revoke := certificatesToRevoke(cert); !reflect.DeepEqual(revoke, expected) {
+		t.Errorf("expected %v, got %v", expected, revoke)
+	}
+}
@@ -57,12 +57,15 @@ func (this *DNSEntrySource) GetDNSInfo(logger logger.LogContext, obj resources.O
 	data := obj.Data().(*api.DNSEntry)
 
 	info := &source.DNSInfo{
-		Names:    utils.NewStringSet(data.Spec.DNSName),
-		Targets:  utils.NewStringSetByArray(data.Spec.Targets),
-		Text:     utils.NewStringSetByArray(data.Spec.Text),
-		OrigRef:  data.Spec.Reference,
-		TTL:      data.Spec.TTL,
-		Interval: data.Spec.CNameLookupInterval,
+		Names:            utils.NewStringSet(data.Spec.DNSName),
+		Targets:          utils.NewStringSetByArray(data.Spec.Targets),
+		Text:             utils.NewStringSetByArray(data.Spec.Text),
+		PrivateTargets:   utils.NewStringSetByArray(data.Spec.PrivateTargets),
+		OrigRef:          data.Spec.Reference,
+		TTL:              data.Spec.TTL,
+		Interval:         data.Spec.CNameLookupInterval,
+		ProviderRef:      data.Spec.ProviderRef,
+		ProviderSelector: data.Spec.ProviderSelector,
 	}
 	return info, nil
 }
@@ -17,6 +17,13 @@
 package dns
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/gardener/controller-manager-library/pkg/utils"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
@@ -114,6 +121,15 @@ const (
 
 	ATTR_TIMESTAMP = "ts"
 	ATTR_LOCKID    = "lockid"
+
+	// ATTR_SHARED marks a record set shared by multiple entries
+	ATTR_SHARED = "shared"
+	// ATTR_CONTRIBUTOR_PREFIX is the prefix of the attributes listing the
+	// values contributed to a shared record set per owner id
+	ATTR_CONTRIBUTOR_PREFIX = "shared."
+
+	// MaxTXTStringLength is the maximum length of a character string of a TXT record
+	MaxTXTStringLength = 255
 )
 
 type DNSSet struct {
@@ -217,6 +233,86 @@ func (this *DNSSet) SetKind(t string, prop ...bool) *DNSSet {
 	return this
 }
 
+func (this *DNSSet) IsShared() bool {
+	return this.GetMetaAttr(ATTR_SHARED) == "true"
+}
+
+// GetContributors returns the keys of the values contributed to a shared
+// record set per owner id.
+func (this *DNSSet) GetContributors() map[string]utils.StringSet {
+	result := map[string]utils.StringSet{}
+	for name, value := range this.Sets[RS_META].GetAttrsWithPrefix(ATTR_CONTRIBUTOR_PREFIX) {
+		ownerid := contributorOwnerId(name[len(ATTR_CONTRIBUTOR_PREFIX):])
+		keys := result[ownerid]
+		if keys == nil {
+			keys = utils.StringSet{}
+			result[ownerid] = keys
+		}
+		if value != "" {
+			keys.AddAll(strings.Split(value, ","))
+		}
+	}
+	return result
+}
+
+// SetContributor sets the keys of the values contributed by an owner id.
+// The keys are split into several attributes, so that each TXT string
+// stays within the limit of MaxTXTStringLength characters.
+func (this *DNSSet) SetContributor(ownerid string, keys utils.StringSet) {
+	this.DeleteContributor(ownerid)
+	list := keys.AsArray()
+	sort.Strings(list)
+	chunk := 0
+	value := ""
+	for _, key := range list {
+		next := key
+		if value != "" {
+			next = value + "," + key
+		}
+		if value != "" && len(newAttrValue(contributorAttrName(ownerid, chunk), next)) > MaxTXTStringLength+2 {
+			this.SetMetaAttr(contributorAttrName(ownerid, chunk), value)
+			chunk++
+			next = key
+		}
+		value = next
+	}
+	this.SetMetaAttr(contributorAttrName(ownerid, chunk), value)
+}
+
+func (this *DNSSet) DeleteContributor(ownerid string) {
+	for name := range this.Sets[RS_META].GetAttrsWithPrefix(ATTR_CONTRIBUTOR_PREFIX) {
+		if contributorOwnerId(name[len(ATTR_CONTRIBUTOR_PREFIX):]) == ownerid {
+			this.DeleteMetaAttr(name)
+		}
+	}
+}
+
+// contributorAttrName returns the attribute name of a chunk of the contributed keys.
+// The first chunk uses the plain attribute name.
+func contributorAttrName(ownerid string, chunk int) string {
+	if chunk == 0 {
+		return ATTR_CONTRIBUTOR_PREFIX + ownerid
+	}
+	return fmt.Sprintf("%s%s#%d", ATTR_CONTRIBUTOR_PREFIX, ownerid, chunk)
+}
+
+// contributorOwnerId strips the chunk suffix from a contributor attribute name.
+func contributorOwnerId(name string) string {
+	if i := strings.LastIndex(name, "#"); i > 0 {
+		if _, err := strconv.Atoi(name[i+1:]); err == nil {
+			return name[:i]
+		}
+	}
+	return name
+}
+
+// ContributionKey returns the key of a record value used to track the
+// contributors of a shared record set.
+func ContributionKey(rtype, value string) string {
+	sum := sha256.Sum256([]byte(rtype + "=" + value))
+	return hex.EncodeToString(sum[:])[:8]
+}
+
 func (this *DNSSet) SetRecordSet(rtype string, ttl int64, values ...string) {
 	records := make([]*Record, len(values))
 	for i, r := range values {
@@ -0,0 +1,62 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dns
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+func TestSetContributorSplitsLongValues(t *testing.T) {
+	keys := utils.StringSet{}
+	for i := 0; i < 100; i++ {
+		keys.Add(ContributionKey(RS_A, fmt.Sprintf("10.0.0.%d", i)))
+	}
+
+	set := NewDNSSet("a.example.com")
+	set.SetContributor("owner1", keys)
+	set.SetContributor("owner2", utils.NewStringSet(ContributionKey(RS_A, "1.1.1.1")))
+
+	meta := set.Sets[RS_META]
+	if len(meta.Records) < 5 {
+		t.Errorf("expected contributions of owner1 to be split, got %d records", len(meta.Records))
+	}
+	for _, r := range meta.Records {
+		if l := len(r.Value) - 2; l > MaxTXTStringLength {
+			t.Errorf("TXT string too long (%d characters): %s", l, r.Value)
+		}
+	}
+	contributors := set.GetContributors()
+	if len(contributors) != 2 || !reflect.DeepEqual(contributors["owner1"], keys) {
+		t.Errorf("unexpected contributors: %v", contributors)
+	}
+
+	// shrinking the contributions removes the surplus chunks
+	set.SetContributor("owner1", utils.NewStringSet(ContributionKey(RS_A, "2.2.2.2")))
+	if len(meta.Records) != 2 {
+		t.Errorf("expected 2 records, got %d", len(meta.Records))
+	}
+
+	set.DeleteContributor("owner1")
+	contributors = set.GetContributors()
+	if len(contributors) != 1 || contributors["owner1"] != nil {
+		t.Errorf("unexpected contributors: %v", contributors)
+	}
+}
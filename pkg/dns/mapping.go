@@ -72,6 +72,12 @@ func calcMetaRecordDomainName(name, prefix, base string) string {
 	return add + prefix + name
 }
 
+// ProviderNames returns the record names used in a hosted zone with the given base domain
+// to store the records of a DNS name including its metadata TXT record.
+func ProviderNames(name, base string) []string {
+	return []string{name, calcMetaRecordDomainName(name, TxtPrefix, base)}
+}
+
 // CalcMetaRecordDomainNameForValidation returns domain name of metadata TXT DNS record if globally defined prefix is used.
 // As it does not consider the zone, it may be wrong for the zone base domain.
 func CalcMetaRecordDomainNameForValidation(name string) string {
@@ -0,0 +1,85 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+// accountZoneLimiter bounds the number of concurrent zone reconciliations
+// in total and per provider account (identified by the account hash).
+type accountZoneLimiter struct {
+	lock    sync.Mutex
+	limit   int
+	total   int
+	running int
+	active  map[string]int
+}
+
+func newAccountZoneLimiter(limit, total int) *accountZoneLimiter {
+	return &accountZoneLimiter{
+		limit:  limit,
+		total:  total,
+		active: map[string]int{},
+	}
+}
+
+// TryAcquire reserves a reconciliation slot for all given accounts.
+// If the total limit or any of the accounts has reached its limit,
+// nothing is reserved and false is returned.
+func (this *accountZoneLimiter) TryAcquire(accounts utils.StringSet) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.total > 0 && this.running >= this.total {
+		return false
+	}
+	if this.limit > 0 {
+		for a := range accounts {
+			if this.active[a] >= this.limit {
+				return false
+			}
+		}
+	}
+	this.running++
+	for a := range accounts {
+		this.active[a]++
+	}
+	return true
+}
+
+func (this *accountZoneLimiter) Release(accounts utils.StringSet) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.running > 0 {
+		this.running--
+	}
+	for a := range accounts {
+		if this.active[a] <= 1 {
+			delete(this.active, a)
+		} else {
+			this.active[a]--
+		}
+	}
+}
+
+func (this *accountZoneLimiter) Active(account string) int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	return this.active[account]
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Account zone limiter", func() {
+	a := utils.NewStringSet("a")
+	b := utils.NewStringSet("b")
+	ab := utils.NewStringSet("a", "b")
+
+	ginkgo.It("limits concurrent reconciliations per account", func() {
+		limiter := newAccountZoneLimiter(2, 0)
+		Expect(limiter.TryAcquire(a)).To(BeTrue())
+		Expect(limiter.TryAcquire(ab)).To(BeTrue())
+		Expect(limiter.TryAcquire(a)).To(BeFalse())
+		Expect(limiter.TryAcquire(b)).To(BeTrue())
+		Expect(limiter.TryAcquire(ab)).To(BeFalse())
+		Expect(limiter.Active("a")).To(Equal(2))
+		Expect(limiter.Active("b")).To(Equal(2))
+
+		limiter.Release(ab)
+		Expect(limiter.TryAcquire(a)).To(BeTrue())
+		Expect(limiter.TryAcquire(ab)).To(BeFalse())
+		Expect(limiter.TryAcquire(b)).To(BeTrue())
+		Expect(limiter.Active("a")).To(Equal(2))
+	})
+
+	ginkgo.It("is unlimited for limit 0", func() {
+		limiter := newAccountZoneLimiter(0, 0)
+		for i := 0; i < 10; i++ {
+			Expect(limiter.TryAcquire(a)).To(BeTrue())
+		}
+	})
+
+	ginkgo.It("limits concurrent reconciliations in total", func() {
+		limiter := newAccountZoneLimiter(0, 2)
+		Expect(limiter.TryAcquire(a)).To(BeTrue())
+		Expect(limiter.TryAcquire(b)).To(BeTrue())
+		Expect(limiter.TryAcquire(ab)).To(BeFalse())
+		limiter.Release(a)
+		Expect(limiter.TryAcquire(ab)).To(BeTrue())
+		Expect(limiter.Active("a")).To(Equal(1))
+		Expect(limiter.Active("b")).To(Equal(2))
+	})
+})
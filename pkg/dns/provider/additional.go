@@ -0,0 +1,410 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Additional zones
+//
+// Besides the zone of the assigned provider, an entry may be maintained in
+// additional hosted zones, for example in the private zone of a split-horizon
+// entry. The additional zones are determined with the premise of the entry.
+// The records are maintained by the reconciliation of the additional zone and
+// the result is reported per zone in the status of the entry. Records in zones
+// not used anymore are removed by the cleanup of unapplied record sets of the
+// zone reconciliation. Entries are only handled by the replica responsible for
+// their assigned zone, therefore additional zones of other shards are not
+// maintained and reported as such.
+////////////////////////////////////////////////////////////////////////////////
+
+// additionalZone is a hosted zone an entry is maintained in besides the zone
+// of the assigned provider.
+type additionalZone struct {
+	zoneid   string
+	ptype    string
+	provider DNSProvider
+	private  bool
+	// shard is the replica responsible for the zone if it is not handled locally
+	shard string
+}
+
+func (this *additionalZone) String() string {
+	if this.shard != "" {
+		return fmt.Sprintf("%s(%s)@%s", this.zoneid, Provider(this.provider), this.shard)
+	}
+	return fmt.Sprintf("%s(%s)", this.zoneid, Provider(this.provider))
+}
+
+// IsLocal reports whether the zone is handled by the local replica.
+func (this *additionalZone) IsLocal() bool {
+	return this.shard == ""
+}
+
+type additionalZones []*additionalZone
+
+func (this additionalZones) Get(zoneid string) *additionalZone {
+	for _, z := range this {
+		if z.zoneid == zoneid {
+			return z
+		}
+	}
+	return nil
+}
+
+// ZoneIds returns the ids of the zones handled by the local replica.
+func (this additionalZones) ZoneIds() utils.StringSet {
+	set := utils.StringSet{}
+	for _, z := range this {
+		if z.IsLocal() {
+			set.Add(z.zoneid)
+		}
+	}
+	return set
+}
+
+func (this additionalZones) HasProvider(name resources.ObjectName) bool {
+	for _, z := range this {
+		if z.provider != nil && z.provider.ObjectName() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (this additionalZones) Equals(zones additionalZones) bool {
+	if len(this) != len(zones) {
+		return false
+	}
+	for i, z := range this {
+		if z.zoneid != zones[i].zoneid || z.provider != zones[i].provider || z.shard != zones[i].shard {
+			return false
+		}
+	}
+	return true
+}
+
+func (this additionalZones) String() string {
+	list := make([]string, len(this))
+	for i, z := range this {
+		list[i] = z.String()
+	}
+	return "[" + strings.Join(list, ", ") + "]"
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// setAdditionalZones indexes an entry for its additional zones. Zones the entry
+// has been removed from are triggered to clean up the records.
+func (this *state) setAdditionalZones(logger logger.LogContext, e *Entry, zoneids utils.StringSet) {
+	for zoneid := range this.dnsnames.SetAdditional(e, zoneids) {
+		if this.zones[zoneid] != nil {
+			logger.Infof("entry %q removed from additional zone %s -> trigger zone", e.ObjectName(), zoneid)
+			this.triggerHostedZone(zoneid)
+		}
+	}
+}
+
+// addAdditionalEntriesForZone determines the entries to be maintained in a zone
+// as additional zone. If several entries claim the same dns name, the earliest
+// one is used. Entries for dns names claimed by an entry assigned to the zone are
+// reported as conflicts.
+func (this *state) addAdditionalEntriesForZone(logger logger.LogContext, stale DNSNames, zone DNSHostedZone) (Entries, map[resources.ObjectName]error) {
+	entries := Entries{}
+	conflicts := map[resources.ObjectName]error{}
+	names := map[string]*Entry{}
+	for _, e := range this.dnsnames.AdditionalForZone(zone.Id()) {
+		if e.duplicate || !e.IsActive() || e.IsDeleting() {
+			continue
+		}
+		name := ZonedDNSName{ZoneID: zone.Id(), DNSName: e.DNSName()}
+		if cur := this.dnsnames.Get(name); cur != nil {
+			conflicts[e.ObjectName()] = &perrs.AlreadyBusyForEntry{DNSName: e.DNSName(), ObjectName: cur.ObjectName()}
+			entries[e.ObjectName()] = e
+			continue
+		}
+		if !e.IsValid() {
+			if e.KeepRecords() {
+				stale[name] = e
+			}
+			continue
+		}
+		if cur := names[e.DNSName()]; cur != nil {
+			if cur.Before(e) {
+				conflicts[e.ObjectName()] = &perrs.AlreadyBusyForEntry{DNSName: e.DNSName(), ObjectName: cur.ObjectName()}
+				entries[e.ObjectName()] = e
+				continue
+			}
+			conflicts[cur.ObjectName()] = &perrs.AlreadyBusyForEntry{DNSName: e.DNSName(), ObjectName: e.ObjectName()}
+		}
+		names[e.DNSName()] = e
+		entries[e.ObjectName()] = e
+	}
+	if len(entries) > 0 {
+		logger.Infof("found %d entries for additional zone %s (%d conflicts)", len(entries), zone.Id(), len(conflicts))
+	}
+	return entries, conflicts
+}
+
+// reconcileAdditionalEntry maintains the records of an entry in an additional zone.
+func (this *state) reconcileAdditionalEntry(logger logger.LogContext, req *zoneReconciliation, changes *ChangeModel, e *Entry) ChangeResult {
+	zone := e.additional.Get(req.zone.Id())
+	if zone == nil {
+		return ChangeResult{}
+	}
+	targets := e.targetsFor(zone)
+	done := &additionalZoneStatusUpdate{Entry: e, logger: logger, zone: zone, targets: targets}
+	if err := req.conflicts[e.ObjectName()]; err != nil {
+		logger.Warnf("%s in additional zone %s", err, zone.zoneid)
+		done.Failed(err)
+		return ChangeResult{}
+	}
+	spec := e.object.GetTargetSpec(&zoneTargetProvider{Entry: e, targets: targets})
+	return changes.Apply(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), done, spec)
+}
+
+// zoneTargetProvider provides the targets of an entry for an additional zone.
+type zoneTargetProvider struct {
+	*Entry
+	targets Targets
+}
+
+func (this *zoneTargetProvider) Targets() Targets {
+	return this.targets
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// additionalZoneStatusUpdate reports the result of the reconciliation of an
+// additional zone in the status of the entry.
+type additionalZoneStatusUpdate struct {
+	*Entry
+	logger  logger.LogContext
+	zone    *additionalZone
+	targets Targets
+	done    bool
+}
+
+var _ DoneHandler = &additionalZoneStatusUpdate{}
+
+func (this *additionalZoneStatusUpdate) SetInvalid(err error) {
+	this.update(api.STATE_INVALID, err.Error())
+}
+
+func (this *additionalZoneStatusUpdate) Failed(err error) {
+	this.update(api.STATE_ERROR, err.Error())
+}
+
+func (this *additionalZoneStatusUpdate) Throttled() {
+	if _, err := this.UpdateAdditionalZoneStatus(this.logger, this.zone, api.STATE_PENDING, MSG_THROTTLING, nil); err != nil {
+		this.logger.Errorf("cannot update: %s", err)
+	}
+}
+
+func (this *additionalZoneStatusUpdate) Succeeded() {
+	this.update(api.STATE_READY, "dns entry active")
+}
+
+func (this *additionalZoneStatusUpdate) update(state, msg string) {
+	if this.done {
+		return
+	}
+	this.done = true
+	var targets Targets
+	if state == api.STATE_READY {
+		targets = this.targets
+	}
+	if _, err := this.UpdateAdditionalZoneStatus(this.logger, this.zone, state, msg, targets); err != nil {
+		this.logger.Errorf("cannot update: %s", err)
+	}
+}
+
+// UpdateAdditionalZoneStatus updates the status of the entry for an additional zone.
+func (this *EntryVersion) UpdateAdditionalZoneStatus(logger logger.LogContext, zone *additionalZone, state, msg string, targets Targets) (bool, error) {
+	f := func(data resources.ObjectData) (bool, error) {
+		entry, ok := data.(*api.DNSEntry)
+		if !ok {
+			return false, nil
+		}
+		mod := &utils.ModificationState{}
+		assureAdditionalZoneStatus(mod, &entry.Status, zone, state, msg, targets)
+		if mod.IsModified() {
+			logger.Infof("update state of '%s/%s' for additional zone %s to %s (%s)", entry.Namespace, entry.Name, zone.zoneid, state, msg)
+		}
+		return mod.IsModified(), nil
+	}
+	return this.object.ModifyStatus(f)
+}
+
+// assureAdditionalZoneStatus sets the status of an entry for an additional zone.
+func assureAdditionalZoneStatus(mod *utils.ModificationState, entryStatus *api.DNSEntryStatus, zone *additionalZone, state, msg string, targets Targets) {
+	var status *api.DNSEntryZoneStatus
+	for i := range entryStatus.AdditionalZones {
+		if entryStatus.AdditionalZones[i].Zone == zone.zoneid {
+			status = &entryStatus.AdditionalZones[i]
+		}
+	}
+	if status == nil {
+		entryStatus.AdditionalZones = append(entryStatus.AdditionalZones, api.DNSEntryZoneStatus{Zone: zone.zoneid})
+		status = &entryStatus.AdditionalZones[len(entryStatus.AdditionalZones)-1]
+		mod.Modify(true)
+	}
+	mod.AssureStringValue(&status.Provider, Provider(zone.provider)).
+		AssureStringValue(&status.ProviderType, zone.ptype).
+		AssureBoolValue(&status.Private, zone.private).
+		AssureStringValue(&status.State, state).
+		AssureStringPtrValue(&status.Message, msg)
+	if targets != nil || state != api.STATE_PENDING {
+		list, _ := targetList(targets)
+		mod.AssureStringSet(&status.Targets, utils.NewStringSetByArray(list))
+	}
+}
+
+// reportForeignAdditionalZones reports additional zones handled by other replicas
+// as not maintained in the status of an entry.
+func reportForeignAdditionalZones(mod *utils.ModificationState, status *api.DNSEntryStatus, zones additionalZones) {
+	for _, z := range zones {
+		if !z.IsLocal() {
+			msg := fmt.Sprintf("zone is handled by shard %q: entry not maintained in this zone", z.shard)
+			assureAdditionalZoneStatus(mod, status, z, api.STATE_ERROR, msg, nil)
+		}
+	}
+}
+
+// pruneAdditionalZoneStatus removes the status of zones not used as additional zones anymore.
+func pruneAdditionalZoneStatus(status *api.DNSEntryStatus, zones additionalZones) bool {
+	var list []api.DNSEntryZoneStatus
+	for _, s := range status.AdditionalZones {
+		if zones.Get(s.Zone) != nil {
+			list = append(list, s)
+		}
+	}
+	if len(list) == len(status.AdditionalZones) {
+		return false
+	}
+	status.AdditionalZones = list
+	return true
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+// pendingZoneCleanup describes the additional zones of a deleted entry still to be
+// cleaned up.
+type pendingZoneCleanup struct {
+	activezone string
+	zones      utils.StringSet
+}
+
+// pendingZoneCleanups keeps track of the additional zones of deleted entries. The
+// finalizer of a deleted entry is removed only after the records of the entry have
+// been removed from all its additional zones. Entries are kept until they are
+// removed from the state, so that zones already cleaned up are not registered
+// again by later reconciliations of the deleted entry.
+type pendingZoneCleanups struct {
+	lock    sync.Mutex
+	entries map[resources.ObjectName]*pendingZoneCleanup
+}
+
+func newPendingZoneCleanups() *pendingZoneCleanups {
+	return &pendingZoneCleanups{entries: map[resources.ObjectName]*pendingZoneCleanup{}}
+}
+
+// Add registers the additional zones of a deleted entry. For an entry already
+// registered, only the zones still pending are kept.
+func (this *pendingZoneCleanups) Add(e *Entry, zoneids utils.StringSet) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if c := this.entries[e.ObjectName()]; c != nil {
+		c.activezone = e.activezone
+		c.zones = c.zones.Intersect(zoneids)
+		return
+	}
+	if len(zoneids) > 0 {
+		this.entries[e.ObjectName()] = &pendingZoneCleanup{activezone: e.activezone, zones: zoneids.Copy()}
+	}
+}
+
+// Remove forgets an entry removed from the state.
+func (this *pendingZoneCleanups) Remove(name resources.ObjectName) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.entries, name)
+}
+
+func (this *pendingZoneCleanups) IsPending(name resources.ObjectName) bool {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	c := this.entries[name]
+	return c != nil && len(c.zones) > 0
+}
+
+// ForZone returns the deleted entries with a pending cleanup of the given zone.
+func (this *pendingZoneCleanups) ForZone(zoneid string) []resources.ObjectName {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	var names []resources.ObjectName
+	for name, c := range this.entries {
+		if c.zones.Contains(zoneid) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Done marks the cleanup of a zone as done for the given entries. It returns the
+// active zones of the entries without pending cleanups anymore.
+func (this *pendingZoneCleanups) Done(zoneid string, names []resources.ObjectName) utils.StringSet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	activezones := utils.StringSet{}
+	for _, name := range names {
+		if c := this.entries[name]; c != nil && c.zones.Contains(zoneid) {
+			c.zones.Remove(zoneid)
+			if len(c.zones) == 0 {
+				activezones.Add(c.activezone)
+			}
+		}
+	}
+	return activezones
+}
+
+// addZoneCleanups registers the additional zones of a deleted entry to be cleaned
+// up before the finalizer of the entry is removed.
+func (this *state) addZoneCleanups(logger logger.LogContext, e *Entry) {
+	zoneids := utils.StringSet{}
+	for _, z := range e.additional {
+		if z.IsLocal() && this.zones[z.zoneid] != nil {
+			zoneids.Add(z.zoneid)
+		}
+	}
+	this.zoneCleanups.Add(e, zoneids)
+	for zoneid := range zoneids {
+		logger.Infof("deleted entry %q must be removed from additional zone %s -> trigger zone", e.ObjectName(), zoneid)
+		this.triggerHostedZone(zoneid)
+	}
+}
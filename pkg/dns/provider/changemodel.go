@@ -72,6 +72,10 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 	for _, s := range this.dnssets {
 		_, ok := model.applied[s.Name]
 		if !ok {
+			if s.IsShared() && model.IsStale(ZonedDNSName{ZoneID: model.ZoneId(), DNSName: s.Name}) == nil {
+				mod = this.cleanupShared(model, s) || mod
+				continue
+			}
 			if s.IsOwnedBy(model.ownership) {
 				if e := model.IsStale(ZonedDNSName{ZoneID: model.ZoneId(), DNSName: s.Name}); e != nil {
 					if e.IsDeleting() {
@@ -96,7 +100,7 @@ func (this *ChangeGroup) cleanup(logger logger.LogContext, model *ChangeModel) b
 					var done DoneHandler
 					for _, e := range model.context.entries {
 						if e.dnsname == s.Name {
-							done = NewStatusUpdate(logger, e, model.context.fhandler)
+							done = NewStatusUpdate(logger, e, model.context.zone, model.context.fhandler)
 							break
 						}
 					}
@@ -158,6 +162,10 @@ type ChangeModel struct {
 	providergroups map[string]*ChangeGroup
 	zonestate      DNSZoneState
 	failedDNSNames utils.StringSet
+	succeeded      utils.StringSet
+	adoption       AdoptionProgress
+	adopting       map[string]string
+	conflicts      RecordConflicts
 }
 
 type ChangeResult struct {
@@ -175,6 +183,10 @@ func NewChangeModel(logger logger.LogContext, ownership dns.Ownership, req *zone
 		applied:        map[string]*dns.DNSSet{},
 		providergroups: map[string]*ChangeGroup{},
 		failedDNSNames: utils.StringSet{},
+		succeeded:      utils.StringSet{},
+		adoption:       AdoptionProgress{},
+		adopting:       map[string]string{},
+		conflicts:      RecordConflicts{},
 	}
 }
 
@@ -238,6 +250,11 @@ func (this *ChangeModel) Setup() error {
 			view = this.dangling
 		}
 		view.dnssets[dnsName] = set
+		if this.IsForeign(set) {
+			for id := range this.getAdoptersFor(set.GetOwner()) {
+				this.adoption.Get(id).Pending++
+			}
+		}
 		for t, r := range set.Sets {
 			this.dumpf("    %s: %d records: %s", t, len(r.Records), r.RecordString())
 		}
@@ -261,7 +278,7 @@ func (this *ChangeModel) PseudoApply(name string) {
 
 func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string, createdAt time.Time, done DoneHandler, spec TargetSpec) ChangeResult {
 	//this.Infof("%s: %v", name, targets)
-	if len(spec.Targets()) == 0 && !delete {
+	if _, shared := spec.(*sharedTargetSpec); len(spec.Targets()) == 0 && !delete && !shared {
 		return ChangeResult{}
 	}
 
@@ -284,6 +301,9 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 
 	view := this.getProviderView(p)
 	oldset := view.dnssets[name]
+	if shared, ok := spec.(*sharedTargetSpec); ok {
+		return this.execShared(apply, view, oldset, name, updateGroup, createdAt, done, shared)
+	}
 	newset := dns.NewDNSSet(name)
 	newset.UpdateGroup = updateGroup
 	newset.SetKind(spec.Kind())
@@ -293,19 +313,10 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 	mod := false
 	if oldset != nil {
 		this.Debugf("found old for %s %q", oldset.GetKind(), oldset.Name)
-		if this.IsForeign(oldset) {
-			err := &perrs.AlreadyBusyForOwner{DNSName: name, EntryCreatedAt: createdAt, Owner: oldset.GetOwner()}
-			retry := p.ReportZoneStateConflict(this.context.zone.getZone(), err)
-			if done != nil {
-				if apply && !retry {
-					done.SetInvalid(err)
-				}
-			} else {
-				this.Warnf("no done handler and %s", err)
-			}
-			return ChangeResult{Error: err, Retry: retry}
+		if this.isForeignFor(oldset, spec) {
+			return this.foreignOwnerConflict(apply, delete, p, oldset, createdAt, done, spec)
 		} else {
-			if !spec.Responsible(oldset, this.ownership) {
+			if !spec.Responsible(oldset, this.ownershipFor(spec)) {
 				return ChangeResult{}
 			}
 			if oldset.GetOwner() == "" && !this.Owns(oldset) {
@@ -314,44 +325,14 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 				}
 				this.Infof("catch entry %q by reassigning owner", name)
 			}
-			for ty, rset := range newset.Sets {
-				curset := oldset.Sets[ty]
-				if curset == nil {
-					if apply {
-						view.addCreateRequest(newset, ty, done)
-					}
-					mod = true
-				} else {
-					olddns, _ := dns.MapToProvider(ty, oldset, this.Domain())
-					newdns, _ := dns.MapToProvider(ty, newset, this.Domain())
-					if olddns == newdns {
-						if !curset.Match(rset) {
-							if apply {
-								view.addUpdateRequest(oldset, newset, ty, done)
-							}
-							mod = true
-						} else {
-							if apply {
-								this.Debugf("records type %s up to date for %s", ty, name)
-							}
-						}
-					} else {
-						if apply {
-							view.addCreateRequest(newset, ty, done)
-							view.addDeleteRequest(oldset, ty, this.wrappedDoneHandler(name, nil))
-						}
-						mod = true
-					}
-				}
-			}
-			for ty := range oldset.Sets {
-				if _, ok := newset.Sets[ty]; !ok {
-					if apply {
-						view.addDeleteRequest(oldset, ty, done)
-					}
-					mod = true
+			if this.IsForeign(oldset) && !delete {
+				this.Infof("adopt entry %q from owner %q", name, oldset.GetOwner())
+				if apply {
+					// counted as adopted after the changes have been executed successfully
+					this.adopting[name] = this.getOwnerId(spec)
 				}
 			}
+			mod = this.addChanges(view, name, oldset, newset, apply, done)
 		}
 	} else {
 		if !delete {
@@ -374,6 +355,76 @@ func (this *ChangeModel) Exec(apply bool, delete bool, name, updateGroup string,
 	return ChangeResult{Modified: mod}
 }
 
+// foreignOwnerConflict reports a dns set blocked by a foreign owner.
+func (this *ChangeModel) foreignOwnerConflict(apply bool, delete bool, p DNSProvider, oldset *dns.DNSSet, createdAt time.Time, done DoneHandler, spec TargetSpec) ChangeResult {
+	name := oldset.Name
+	if adopters := this.getAdoptersFor(oldset.GetOwner()); len(adopters) > 0 && apply && !delete {
+		this.Warnf("entry %q with owner %q cannot be adopted by owner %q", name, oldset.GetOwner(), this.getOwnerId(spec))
+		for id := range adopters {
+			p := this.adoption.Get(id)
+			p.Conflicts = append(p.Conflicts, fmt.Sprintf("%s: claimed by owner %q", name, this.getOwnerId(spec)))
+		}
+	}
+	if apply && !delete {
+		this.conflicts.Add(name, oldset.GetOwner())
+	}
+	err := &perrs.AlreadyBusyForOwner{DNSName: name, EntryCreatedAt: createdAt, Owner: oldset.GetOwner()}
+	retry := p.ReportZoneStateConflict(this.context.zone.getZone(), err)
+	if done != nil {
+		if apply && !retry {
+			done.SetInvalid(err)
+		}
+	} else {
+		this.Warnf("no done handler and %s", err)
+	}
+	return ChangeResult{Error: err, Retry: retry}
+}
+
+// addChanges adds the requests required to update the record sets of an old dns set
+// to a new one. It reports whether there are any changes.
+func (this *ChangeModel) addChanges(view *ChangeGroup, name string, oldset, newset *dns.DNSSet, apply bool, done DoneHandler) bool {
+	mod := false
+	for ty, rset := range newset.Sets {
+		curset := oldset.Sets[ty]
+		if curset == nil {
+			if apply {
+				view.addCreateRequest(newset, ty, done)
+			}
+			mod = true
+		} else {
+			olddns, _ := dns.MapToProvider(ty, oldset, this.Domain())
+			newdns, _ := dns.MapToProvider(ty, newset, this.Domain())
+			if olddns == newdns {
+				if !curset.Match(rset) {
+					if apply {
+						view.addUpdateRequest(oldset, newset, ty, done)
+					}
+					mod = true
+				} else {
+					if apply {
+						this.Debugf("records type %s up to date for %s", ty, name)
+					}
+				}
+			} else {
+				if apply {
+					view.addCreateRequest(newset, ty, done)
+					view.addDeleteRequest(oldset, ty, this.wrappedDoneHandler(name, nil))
+				}
+				mod = true
+			}
+		}
+	}
+	for ty := range oldset.Sets {
+		if _, ok := newset.Sets[ty]; !ok {
+			if apply {
+				view.addDeleteRequest(oldset, ty, done)
+			}
+			mod = true
+		}
+	}
+	return mod
+}
+
 func (this *ChangeModel) Cleanup(logger logger.LogContext) bool {
 	mod := false
 	for _, view := range this.providergroups {
@@ -398,6 +449,43 @@ func (this *ChangeModel) Update(logger logger.LogContext) error {
 	return nil
 }
 
+// AdoptionProgress returns the progress of owner id adoptions found in the zone.
+// Only DNS names whose changes have been executed successfully are counted as adopted.
+func (this *ChangeModel) AdoptionProgress() AdoptionProgress {
+	for name, id := range this.adopting {
+		if this.succeeded.Contains(name) && !this.failedDNSNames.Contains(name) {
+			p := this.adoption.Get(id)
+			p.Pending--
+			if p.Adopted == nil {
+				p.Adopted = utils.StringSet{}
+			}
+			p.Adopted.Add(name)
+		}
+	}
+	this.adopting = map[string]string{}
+	return this.adoption
+}
+
+// Conflicts returns the DNS names blocked by records of foreign owners
+// together with the entries claiming them.
+func (this *ChangeModel) Conflicts() RecordConflicts {
+	for _, e := range this.context.entries {
+		if c := this.conflicts[e.dnsname]; c != nil {
+			c.Entries.Add(e.ObjectName().String())
+		}
+	}
+	return this.conflicts
+}
+
+// RecordSetCount returns the number of record sets found in the zone state.
+func (this *ChangeModel) RecordSetCount() int {
+	count := 0
+	for _, set := range this.zonestate.GetDNSSets() {
+		count += len(set.Sets)
+	}
+	return count
+}
+
 func (this *ChangeModel) IsFailed(dnsName string) bool {
 	return this.failedDNSNames.Contains(dnsName)
 }
@@ -433,6 +521,7 @@ func (this *changeModelDoneHandler) Failed(err error) {
 }
 
 func (this *changeModelDoneHandler) Succeeded() {
+	this.changeModel.succeeded.Add(this.dnsName)
 	if this.inner != nil {
 		this.inner.Succeeded()
 	}
@@ -455,6 +544,34 @@ func (this *ChangeModel) IsForeign(set *dns.DNSSet) bool {
 	return set.IsForeign(this.ownership)
 }
 
+func (this *ChangeModel) getOwnerId(spec TargetSpec) string {
+	if id := spec.OwnerId(); id != "" {
+		return id
+	}
+	return this.config.Ident
+}
+
+func (this *ChangeModel) getAdoptersFor(id string) utils.StringSet {
+	if a, ok := this.ownership.(AdoptionOwnership); ok && id != "" {
+		return a.GetAdoptersFor(id)
+	}
+	return nil
+}
+
+// ownershipFor returns the ownership used for a dedicated target spec.
+// Additionally to the active owner ids it covers the owner ids adopted
+// by the owner id of the spec.
+func (this *ChangeModel) ownershipFor(spec TargetSpec) dns.Ownership {
+	if _, ok := this.ownership.(AdoptionOwnership); !ok {
+		return this.ownership
+	}
+	return &adoptingOwnership{model: this, Ownership: this.ownership, id: this.getOwnerId(spec)}
+}
+
+func (this *ChangeModel) isForeignFor(set *dns.DNSSet, spec TargetSpec) bool {
+	return set.IsForeign(this.ownershipFor(spec))
+}
+
 func (this *ChangeModel) setOwner(set *dns.DNSSet, id string) bool {
 	if id == "" {
 		id = this.config.Ident
@@ -468,7 +585,7 @@ func (this *ChangeModel) setOwner(set *dns.DNSSet, id string) bool {
 
 func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider DNSProvider, spec TargetSpec) *dns.DNSSet {
 	set.SetKind(spec.Kind())
-	if base == nil || !this.IsForeign(base) {
+	if base == nil || !this.isForeignFor(base, spec) {
 		if this.setOwner(set, spec.OwnerId()) {
 			set.SetMetaAttr(dns.ATTR_PREFIX, dns.TxtPrefix)
 		}
@@ -481,19 +598,23 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 		ttl := t.GetTTL()
 		if t.GetRecordType() == dns.RS_CNAME && len(spec.Targets()) > 1 {
 			cnames = append(cnames, t.GetHostName())
-			ipv4addrs, ipv6addrs, err := lookupHosts(t.GetHostName())
-			if err == nil {
-				for _, addr := range ipv4addrs {
-					AddRecord(targetsets, dns.RS_A, addr, ttl)
-				}
-				for _, addr := range ipv6addrs {
-					AddRecord(targetsets, dns.RS_AAAA, addr, ttl)
-				}
-			} else {
-				this.Errorf("cannot lookup '%s': %s", t.GetHostName(), err)
+			addrs, ok := this.lookupHost(t.GetHostName())
+			if !ok {
+				this.Warnf("'%s' not resolved yet", t.GetHostName())
+				continue
+			}
+			if addrs.Err != nil {
+				this.Errorf("cannot lookup '%s': %s", t.GetHostName(), addrs.Err)
+				continue
+			}
+			for _, addr := range addrs.IPv4 {
+				AddRecord(targetsets, dns.RS_A, addr, ttl)
+			}
+			for _, addr := range addrs.IPv6 {
+				AddRecord(targetsets, dns.RS_AAAA, addr, ttl)
 			}
 			this.Debugf("mapping target '%s' to A records: %s or AAAA records: %s",
-				t.GetHostName(), strings.Join(ipv4addrs, ","), strings.Join(ipv6addrs, ","))
+				t.GetHostName(), strings.Join(addrs.IPv4, ","), strings.Join(addrs.IPv6, ","))
 		} else {
 			t = provider.MapTarget(t)
 			AddRecord(targetsets, t.GetRecordType(), t.GetHostName(), ttl)
@@ -507,6 +628,27 @@ func (this *ChangeModel) ApplySpec(set *dns.DNSSet, base *dns.DNSSet, provider D
 	return set
 }
 
+// lookupHost returns the addresses of a CNAME target cached by the CNAME resolver.
+// The hosts are registered and resolved in the background during the entry reconciliation.
+func (this *ChangeModel) lookupHost(hostname string) (HostAddresses, bool) {
+	if this.context == nil || this.context.resolver == nil {
+		return HostAddresses{}, false
+	}
+	return this.context.resolver.Lookup(hostname)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+type adoptingOwnership struct {
+	dns.Ownership
+	model *ChangeModel
+	id    string
+}
+
+func (this *adoptingOwnership) IsResponsibleFor(id string) bool {
+	return this.Ownership.IsResponsibleFor(id) || this.model.getAdoptersFor(id).Contains(this.id)
+}
+
 func AddRecord(targetsets dns.RecordSets, ty string, host string, ttl int64) {
 	rs := targetsets[ty]
 	if rs == nil {
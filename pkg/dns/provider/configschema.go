@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// CredentialKey describes a key of the credentials secret of a provider.
+// The first key is the preferred one, the others are accepted alternatives.
+type CredentialKey struct {
+	Keys     []string
+	Required bool
+}
+
+// RequiredKey describes a required key of the credentials secret with optional alternative keys.
+func RequiredKey(key string, altKeys ...string) CredentialKey {
+	return CredentialKey{Keys: append([]string{key}, altKeys...), Required: true}
+}
+
+// OptionalKey describes an optional key of the credentials secret with optional alternative keys.
+func OptionalKey(key string, altKeys ...string) CredentialKey {
+	return CredentialKey{Keys: append([]string{key}, altKeys...)}
+}
+
+// ProviderConfigValidationFunction performs additional checks of a decoded provider config
+// (a pointer of the type of the config prototype or nil) and the credentials.
+type ProviderConfigValidationFunction func(config interface{}, properties utils.Properties) error
+
+// ProviderConfigSchema describes the provider config and the credentials supported by a provider type.
+type ProviderConfigSchema struct {
+	// Config is a prototype of the provider config. Fields not defined by the prototype are rejected.
+	// If it is nil, no provider config is allowed.
+	Config interface{}
+	// CredentialKeys are the keys of the credentials secret. If empty, the secret keys are not checked.
+	CredentialKeys []CredentialKey
+	// Validate is an optional function for checks not covered by the schema,
+	// e.g. keys only required for a dedicated kind of authentication.
+	Validate ProviderConfigValidationFunction
+}
+
+// ValidateProviderConfig checks a provider config and the credentials against the schema.
+// Unknown fields of the provider config and missing credential keys are reported as error,
+// unknown credential keys are returned as warnings. If properties is nil, only the provider config is checked.
+func (this *ProviderConfigSchema) ValidateProviderConfig(config *runtime.RawExtension, properties utils.Properties) ([]string, error) {
+	if this == nil {
+		return nil, nil
+	}
+	var errs []string
+	var warnings []string
+
+	var cfg interface{}
+	if config != nil && len(bytes.TrimSpace(config.Raw)) > 0 && string(bytes.TrimSpace(config.Raw)) != "null" {
+		if this.Config == nil {
+			errs = append(errs, "providerConfig not supported")
+		} else {
+			t := reflect.TypeOf(this.Config)
+			if t.Kind() == reflect.Ptr {
+				t = t.Elem()
+			}
+			cfg = reflect.New(t).Interface()
+			dec := json.NewDecoder(bytes.NewReader(config.Raw))
+			dec.DisallowUnknownFields()
+			if err := dec.Decode(cfg); err != nil {
+				errs = append(errs, fmt.Sprintf("invalid providerConfig: %s", strings.TrimPrefix(err.Error(), "json: ")))
+				cfg = nil
+			}
+		}
+	}
+
+	if properties == nil {
+		if len(errs) > 0 {
+			return nil, fmt.Errorf("%s", strings.Join(errs, ", "))
+		}
+		return nil, nil
+	}
+
+	if len(this.CredentialKeys) > 0 {
+		known := utils.StringSet{}
+		for _, k := range this.CredentialKeys {
+			known.Add(k.Keys...)
+			if k.Required && !hasAnyKey(properties, k.Keys) {
+				errs = append(errs, fmt.Sprintf("'%s' required in secret", strings.Join(k.Keys, "' or '")))
+			}
+		}
+		var unknown []string
+		for k := range properties {
+			if !known.Contains(k) {
+				unknown = append(unknown, k)
+			}
+		}
+		if len(unknown) > 0 {
+			sort.Strings(unknown)
+			warnings = append(warnings, fmt.Sprintf("unknown keys in secret: %s", strings.Join(unknown, ", ")))
+		}
+	}
+
+	if len(errs) == 0 && this.Validate != nil {
+		if err := this.Validate(cfg, properties); err != nil {
+			errs = append(errs, err.Error())
+		}
+	}
+	if len(errs) > 0 {
+		return warnings, fmt.Errorf("%s", strings.Join(errs, ", "))
+	}
+	return warnings, nil
+}
+
+func hasAnyKey(properties utils.Properties, keys []string) bool {
+	for _, k := range keys {
+		if _, ok := properties[k]; ok {
+			return true
+		}
+	}
+	return false
+}
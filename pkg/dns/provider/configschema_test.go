@@ -0,0 +1,68 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type testProviderConfig struct {
+	Host *string `json:"host,omitempty"`
+	Port int     `json:"port,omitempty"`
+}
+
+var _ = ginkgo.Describe("Provider config schema", func() {
+	schema := &ProviderConfigSchema{
+		Config: &testProviderConfig{},
+		CredentialKeys: []CredentialKey{
+			RequiredKey("USERNAME", "username"),
+			OptionalKey("REGION"),
+		},
+	}
+	raw := func(s string) *runtime.RawExtension {
+		return &runtime.RawExtension{Raw: []byte(s)}
+	}
+
+	ginkgo.It("accepts a valid config", func() {
+		warnings, err := schema.ValidateProviderConfig(raw(`{"host":"a","port":1}`), utils.Properties{"username": "u"})
+		Expect(err).To(BeNil())
+		Expect(warnings).To(BeEmpty())
+	})
+
+	ginkgo.It("reports unknown config fields and missing keys", func() {
+		_, err := schema.ValidateProviderConfig(raw(`{"hots":"a"}`), utils.Properties{"REGION": "r"})
+		Expect(err).NotTo(BeNil())
+		Expect(err.Error()).To(ContainSubstring(`unknown field "hots"`))
+		Expect(err.Error()).To(ContainSubstring("'USERNAME' or 'username' required in secret"))
+	})
+
+	ginkgo.It("warns about unknown keys", func() {
+		warnings, err := schema.ValidateProviderConfig(nil, utils.Properties{"USERNAME": "u", "PASSWORD": "p"})
+		Expect(err).To(BeNil())
+		Expect(warnings).To(ConsistOf("unknown keys in secret: PASSWORD"))
+	})
+
+	ginkgo.It("rejects a config if not supported", func() {
+		_, err := (&ProviderConfigSchema{}).ValidateProviderConfig(raw(`{"host":"a"}`), utils.Properties{})
+		Expect(err).NotTo(BeNil())
+		_, err = (&ProviderConfigSchema{}).ValidateProviderConfig(raw(`null`), utils.Properties{})
+		Expect(err).To(BeNil())
+	})
+})
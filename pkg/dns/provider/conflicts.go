@@ -0,0 +1,367 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// conflict reports for DNS names blocked by foreign records
+////////////////////////////////////////////////////////////////////////////////
+
+// RecordConflict describes a DNS name blocked by a record of a foreign owner.
+type RecordConflict struct {
+	DNSName string
+	OwnerId string
+	Entries utils.StringSet
+}
+
+// RecordConflicts maps DNS names to conflicts found in a hosted zone.
+type RecordConflicts map[string]*RecordConflict
+
+func (this RecordConflicts) Add(dnsname, owner string) *RecordConflict {
+	c := this[dnsname]
+	if c == nil || c.OwnerId != owner {
+		c = &RecordConflict{DNSName: dnsname, OwnerId: owner, Entries: utils.StringSet{}}
+		this[dnsname] = c
+	}
+	return c
+}
+
+type zoneConflicts struct {
+	zoneid    string
+	ptype     string
+	domain    string
+	conflicts map[string]*api.DNSRecordConflict
+}
+
+// ZoneConflictReports maps the object names of conflict reports to the report to store.
+// A nil report indicates that there is no conflict for a zone.
+type ZoneConflictReports map[string]*api.DNSZoneConflictReportData
+
+type conflictCache struct {
+	lock      sync.Mutex
+	zones     map[string]*zoneConflicts
+	names     map[string]string
+	changed   utils.StringSet
+	reported  map[string]*api.DNSZoneConflictReportData
+	persisted map[string]*zoneConflicts
+}
+
+func newConflictCache() *conflictCache {
+	return &conflictCache{
+		zones:     map[string]*zoneConflicts{},
+		names:     map[string]string{},
+		changed:   utils.StringSet{},
+		reported:  map[string]*api.DNSZoneConflictReportData{},
+		persisted: map[string]*zoneConflicts{},
+	}
+}
+
+// Report stores the conflicts found during the reconciliation of a hosted zone.
+// The detection time of already known conflicts is preserved, initially from the
+// persisted conflict report.
+func (this *conflictCache) Report(zone *dnsHostedZone, conflicts RecordConflicts, ownership *OwnerCache) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	old := this.zones[zone.Id()]
+	if old == nil {
+		old = this.persisted[zone.Id()]
+	}
+	delete(this.persisted, zone.Id())
+	zc := &zoneConflicts{
+		zoneid:    zone.Id(),
+		ptype:     zone.ProviderType(),
+		domain:    zone.Domain(),
+		conflicts: map[string]*api.DNSRecordConflict{},
+	}
+	now := metav1.Now()
+	for name, c := range conflicts {
+		since := now
+		if old != nil {
+			if o := old.conflicts[name]; o != nil && o.OwnerId == c.OwnerId {
+				since = o.Since
+			}
+		}
+		entries := c.Entries.AsArray()
+		sort.Strings(entries)
+		zc.conflicts[name] = &api.DNSRecordConflict{
+			DNSName:        name,
+			OwnerId:        c.OwnerId,
+			InactiveOwners: ownership.GetInactiveOwners(c.OwnerId),
+			Entries:        entries,
+			Since:          since,
+		}
+	}
+	this.zones[zone.Id()] = zc
+	this.names[zone.Id()] = ZoneConflictReportName(zc.ptype, zc.zoneid)
+	this.changed.Add(zone.Id())
+	metrics.ReportZoneConflicts(zc.ptype, zc.zoneid, zc.ownerCounts())
+}
+
+// Delete removes the conflicts of a deleted hosted zone.
+func (this *conflictCache) Delete(zoneid string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if zc := this.zones[zoneid]; zc != nil {
+		metrics.DeleteZoneConflicts(zc.ptype, zoneid)
+		delete(this.zones, zoneid)
+		this.changed.Add(zoneid)
+	}
+}
+
+// GetChangedReports returns the reports changed since the last call.
+func (this *conflictCache) GetChangedReports() ZoneConflictReports {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	reports := ZoneConflictReports{}
+	for zoneid := range this.changed {
+		var report *api.DNSZoneConflictReportData
+		if zc := this.zones[zoneid]; zc != nil && len(zc.conflicts) > 0 {
+			report = zc.report()
+		}
+		old, ok := this.reported[zoneid]
+		if ok && reflect.DeepEqual(old, report) {
+			continue
+		}
+		reports[this.names[zoneid]] = report
+		if this.zones[zoneid] != nil {
+			this.reported[zoneid] = report
+		} else {
+			delete(this.reported, zoneid)
+			delete(this.names, zoneid)
+		}
+	}
+	this.changed = utils.StringSet{}
+	return reports
+}
+
+// Restore remembers the conflicts of persisted reports, so that their detection
+// time is kept after a restart.
+func (this *conflictCache) Restore(objects []*api.DNSZoneConflictReport) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for _, obj := range objects {
+		if _, ok := this.zones[obj.Report.ZoneID]; ok {
+			continue
+		}
+		zc := &zoneConflicts{conflicts: map[string]*api.DNSRecordConflict{}}
+		for i := range obj.Report.Conflicts {
+			c := obj.Report.Conflicts[i]
+			zc.conflicts[c.DNSName] = &c
+		}
+		this.persisted[obj.Report.ZoneID] = zc
+	}
+}
+
+// GetStaleReports returns deletion reports for the conflict reports of unknown
+// hosted zones. Only reports of the given provider types are considered, as the
+// reports of other types may be maintained by other controllers.
+func (this *conflictCache) GetStaleReports(objects []*api.DNSZoneConflictReport, known utils.StringSet, ptypes utils.StringSet) ZoneConflictReports {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	reports := ZoneConflictReports{}
+	for _, obj := range objects {
+		if !ptypes.Contains(obj.Report.ProviderType) || known.Contains(obj.Name) {
+			continue
+		}
+		if _, ok := this.zones[obj.Report.ZoneID]; ok {
+			continue
+		}
+		delete(this.persisted, obj.Report.ZoneID)
+		reports[obj.Name] = nil
+	}
+	return reports
+}
+
+func (this *zoneConflicts) report() *api.DNSZoneConflictReportData {
+	report := &api.DNSZoneConflictReportData{
+		ZoneID:       this.zoneid,
+		ProviderType: this.ptype,
+		DomainName:   this.domain,
+		Count:        len(this.conflicts),
+	}
+	for _, c := range this.conflicts {
+		report.Conflicts = append(report.Conflicts, *c)
+	}
+	sort.Slice(report.Conflicts, func(i, j int) bool {
+		return report.Conflicts[i].DNSName < report.Conflicts[j].DNSName
+	})
+	return report
+}
+
+func (this *zoneConflicts) ownerCounts() map[string]int {
+	counts := map[string]int{}
+	for _, c := range this.conflicts {
+		counts[c.OwnerId]++
+	}
+	return counts
+}
+
+var invalidNameChars = regexp.MustCompile("[^a-z0-9.-]+")
+
+// ZoneConflictReportName returns the object name of the conflict report for a hosted zone.
+func ZoneConflictReportName(ptype, zoneid string) string {
+	return zoneObjectName(ptype, zoneid)
+}
+
+// zoneObjectName returns a valid object name for objects describing a hosted zone.
+// A short hash of the zone id keeps the names of zone ids unique, which differ only
+// in case or in characters replaced for the object name.
+func zoneObjectName(ptype, zoneid string) string {
+	name := invalidNameChars.ReplaceAllString(strings.ToLower(fmt.Sprintf("%s-%s", ptype, zoneid)), "-")
+	sum := sha256.Sum256([]byte(zoneid))
+	return fmt.Sprintf("%s-%x", strings.Trim(name, "-."), sum[:4])
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) UpdateConflictReports(log logger.LogContext) {
+	if !this.initialized {
+		return
+	}
+	if reports := this.conflicts.GetChangedReports(); len(reports) > 0 {
+		log.Infof("found %d changed conflict reports", len(reports))
+		this.conflictupd <- reports
+	}
+}
+
+// cleanupConflictReports deletes the conflict reports of hosted zones not known
+// after the initial setup of the providers, for example of zones deleted while the
+// controller was not running. The conflicts of the other reports are restored.
+func (this *state) cleanupConflictReports() {
+	list, err := this.conflictresc.List(metav1.ListOptions{})
+	if err != nil {
+		this.context.Warnf("cannot list conflict reports: %s", err)
+		return
+	}
+	var objects []*api.DNSZoneConflictReport
+	for _, obj := range list {
+		if report, ok := obj.Data().(*api.DNSZoneConflictReport); ok {
+			objects = append(objects, report)
+		}
+	}
+	known := utils.StringSet{}
+	this.lock.RLock()
+	for _, zone := range this.zones {
+		known.Add(ZoneConflictReportName(zone.ProviderType(), zone.Id()))
+	}
+	this.lock.RUnlock()
+	this.conflicts.Restore(objects)
+	if reports := this.conflicts.GetStaleReports(objects, known, this.config.Enabled); len(reports) > 0 {
+		this.context.Infof("found %d conflict reports of unknown zones", len(reports))
+		this.conflictupd <- reports
+	}
+}
+
+func startConflictReportUpdater(ctx Context, resc resources.Interface) chan ZoneConflictReports {
+	log := ctx.AddIndent("conflicts: ")
+
+	requests := make(chan ZoneConflictReports, 2)
+	go func() {
+		log.Infof("starting conflict report updater")
+		for {
+			select {
+			case <-ctx.GetContext().Done():
+				log.Infof("stopping conflict report updater")
+				return
+			case reports := <-requests:
+				for name, report := range reports {
+					updateConflictReport(log, resc, name, report)
+				}
+			}
+		}
+	}()
+	return requests
+}
+
+func updateConflictReport(log logger.LogContext, resc resources.Interface, name string, report *api.DNSZoneConflictReportData) {
+	if report == nil {
+		if err := resc.DeleteByName(resources.NewObjectName(name)); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Errorf("cannot delete conflict report %s: %s", name, err)
+			}
+		} else {
+			log.Infof("deleted conflict report %s", name)
+		}
+		return
+	}
+
+	log.Infof("updating conflict report %s (%d conflicts)", name, report.Count)
+	_, _, err := resc.CreateOrModifyByName(resources.NewObjectName(name), func(data resources.ObjectData) (bool, error) {
+		obj, ok := data.(*api.DNSZoneConflictReport)
+		if !ok {
+			return false, fmt.Errorf("invalid conflict report object type %T", data)
+		}
+		report = keepConflictsSince(report, &obj.Report)
+		if reflect.DeepEqual(sanitizeConflictReport(&obj.Report), sanitizeConflictReport(report)) {
+			return false, nil
+		}
+		obj.Report = *report
+		obj.Report.LastUpdateTime = &metav1.Time{Time: time.Now()}
+		return true, nil
+	})
+	if err != nil {
+		log.Errorf("cannot update conflict report %s: %s", name, err)
+	}
+}
+
+// keepConflictsSince preserves the detection time of conflicts already contained in the
+// persisted report, so that it survives restarts of the controller.
+func keepConflictsSince(report, persisted *api.DNSZoneConflictReportData) *api.DNSZoneConflictReportData {
+	since := map[string]metav1.Time{}
+	for _, c := range persisted.Conflicts {
+		since[c.DNSName+"|"+c.OwnerId] = c.Since
+	}
+	r := report.DeepCopy()
+	for i, c := range r.Conflicts {
+		if t, ok := since[c.DNSName+"|"+c.OwnerId]; ok && !t.IsZero() && t.Before(&c.Since) {
+			r.Conflicts[i].Since = t
+		}
+	}
+	return r
+}
+
+func sanitizeConflictReport(report *api.DNSZoneConflictReportData) *api.DNSZoneConflictReportData {
+	r := report.DeepCopy()
+	r.LastUpdateTime = nil
+	for i := range r.Conflicts {
+		r.Conflicts[i].Since = metav1.NewTime(r.Conflicts[i].Since.Truncate(time.Second))
+	}
+	return r
+}
@@ -0,0 +1,187 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type conflictTestHandler struct {
+	DNSHandler
+}
+
+func (this *conflictTestHandler) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
+	return false
+}
+
+var _ = ginkgo.Describe("Conflict reports", func() {
+	zone := newDNSHostedZone(time.Second, NewDNSHostedZone("aws-route53", "/hostedzone/Z1", "example.com", "", nil, false))
+	name := ZoneConflictReportName("aws-route53", "/hostedzone/Z1")
+
+	ginkgo.It("builds valid object names", func() {
+		Expect(name).To(MatchRegexp("^aws-route53--hostedzone-z1-[0-9a-f]{8}$"))
+		Expect(ZoneConflictReportName("test", "Zone_1.")).To(MatchRegexp("^test-zone-1-[0-9a-f]{8}$"))
+	})
+
+	ginkgo.It("builds different object names for zone ids differing only in replaced characters or case", func() {
+		names := utils.NewStringSet(
+			ZoneConflictReportName("test", "Zone_1"),
+			ZoneConflictReportName("test", "zone-1"),
+			ZoneConflictReportName("test", "ZONE-1"),
+		)
+		Expect(names).To(HaveLen(3))
+	})
+
+	ginkgo.It("detects DNS names blocked by foreign owners", func() {
+		provider := &dnsProviderVersion{account: &DNSAccount{handler: &conflictTestHandler{}}}
+		obj := &namedEntryObject{name: resources.NewObjectName("default", "a")}
+		entry := &Entry{EntryVersion: &EntryVersion{dnsname: "a.example.com", object: &dnsutils.DNSEntryObject{Object: obj}}}
+		model := &ChangeModel{
+			LogContext: logger.New(),
+			ownership:  &testOwnership{ids: utils.NewStringSet("mine")},
+			context:    &zoneReconciliation{zone: zone, entries: Entries{obj.name: entry}},
+			conflicts:  RecordConflicts{},
+		}
+		oldset := dns.NewDNSSet("a.example.com")
+		oldset.SetOwner("foreign")
+
+		model.foreignOwnerConflict(false, false, provider, oldset, time.Now(), nil, nil)
+		model.foreignOwnerConflict(true, true, provider, oldset, time.Now(), nil, nil)
+		Expect(model.Conflicts()).To(BeEmpty())
+
+		model.foreignOwnerConflict(true, false, provider, oldset, time.Now(), nil, nil)
+		conflicts := model.Conflicts()
+		Expect(conflicts).To(HaveLen(1))
+		Expect(conflicts["a.example.com"].OwnerId).To(Equal("foreign"))
+		Expect(conflicts["a.example.com"].Entries).To(Equal(utils.NewStringSet("default/a")))
+	})
+
+	ginkgo.It("reports changed conflicts per zone", func() {
+		owners := &TestOwnerCacheContext{ids: map[OwnerName]owner{}}
+		owners.OwnerCache = NewOwnerCache(owners, &Config{Ident: ident})
+		owners.updateOwnerData("o1", "foreign", false)
+
+		cache := newConflictCache()
+		conflicts := RecordConflicts{}
+		conflicts.Add("a.example.com", "foreign").Entries.Add("default/b", "default/a")
+		cache.Report(zone, conflicts, owners.OwnerCache)
+
+		reports := cache.GetChangedReports()
+		Expect(reports).To(HaveLen(1))
+		report := reports[name]
+		Expect(report.ZoneID).To(Equal("/hostedzone/Z1"))
+		Expect(report.DomainName).To(Equal("example.com"))
+		Expect(report.Count).To(Equal(1))
+		Expect(report.Conflicts[0].OwnerId).To(Equal("foreign"))
+		Expect(report.Conflicts[0].Entries).To(Equal([]string{"default/a", "default/b"}))
+		Expect(report.Conflicts[0].InactiveOwners).To(Equal([]string{"o1"}))
+		since := report.Conflicts[0].Since
+
+		cache.Report(zone, conflicts, owners.OwnerCache)
+		Expect(cache.GetChangedReports()).To(BeEmpty())
+
+		conflicts.Add("b.example.com", "other")
+		cache.Report(zone, conflicts, owners.OwnerCache)
+		report = cache.GetChangedReports()[name]
+		Expect(report.Count).To(Equal(2))
+		Expect(report.Conflicts[0].Since).To(Equal(since))
+
+		cache.Report(zone, RecordConflicts{}, owners.OwnerCache)
+		reports = cache.GetChangedReports()
+		Expect(reports).To(HaveKey(name))
+		Expect(reports[name]).To(BeNil())
+
+		cache.Delete(zone.Id())
+		Expect(cache.GetChangedReports()).To(BeEmpty())
+	})
+
+	ginkgo.It("keeps the detection time of persisted conflicts", func() {
+		owners := &TestOwnerCacheContext{ids: map[OwnerName]owner{}}
+		owners.OwnerCache = NewOwnerCache(owners, &Config{Ident: ident})
+		since := metav1.NewTime(time.Now().Add(-time.Hour).Truncate(time.Second))
+		persisted := &api.DNSZoneConflictReport{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Report: api.DNSZoneConflictReportData{
+				ZoneID:       zone.Id(),
+				ProviderType: zone.ProviderType(),
+				Conflicts: []api.DNSRecordConflict{
+					{DNSName: "a.example.com", OwnerId: "foreign", Since: since},
+					{DNSName: "b.example.com", OwnerId: "foreign", Since: since},
+				},
+			},
+		}
+
+		cache := newConflictCache()
+		cache.Restore([]*api.DNSZoneConflictReport{persisted})
+		conflicts := RecordConflicts{}
+		conflicts.Add("a.example.com", "foreign")
+		conflicts.Add("b.example.com", "other")
+		cache.Report(zone, conflicts, owners.OwnerCache)
+		report := cache.GetChangedReports()[name]
+		Expect(report.Conflicts[0].Since).To(Equal(since))
+		Expect(report.Conflicts[1].Since).NotTo(Equal(since))
+
+		// conflicts reported before the restore
+		report.Conflicts[0].Since = metav1.Now()
+		report = keepConflictsSince(report, &persisted.Report)
+		Expect(report.Conflicts[0].Since).To(Equal(since))
+		Expect(report.Conflicts[1].Since).NotTo(Equal(since))
+	})
+
+	ginkgo.It("reports conflict reports of unknown zones of enabled provider types as stale", func() {
+		object := func(ptype, zoneid string) *api.DNSZoneConflictReport {
+			return &api.DNSZoneConflictReport{
+				ObjectMeta: metav1.ObjectMeta{Name: ZoneConflictReportName(ptype, zoneid)},
+				Report:     api.DNSZoneConflictReportData{ProviderType: ptype, ZoneID: zoneid},
+			}
+		}
+		cache := newConflictCache()
+		cache.Report(newDNSHostedZone(time.Second, NewDNSHostedZone("test", "reconciled", "example.org", "", nil, false)), nil, nil)
+		objects := []*api.DNSZoneConflictReport{
+			object("test", "zone1"),
+			object("test", "gone"),
+			object("test", "reconciled"),
+			object("other", "foreign"),
+		}
+		reports := cache.GetStaleReports(objects, utils.NewStringSet(ZoneConflictReportName("test", "zone1")), utils.NewStringSet("test"))
+		Expect(reports).To(HaveLen(1))
+		Expect(reports).To(HaveKey(ZoneConflictReportName("test", "gone")))
+		Expect(reports[ZoneConflictReportName("test", "gone")]).To(BeNil())
+	})
+
+	ginkgo.It("replaces conflicts for a changed owner", func() {
+		conflicts := RecordConflicts{}
+		conflicts.Add("a.example.com", "o1").Entries.Add("default/a")
+		conflicts.Add("a.example.com", "o1").Entries.Add("default/b")
+		Expect(conflicts["a.example.com"].Entries).To(HaveLen(2))
+		conflicts.Add("a.example.com", "o2")
+		Expect(conflicts["a.example.com"].OwnerId).To(Equal("o2"))
+		Expect(conflicts["a.example.com"].Entries).To(BeEmpty())
+	})
+})
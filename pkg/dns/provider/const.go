@@ -37,11 +37,36 @@ const (
 	OPT_RESCHEDULEDELAY            = "reschedule-delay"
 	OPT_LOCKSTATUSCHECKPERIOD      = "lock-status-check-period"
 	OPT_DISABLE_ZONE_STATE_CACHING = "disable-zone-state-caching"
+	OPT_ACCOUNT_ZONE_CONCURRENCY   = "account-zone-concurrency"
+	OPT_ZONE_CONCURRENCY           = "zone-concurrency"
 
 	OPT_REMOTE_ACCESS_PORT               = "remote-access-port"
 	OPT_REMOTE_ACCESS_CACERT             = "remote-access-cacert"
 	OPT_REMOTE_ACCESS_SERVER_SECRET_NAME = "remote-access-server-secret-name"
 	OPT_REMOTE_ACCESS_CLIENT_ID          = "remote-access-client-id"
+	OPT_REMOTE_ACCESS_POLICY_SECRET_NAME = "remote-access-policy-secret-name"
+	OPT_REMOTE_ACCESS_AUDIT_LOG_FILE     = "remote-access-audit-log-file"
+	OPT_REMOTE_ACCESS_AUDIT_WEBHOOK_URL  = "remote-access-audit-webhook-url"
+	OPT_REMOTE_ACCESS_AUDIT_EVENTS       = "remote-access-audit-events"
+	OPT_REMOTE_ACCESS_REVOCATION_LIST    = "remote-access-revocation-list"
+	OPT_REMOTE_ACCESS_TOKEN_TTL          = "remote-access-token-ttl"
+	OPT_REMOTE_ACCESS_TOKEN_SECRET_NAME  = "remote-access-token-secret-name"
+	OPT_REMOTE_ACCESS_LIMITS_SECRET_NAME = "remote-access-limits-secret-name"
+
+	OPT_SHARDING              = "sharding"
+	OPT_SHARD_ID              = "shard-id"
+	OPT_SHARD_LEASE_PREFIX    = "shard-lease-prefix"
+	OPT_SHARD_LEASE_NAMESPACE = "shard-lease-namespace"
+	OPT_SHARD_LEASE_DURATION  = "shard-lease-duration"
+
+	OPT_RESOLVER_NAMESERVERS = "resolver-nameservers"
+	OPT_RESOLVER_WORKERS     = "resolver-workers"
+	OPT_RESOLVER_MAX_TARGETS = "resolver-max-cname-targets"
+	OPT_RESOLVER_MIN_REFRESH = "resolver-min-refresh"
+
+	OPT_PROPAGATION_CHECK    = "propagation-check"
+	OPT_PROPAGATION_TIMEOUT  = "propagation-timeout"
+	OPT_PROPAGATION_INTERVAL = "propagation-check-interval"
 
 	OPT_PROVIDERTYPES = "provider-types"
 
@@ -57,7 +82,8 @@ const (
 	CMD_STATISTIC         = "statistic"
 	CMD_DNSLOOKUP         = "dnslookup"
 
-	MSG_THROTTLING = "provider throttled"
+	MSG_THROTTLING  = "provider throttled"
+	MSG_PROPAGATING = "propagating"
 )
 
 const (
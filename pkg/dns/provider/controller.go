@@ -52,12 +52,20 @@ const FACTORY_OPTIONS = "factory"
 
 const DNS_POOL = "dns"
 
+// DNS_POOL_SIZE is the default number of workers of the dns pool.
+// The zone reconciliations are limited by the option zone-concurrency,
+// which should not exceed the pool size given by the option dns.pool.size.
+const DNS_POOL_SIZE = 5
+
 var ownerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSOwnerKind)
 var secretGroupKind = resources.NewGroupKind("", "Secret")
 var providerGroupKind = resources.NewGroupKind(api.GroupName, api.DNSProviderKind)
 var entryGroupKind = resources.NewGroupKind(api.GroupName, api.DNSEntryKind)
 var zonePolicyGroupKind = resources.NewGroupKind(api.GroupName, api.DNSHostedZonePolicyKind)
+var zoneRequestGroupKind = resources.NewGroupKind(api.GroupName, api.DNSHostedZoneRequestKind)
 var lockGroupKind = resources.NewGroupKind(api.GroupName, api.DNSLockKind)
+var conflictReportGroupKind = resources.NewGroupKind(api.GroupName, api.DNSZoneConflictReportKind)
+var hostedZoneGroupKind = resources.NewGroupKind(api.GroupName, api.DNSHostedZoneKind)
 
 // RemoteAccessClientID stores the optional client ID for remote access
 var RemoteAccessClientID string
@@ -127,12 +135,34 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		DefaultedIntOption(OPT_CACHE_TTL, 120, "Time-to-live for provider hosted zone cache").
 		DefaultedIntOption(OPT_SETUP, 10, "number of processors for controller setup").
 		DefaultedDurationOption(OPT_DNSDELAY, 10*time.Second, "delay between two dns reconciliations").
+		DefaultedIntOption(OPT_ACCOUNT_ZONE_CONCURRENCY, 1, "maximum number of zones reconciled concurrently per provider account (0 for unlimited)").
+		DefaultedIntOption(OPT_ZONE_CONCURRENCY, DNS_POOL_SIZE, "maximum number of zones reconciled concurrently (0 for size of dns pool)").
 		DefaultedDurationOption(OPT_RESCHEDULEDELAY, 120*time.Second, "reschedule delay after losing provider").
 		DefaultedDurationOption(OPT_LOCKSTATUSCHECKPERIOD, 120*time.Second, "interval for dns lock status checks").
 		DefaultedIntOption(OPT_REMOTE_ACCESS_PORT, 0, "port of remote access server for remote-enabled providers").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CACERT, "", "CA who signed client certs file").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_SERVER_SECRET_NAME, "", "name of secret containing remote access server's certificate").
 		DefaultedStringOption(OPT_REMOTE_ACCESS_CLIENT_ID, "", "identifier used for remote access").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_POLICY_SECRET_NAME, "", "name of secret containing the authorization policy for remote access clients (optional)").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_AUDIT_LOG_FILE, "", "file to append audit events of remote access changes as JSON lines").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_AUDIT_WEBHOOK_URL, "", "URL of webhook to post audit events of remote access changes").
+		DefaultedBoolOption(OPT_REMOTE_ACCESS_AUDIT_EVENTS, false, "report audit events of remote access changes as Kubernetes events on the DNSProvider").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_REVOCATION_LIST, "", "name of secret containing the list of revoked client certificates (optional)").
+		DefaultedDurationOption(OPT_REMOTE_ACCESS_TOKEN_TTL, 2*time.Hour, "validity duration of login tokens of remote access clients").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_TOKEN_SECRET_NAME, "", "name of secret containing the key for signing login tokens of remote access clients (optional)").
+		DefaultedStringOption(OPT_REMOTE_ACCESS_LIMITS_SECRET_NAME, "", "name of secret containing rate limits and daily quotas for remote access clients (optional)").
+		DefaultedBoolOption(OPT_SHARDING, false, "distribute hosted zones across all controller replicas (requires running without leader election)").
+		DefaultedStringOption(OPT_SHARD_ID, "", "identity of this replica in the shard group (default: POD_NAME or hostname)").
+		DefaultedStringOption(OPT_SHARD_LEASE_PREFIX, "dns-controller-shard", "name prefix of the leases of the shard group").
+		DefaultedStringOption(OPT_SHARD_LEASE_NAMESPACE, "", "namespace of the leases of the shard group (default: namespace of controller manager)").
+		DefaultedDurationOption(OPT_SHARD_LEASE_DURATION, 30*time.Second, "duration of a shard lease before its zones are taken over by other replicas").
+		DefaultedStringOption(OPT_RESOLVER_NAMESERVERS, "", "comma separated list of nameservers used to resolve CNAME targets of multi-target entries (default: from /etc/resolv.conf)").
+		DefaultedIntOption(OPT_RESOLVER_WORKERS, 5, "number of workers resolving CNAME targets of multi-target entries").
+		DefaultedIntOption(OPT_RESOLVER_MAX_TARGETS, 25, "maximum number of CNAME targets of a multi-target entry").
+		DefaultedDurationOption(OPT_RESOLVER_MIN_REFRESH, 30*time.Second, "minimum interval between two lookups of a CNAME target").
+		DefaultedBoolOption(OPT_PROPAGATION_CHECK, false, "keep changed entries pending until their records are served by all authoritative nameservers of the hosted zone").
+		DefaultedDurationOption(OPT_PROPAGATION_TIMEOUT, 5*time.Minute, "maximum duration to wait for the propagation of changed records").
+		DefaultedDurationOption(OPT_PROPAGATION_INTERVAL, 10*time.Second, "interval between two queries of the authoritative nameservers during propagation checks").
 		FinalizerDomain("dns.gardener.cloud").
 		Reconciler(DNSReconcilerType(factory)).
 		Cluster(TARGET_CLUSTER).
@@ -146,7 +176,7 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 			controller.NewResourceKey(api.GroupName, api.DNSLockKind),
 		).
 		Cluster(PROVIDER_CLUSTER).
-		CustomResourceDefinitions(providerGroupKind).
+		CustomResourceDefinitions(providerGroupKind, conflictReportGroupKind, hostedZoneGroupKind, zoneRequestGroupKind).
 		WorkerPool("providers", 2, 10*time.Minute).
 		Watches(
 			controller.NewResourceKey(api.GroupName, api.DNSProviderKind),
@@ -159,7 +189,11 @@ func DNSController(name string, factory DNSHandlerFactory) controller.Configurat
 		Watches(
 			controller.NewResourceKey(api.GroupName, api.DNSHostedZonePolicyKind),
 		).
-		WorkerPool(DNS_POOL, 1, 15*time.Minute).CommandMatchers(utils.NewStringGlobMatcher(CMD_HOSTEDZONE_PREFIX+"*")).
+		WorkerPool("zonerequests", 1, 15*time.Minute).
+		Watches(
+			controller.NewResourceKey(api.GroupName, api.DNSHostedZoneRequestKind),
+		).
+		WorkerPool(DNS_POOL, DNS_POOL_SIZE, 15*time.Minute).CommandMatchers(utils.NewStringGlobMatcher(CMD_HOSTEDZONE_PREFIX+"*")).
 		Commands(CMD_DNSLOOKUP).
 		WorkerPool("statistic", 2, 0).Commands(CMD_STATISTIC).
 		OptionSource(FACTORY_OPTIONS, FactoryOptionSourceCreator(factory))
@@ -206,6 +240,14 @@ func Create(c controller.Interface, factory DNSHandlerFactory) (reconcile.Interf
 	if err != nil {
 		return nil, err
 	}
+	conflictresc, err := c.GetCluster(PROVIDER_CLUSTER).Resources().GetByGK(conflictReportGroupKind)
+	if err != nil {
+		return nil, err
+	}
+	hostedzoneresc, err := c.GetCluster(PROVIDER_CLUSTER).Resources().GetByGK(hostedZoneGroupKind)
+	if err != nil {
+		return nil, err
+	}
 	secretresc, err := c.GetCluster(TARGET_CLUSTER).Resources().GetByGK(secretGroupKind)
 	if err != nil {
 		return nil, err
@@ -215,7 +257,7 @@ func Create(c controller.Interface, factory DNSHandlerFactory) (reconcile.Interf
 		controller: c,
 		state: c.GetOrCreateSharedValue(KEY_STATE,
 			func() interface{} {
-				return NewDNSState(NewDefaultContext(c), ownerresc, secretresc, classes, *config)
+				return NewDNSState(NewDefaultContext(c), ownerresc, conflictresc, hostedzoneresc, secretresc, classes, *config)
 			}).(*state),
 	}, nil
 }
@@ -238,6 +280,8 @@ func (this *reconciler) Command(logger logger.LogContext, cmd string) reconcile.
 		return reconcile.RescheduleAfter(logger, this.state.config.StatusCheckPeriod)
 	case CMD_STATISTIC:
 		this.state.UpdateOwnerCounts(logger)
+		this.state.UpdateConflictReports(logger)
+		this.state.UpdateHostedZones(logger)
 	default:
 		zoneid := this.state.DecodeZoneCommand(cmd)
 		if zoneid != "" {
@@ -274,6 +318,10 @@ func (this *reconciler) Reconcile(logger logger.LogContext, obj resources.Object
 		} else {
 			return this.state.RemoveZonePolicy(logger, dnsutils.DNSHostedZonePolicy(obj))
 		}
+	case obj.IsA(&api.DNSHostedZoneRequest{}):
+		if this.state.IsResponsibleFor(logger, obj) {
+			return this.state.UpdateZoneRequest(logger, dnsutils.DNSHostedZoneRequest(obj))
+		}
 	case obj.IsA(&api.DNSLock{}):
 		if this.state.IsResponsibleFor(logger, obj) {
 			return this.state.UpdateEntry(logger, dnsutils.DNSLock(obj))
@@ -298,6 +346,8 @@ func (this *reconciler) Delete(logger logger.LogContext, obj resources.Object) r
 		case obj.IsA(&api.DNSLock{}):
 			obj.UpdateFromCache()
 			return this.state.DeleteEntry(logger, dnsutils.DNSLock(obj))
+		case obj.IsA(&api.DNSHostedZoneRequest{}):
+			return this.state.DeleteZoneRequest(logger, dnsutils.DNSHostedZoneRequest(obj))
 		case obj.IsA(&corev1.Secret{}):
 			return this.state.UpdateSecret(logger, obj)
 		}
@@ -318,6 +368,8 @@ func (this *reconciler) Deleted(logger logger.LogContext, key resources.ClusterO
 		return this.state.ZonePolicyDeleted(logger, key)
 	case lockGroupKind:
 		return this.state.EntryDeleted(logger, key)
+	case secretGroupKind:
+		return this.state.SecretDeleted(logger, key.ObjectKey())
 	}
 	return reconcile.Succeeded(logger)
 }
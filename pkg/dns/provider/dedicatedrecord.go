@@ -31,6 +31,25 @@ type DedicatedDNSAccess interface {
 	DeleteRecordSet(logger logger.LogContext, zone DNSHostedZone, rs DedicatedRecordSet) error
 }
 
+// DedicatedRecordTypeSupport is an optional interface of a DedicatedDNSAccess
+// supporting record types besides TXT records.
+type DedicatedRecordTypeSupport interface {
+	SupportsRecordType(rtype string) bool
+}
+
+// SupportsDedicatedRecordType checks whether record sets of a record type can be
+// read and written with a dedicated DNS access. TXT records are always supported.
+func SupportsDedicatedRecordType(access DedicatedDNSAccess, rtype string) bool {
+	if access == nil {
+		return false
+	}
+	if rtype == dns.RS_TXT {
+		return true
+	}
+	s, ok := access.(DedicatedRecordTypeSupport)
+	return ok && s.SupportsRecordType(rtype)
+}
+
 type DedicatedRecord interface {
 	GetType() string
 	GetValue() string
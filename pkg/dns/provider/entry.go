@@ -38,6 +38,8 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 const MSG_PRESERVED = "errorneous entry preserved in provider"
@@ -49,12 +51,17 @@ type EntryPremise struct {
 	fallback DNSProvider // provider with correct zone, but outside selection (only set if provider == nil)
 	zoneid   string
 
+	additional additionalZones
+
 	// non-identifying fields
 	zonedomain string
+	private    bool   // zone is a private zone
+	selection  string // explanation of a restricted provider selection
 }
 
 func (this *EntryPremise) Match(p *EntryPremise) bool {
-	return this.ptype == p.ptype && this.provider == p.provider && this.zoneid == p.zoneid && this.fallback == p.fallback
+	return this.ptype == p.ptype && this.provider == p.provider && this.zoneid == p.zoneid && this.fallback == p.fallback &&
+		this.additional.Equals(p.additional)
 }
 
 func (this *EntryPremise) NotifyChange(p *EntryPremise) string {
@@ -71,6 +78,9 @@ func (this *EntryPremise) NotifyChange(p *EntryPremise) string {
 	if this.fallback != p.fallback {
 		r = append(r, fmt.Sprintf("fallback (%s -> %s)", Provider(this.fallback), Provider(p.fallback)))
 	}
+	if !this.additional.Equals(p.additional) {
+		r = append(r, fmt.Sprintf("additional zones (%s -> %s)", this.additional, p.additional))
+	}
 	if len(r) == 0 {
 		return ""
 	}
@@ -92,6 +102,14 @@ type EntryVersion struct {
 	valid       bool
 	duplicate   bool
 	obsolete    bool
+	shared      bool
+
+	ownershipConflict string
+	providerSelection string
+
+	private      bool    // assigned zone is a private zone
+	splitTargets Targets // targets for zones with opposite visibility of split-horizon entries
+	additional   additionalZones
 }
 
 func NewEntryVersion(object dnsutils.DNSSpecification, old *Entry) *EntryVersion {
@@ -106,6 +124,9 @@ func NewEntryVersion(object dnsutils.DNSSpecification, old *Entry) *EntryVersion
 	} else {
 		v.status = *object.BaseStatus()
 	}
+	if e, ok := object.(*dnsutils.DNSEntryObject); ok {
+		v.shared = e.IsShared()
+	}
 	return v
 }
 
@@ -129,9 +150,18 @@ func (this *EntryVersion) RequiresUpdateFor(e *EntryVersion) (reasons []string,
 	if this.OwnerId() != e.OwnerId() {
 		reasons = append(reasons, "ownerid changed")
 	}
+	if this.shared != e.shared {
+		reasons = append(reasons, "shared mode changed")
+	}
 	if this.targets.DifferFrom(e.targets) {
 		reasons = append(reasons, "targets changed")
 	}
+	if this.splitTargets.DifferFrom(e.splitTargets) {
+		reasons = append(reasons, "split-horizon targets changed")
+	}
+	if !this.additional.Equals(e.additional) {
+		reasons = append(reasons, "additional zones changed")
+	}
 	if this.State() != e.State() {
 		if e.State() != api.STATE_READY {
 			reasons = append(reasons, "state changed")
@@ -152,6 +182,12 @@ func (this *EntryVersion) IsValid() bool {
 	return this.valid
 }
 
+// IsShared reports whether the entry contributes to a record set shared
+// with other entries for the same dns name.
+func (this *EntryVersion) IsShared() bool {
+	return this.shared
+}
+
 func (this *EntryVersion) KeepRecords() bool {
 	return this.IsValid() || this.status.State != api.STATE_INVALID
 }
@@ -196,6 +232,14 @@ func (this *EntryVersion) Targets() Targets {
 	return this.targets
 }
 
+// targetsFor returns the targets of the entry for an additional zone.
+func (this *EntryVersion) targetsFor(zone *additionalZone) Targets {
+	if this.splitTargets != nil && zone.private != this.private {
+		return this.splitTargets
+	}
+	return this.targets
+}
+
 func (this *EntryVersion) Description() string {
 	return this.object.Description()
 }
@@ -340,8 +384,12 @@ func validate(logger logger.LogContext, state *state, entry *EntryVersion, p *En
 	targets = Targets{}
 	warnings = []string{}
 
-	name := entry.object.GetDNSName()
-	if err = dns.ValidateDomainName(name); err != nil {
+	if e, ok := entry.object.(*dnsutils.DNSEntryObject); ok {
+		if errs := ValidateEntry(e.DNSEntry()); len(errs) > 0 {
+			err = fmt.Errorf("%s", strings.Join(errs, ", "))
+			return
+		}
+	} else if err = dns.ValidateDomainName(entry.object.GetDNSName()); err != nil {
 		return
 	}
 
@@ -424,6 +472,9 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 
 	this.valid = false
 	this.responsible = false
+	this.providerSelection = p.selection
+	this.private = p.private
+	this.additional = p.additional
 	spec := this.object
 
 	///////////// handle type responsibility
@@ -515,6 +566,9 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 	if p.provider != nil && spec.GetTTL() != nil {
 		this.status.TTL = spec.GetTTL()
 	}
+	if verr == nil {
+		targets, this.splitTargets, verr = splitHorizonTargets(this, p, spec, targets)
+	}
 
 	if verr != nil {
 		hello.Infof(logger, "validation failed: %s", verr)
@@ -534,13 +588,27 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 		this.valid = true
 	} else {
 		this.warnings = warnings
-		targets, multiCName, multiOk := normalizeTargets(logger, this.object, targets...)
-		if multiCName {
-			this.interval = int64(600)
-			if iv := spec.GetCNameLookupInterval(); iv != nil && *iv > 0 {
-				this.interval = *iv
+		interval := int64(600)
+		if iv := spec.GetCNameLookupInterval(); iv != nil && *iv > 0 {
+			interval = *iv
+		}
+		cnames := targets
+		targets, multiCName, multiOk, pending := normalizeTargets(logger, state.resolver, this.object, this.shared, time.Duration(interval)*time.Second, cnames...)
+		if pending {
+			// keep the targets until the lookup is done
+			targets = this.keptTargets(old, cnames[0].GetTTL())
+			if len(targets) == 0 {
+				msg := "waiting for lookup of CNAME targets"
+				hello.Infof(logger, msg)
+				this.UpdateStatus(logger, api.STATE_PENDING, msg)
+				return reconcile.Succeeded(logger)
 			}
+		}
+		// changed addresses of CNAME targets are triggered by the resolver
+		this.interval = 0
+		if multiCName {
 			if len(targets) == 0 {
+				this.interval = interval
 				msg := "targets cannot be resolved to any valid IPv4 address"
 				if !multiOk {
 					msg = "too many targets"
@@ -558,8 +626,6 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 				this.UpdateStatus(logger, state, verr.Error())
 				return reconcile.Recheck(logger, verr, time.Duration(this.interval)*time.Second)
 			}
-		} else {
-			this.interval = 0
 		}
 
 		this.targets = targets
@@ -611,6 +677,10 @@ func (this *EntryVersion) Setup(logger logger.LogContext, state *state, p *Entry
 				AssureStringPtrPtr(&status.Message, this.status.Message).
 				AssureStringPtrPtr(&status.Zone, this.status.Zone).
 				AssureStringPtrPtr(&status.Provider, this.status.Provider)
+			if entry, ok := data.(*api.DNSEntry); ok {
+				mod.Modify(pruneAdditionalZoneStatus(&entry.Status, this.additional))
+				reportForeignAdditionalZones(mod, &entry.Status, this.additional)
+			}
 			if mod.IsModified() {
 				dnsutils.SetLastUpdateTime(&status.LastUptimeTime)
 				logmsg.Infof(logger)
@@ -649,6 +719,7 @@ func (this *EntryVersion) updateStatus(logger logger.LogContext, state, msg stri
 		if utils.StringValue(this.status.Provider) == "" {
 			mod.Modify(o.AcknowledgeTargets(nil))
 		}
+		this.assureConditions(mod, status, state, logmsg.Get(), false)
 		if mod.IsModified() {
 			logmsg.Infof(logger)
 		}
@@ -692,6 +763,7 @@ func (this *EntryVersion) UpdateStatus(logger logger.LogContext, state string, m
 		}
 		mod.AssureStringValue(&b.State, state)
 		this.status.State = state
+		this.assureConditions(mod, b, state, utils.StringValue(b.Message), false)
 		if mod.IsModified() {
 			dnsutils.SetLastUpdateTime(&b.LastUptimeTime)
 			logger.Infof("update state of '%s/%s' to %s (%s)", o.GetNamespace(), o.GetName(), state, msg)
@@ -715,6 +787,7 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 		this.status.Message = &msg
 		mod.AssureStringValue(&b.State, state)
 		this.status.State = state
+		this.assureConditions(mod, b, state, msg, msg == MSG_THROTTLING)
 		if mod.IsModified() {
 			dnsutils.SetLastUpdateTime(&b.LastUptimeTime)
 			logger.Infof("update state of '%s/%s' to %s (%s)", o.GetNamespace(), o.GetName(), state, msg)
@@ -724,6 +797,115 @@ func (this *EntryVersion) UpdateState(logger logger.LogContext, state, msg strin
 	return this.object.ModifyStatus(f)
 }
 
+// assureConditions maintains the status conditions according to the given state.
+// The state field itself is kept for compatibility.
+func (this *EntryVersion) assureConditions(mod *utils.ModificationState, status *api.DNSBaseStatus, state, msg string, throttled bool) {
+	gen := this.object.GetGeneration()
+	conds := &status.Conditions
+	ready := state == api.STATE_READY
+	reason := state
+	if reason == "" {
+		reason = "NoProvider"
+	} else if state == api.STATE_PENDING && strings.HasPrefix(msg, MSG_PROPAGATING) {
+		reason = "Propagating"
+	}
+	mod.Modify(dnsutils.AssureCondition(conds, api.ConditionReady, dnsutils.ConditionStatus(ready), reason, msg, gen))
+
+	if provider := utils.StringValue(status.Provider); provider != "" {
+		if this.providerSelection != "" {
+			mod.Modify(dnsutils.AssureCondition(conds, api.ConditionProviderAssigned, metav1.ConditionTrue, "ProviderSelected",
+				fmt.Sprintf("responsible provider %s %s", provider, this.providerSelection), gen))
+		} else {
+			mod.Modify(dnsutils.AssureCondition(conds, api.ConditionProviderAssigned, metav1.ConditionTrue, "ProviderFound",
+				fmt.Sprintf("responsible provider %s (longest matching domain)", provider), gen))
+		}
+	} else if this.providerSelection != "" {
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionProviderAssigned, metav1.ConditionFalse, "ProviderRejected",
+			this.providerSelection, gen))
+	} else {
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionProviderAssigned, metav1.ConditionFalse, "NoProvider",
+			"no responsible provider found", gen))
+	}
+
+	conflict := this.ownershipConflict
+	if conflict == "" && this.duplicate {
+		conflict = "DuplicateEntry"
+	}
+	switch {
+	case conflict != "":
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionFalse, conflict, msg, gen))
+	case ready:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionTrue, "Confirmed",
+			"dns name owned by entry", gen))
+	case meta.FindStatusCondition(*conds, api.ConditionOwnershipConfirmed) == nil:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionUnknown, "Pending",
+			"ownership not yet checked", gen))
+	}
+
+	if throttled {
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionThrottled, metav1.ConditionTrue, "ProviderThrottled", msg, gen))
+	} else {
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionThrottled, metav1.ConditionFalse, "NotThrottled", "", gen))
+	}
+
+	if ready {
+		// drift is only reported and reset by the zone reconciliation comparing the records with the zone state
+		if c := meta.FindStatusCondition(*conds, api.ConditionRecordsInSync); c == nil || c.Reason != reasonDrifted {
+			mod.Modify(dnsutils.AssureCondition(conds, api.ConditionRecordsInSync, metav1.ConditionTrue, "InSync",
+				"dns records match entry", gen))
+		}
+	} else {
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionRecordsInSync, metav1.ConditionFalse, reason, msg, gen))
+	}
+}
+
+// reasonDrifted is the reason of the RecordsInSync condition for records modified outside of the controller.
+const reasonDrifted = "Drifted"
+
+// isInSync reports whether the current targets of the entry have already been applied successfully.
+// Differences to the zone state found for such an entry are caused by out-of-band changes.
+func (this *EntryVersion) isInSync() bool {
+	e, ok := this.object.(*dnsutils.DNSEntryObject)
+	if !ok {
+		return false
+	}
+	status := e.Status()
+	if status.State != api.STATE_READY || status.ObservedGeneration != e.GetGeneration() ||
+		!utils.Int64Equal(status.TTL, this.status.TTL) {
+		return false
+	}
+	list, _ := targetList(this.targets)
+	return utils.NewStringSetByArray(list).Equals(utils.NewStringSetByArray(status.Targets))
+}
+
+// ReportDrift maintains the RecordsInSync condition of an entry whose records
+// are not corrected by the controller. The state of the entry is kept.
+func (this *EntryVersion) ReportDrift(logger logger.LogContext, drifted bool, msg string) (bool, error) {
+	status, reason := metav1.ConditionTrue, "InSync"
+	if drifted {
+		status, reason = metav1.ConditionFalse, reasonDrifted
+	} else {
+		msg = "dns records match entry"
+	}
+	if c := meta.FindStatusCondition(this.object.BaseStatus().Conditions, api.ConditionRecordsInSync); c != nil &&
+		c.Status == status && c.Reason == reason {
+		return false, nil
+	}
+	f := func(data resources.ObjectData) (bool, error) {
+		obj, err := this.object.GetResource().Wrap(data)
+		if err != nil {
+			return false, err
+		}
+		b := dnsutils.DNSObject(obj).BaseStatus()
+		mod := dnsutils.AssureCondition(&b.Conditions, api.ConditionRecordsInSync, status, reason, msg, obj.GetGeneration())
+		if mod {
+			logger.Infof("update condition %s of '%s/%s' to %s (%s)", api.ConditionRecordsInSync, obj.GetNamespace(), obj.GetName(), status, msg)
+		}
+		return mod, nil
+	}
+	return this.object.ModifyStatus(f)
+}
+
 func targetList(targets Targets) ([]string, string) {
 	list := []string{}
 	msg := "update effective targets: ["
@@ -737,24 +919,37 @@ func targetList(targets Targets) ([]string, string) {
 	return list, msg
 }
 
-func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification, targets ...Target) (Targets, bool, bool) {
-	multiCNAME := len(targets) > 1 && targets[0].GetRecordType() == dns.RS_CNAME
+// normalizeTargets maps the targets of multi-target CNAME entries to address targets
+// using the cached lookups of the resolver. CNAME targets of shared entries are always
+// mapped, because they cannot be combined with the values of other contributors.
+func normalizeTargets(logger logger.LogContext, resolver *cnameResolver, object dnsutils.DNSSpecification, shared bool, interval time.Duration, targets ...Target) (Targets, bool, bool, bool) {
+	multiCNAME := len(targets) > 0 && targets[0].GetRecordType() == dns.RS_CNAME && (len(targets) > 1 || shared)
 	if !multiCNAME {
-		return targets, false, false
+		resolver.Release(object.ClusterKey())
+		return targets, false, false, false
 	}
 
 	result := make(Targets, 0, len(targets))
-	if len(targets) > 11 {
+	if len(targets) > resolver.MaxTargets() {
 		w := fmt.Sprintf("too many CNAME targets: %d", len(targets))
 		logger.Warn(w)
 		object.Event(corev1.EventTypeWarning, "dnslookup restriction", w)
-		return result, true, false
+		resolver.Release(object.ClusterKey())
+		return result, true, false, false
+	}
+	hostnames := make([]string, 0, len(targets))
+	for _, t := range targets {
+		hostnames = append(hostnames, t.GetHostName())
+	}
+	resolved, pending := resolver.Resolve(object.ClusterKey(), interval, hostnames)
+	if pending {
+		return result, true, true, true
 	}
 	for _, t := range targets {
-		ipv4addrs, ipv6addrs, err := lookupHosts(t.GetHostName())
-		if err == nil {
+		addrs := resolved[t.GetHostName()]
+		if addrs.Err == nil {
 		outerV4:
-			for _, addr := range ipv4addrs {
+			for _, addr := range addrs.IPv4 {
 				for _, old := range result {
 					if old.GetHostName() == addr {
 						continue outerV4
@@ -763,7 +958,7 @@ func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification
 				result = append(result, dnsutils.NewTarget(dns.RS_A, addr, t.GetTTL()))
 			}
 		outerV6:
-			for _, addr := range ipv6addrs {
+			for _, addr := range addrs.IPv6 {
 				for _, old := range result {
 					if old.GetHostName() == addr {
 						continue outerV6
@@ -772,12 +967,38 @@ func normalizeTargets(logger logger.LogContext, object dnsutils.DNSSpecification
 				result = append(result, dnsutils.NewTarget(dns.RS_AAAA, addr, t.GetTTL()))
 			}
 		} else {
-			w := fmt.Sprintf("cannot lookup '%s': %s", t.GetHostName(), err)
+			w := fmt.Sprintf("cannot lookup '%s': %s", t.GetHostName(), addrs.Err)
 			logger.Warn(w)
 			object.Event(corev1.EventTypeNormal, "dnslookup", w)
 		}
 	}
-	return result, true, true
+	return result, true, true, false
+}
+
+// keptTargets returns the targets to keep while the first lookup of the CNAME targets is still pending.
+// These are the targets of the old entry version or, after a restart, the addresses already provisioned
+// according to the entry status. Entries never provisioned before have no targets to keep.
+func (this *EntryVersion) keptTargets(old *Entry, ttl int64) Targets {
+	if old != nil && len(old.targets) > 0 {
+		return old.targets
+	}
+	e, ok := this.object.(*dnsutils.DNSEntryObject)
+	if !ok {
+		return nil
+	}
+	var result Targets
+	for _, addr := range e.Status().Targets {
+		ip := net.ParseIP(addr)
+		switch {
+		case ip == nil:
+			return nil
+		case ip.To4() != nil:
+			result = append(result, dnsutils.NewTarget(dns.RS_A, addr, ttl))
+		default:
+			result = append(result, dnsutils.NewTarget(dns.RS_AAAA, addr, ttl))
+		}
+	}
+	return result
 }
 
 func lookupHosts(hostname string) ([]string, []string, error) {
@@ -0,0 +1,191 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// statusTestObject is a DNS object modifying its status in memory and recording its events.
+type statusTestObject struct {
+	resources.Object
+	data   resources.ObjectData
+	events *[]string
+}
+
+func (this *statusTestObject) Data() resources.ObjectData {
+	return this.data
+}
+
+func (this *statusTestObject) IsA(spec interface{}) bool {
+	return reflect.TypeOf(spec) == reflect.TypeOf(this.data)
+}
+
+func (this *statusTestObject) GetGeneration() int64 {
+	return this.data.GetGeneration()
+}
+
+func (this *statusTestObject) GetNamespace() string {
+	return this.data.GetNamespace()
+}
+
+func (this *statusTestObject) GetName() string {
+	return this.data.GetName()
+}
+
+func (this *statusTestObject) ObjectName() resources.ObjectName {
+	return resources.NewObjectName(this.data.GetNamespace(), this.data.GetName())
+}
+
+func (this *statusTestObject) ClusterKey() resources.ClusterObjectKey {
+	return resources.NewClusterKey("default", entryGroupKind, this.data.GetNamespace(), this.data.GetName())
+}
+
+func (this *statusTestObject) GetResource() resources.Interface {
+	return &statusTestResource{events: this.events}
+}
+
+func (this *statusTestObject) ModifyStatus(modifier resources.Modifier) (bool, error) {
+	return modifier(this.data)
+}
+
+func (this *statusTestObject) Eventf(eventtype, reason, msgfmt string, args ...interface{}) {
+	*this.events = append(*this.events, fmt.Sprintf("%s %s: %s", eventtype, reason, fmt.Sprintf(msgfmt, args...)))
+}
+
+type statusTestResource struct {
+	resources.Interface
+	events *[]string
+}
+
+func (this *statusTestResource) Wrap(data resources.ObjectData) (resources.Object, error) {
+	return &statusTestObject{data: data, events: this.events}, nil
+}
+
+var _ = ginkgo.Describe("Drift detection", func() {
+	var (
+		zone   *dnsHostedZone
+		entry  *Entry
+		events []string
+	)
+	targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300)}
+	ownership := &testOwnership{ids: utils.NewStringSet("mine")}
+
+	ownedSet := func(value string) dns.DNSSets {
+		set := dns.NewDNSSet("a.example.com")
+		set.SetOwner("mine")
+		set.SetRecordSet(dns.RS_A, 300, value)
+		return dns.DNSSets{set.Name: set}
+	}
+
+	// reconcile checks the entry against the zone state like the zone reconciliation.
+	reconcile := func(sets dns.DNSSets) bool {
+		provider := &adoptionTestProvider{sets: sets}
+		req := &zoneReconciliation{zone: zone, providers: DNSProviders{provider.ObjectName(): provider}}
+		changes := NewChangeModel(logger.New(), ownership, req, Config{Ident: "mine"})
+		Expect(changes.Setup()).To(Succeed())
+		Expect(entry.isInSync()).To(BeTrue())
+		result := changes.Check(entry.DNSName(), "default", time.Now(), nil, &testTargetSpec{targets: targets})
+		Expect(result.Error).NotTo(HaveOccurred())
+		return (&state{}).handleDrift(logger.New(), zone, entry, result.Modified)
+	}
+
+	condition := func() *metav1.Condition {
+		return meta.FindStatusCondition(entry.object.BaseStatus().Conditions, api.ConditionRecordsInSync)
+	}
+
+	ginkgo.BeforeEach(func() {
+		zone = newDNSHostedZone(time.Second, NewDNSHostedZone("test", "Z1", "example.com", "", nil, false))
+		report := api.DriftPolicyReport
+		zone.SetPolicy(newDNSHostedZonePolicy("report", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{DriftPolicy: &report}}))
+
+		ttl := int64(300)
+		data := &api.DNSEntry{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "a", Generation: 1},
+			Spec:       api.DNSEntrySpec{DNSName: "a.example.com", TTL: &ttl, Targets: []string{"1.1.1.1"}},
+			Status: api.DNSEntryStatus{
+				DNSBaseStatus: api.DNSBaseStatus{State: api.STATE_READY, TTL: &ttl, ObservedGeneration: 1, Conditions: []metav1.Condition{
+					{Type: api.ConditionRecordsInSync, Status: metav1.ConditionTrue, Reason: "InSync", Message: "dns records match entry", ObservedGeneration: 1},
+				}},
+				Targets: []string{"1.1.1.1"},
+			},
+		}
+		events = nil
+		obj := &dnsutils.DNSEntryObject{Object: &statusTestObject{data: data, events: &events}}
+		entry = &Entry{EntryVersion: &EntryVersion{object: obj, dnsname: "a.example.com", targets: targets, status: obj.Status().DNSBaseStatus}}
+	})
+
+	ginkgo.It("considers only applied entries as in sync", func() {
+		Expect(entry.isInSync()).To(BeTrue())
+
+		entry.targets = Targets{dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 300)}
+		Expect(entry.isInSync()).To(BeFalse())
+
+		entry.targets = targets
+		entry.object.BaseStatus().State = api.STATE_PENDING
+		Expect(entry.isInSync()).To(BeFalse())
+	})
+
+	ginkgo.It("reports nothing for unchanged records", func() {
+		old := *condition()
+		Expect(reconcile(ownedSet("1.1.1.1"))).To(BeFalse())
+		Expect(*condition()).To(Equal(old))
+		Expect(events).To(BeEmpty())
+	})
+
+	ginkgo.It("reports modified records as drifted until they match again", func() {
+		Expect(reconcile(ownedSet("2.2.2.2"))).To(BeTrue())
+		c := condition()
+		Expect(c).NotTo(BeNil())
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal(reasonDrifted))
+		Expect(events).To(HaveLen(1))
+
+		// an unchanged drift is reported only once
+		Expect(reconcile(ownedSet("2.2.2.2"))).To(BeTrue())
+		Expect(events).To(HaveLen(1))
+
+		Expect(reconcile(ownedSet("1.1.1.1"))).To(BeFalse())
+		c = condition()
+		Expect(c).NotTo(BeNil())
+		Expect(c.Status).To(Equal(metav1.ConditionTrue))
+		Expect(c.Reason).To(Equal("InSync"))
+		Expect(events).To(HaveLen(1))
+	})
+
+	ginkgo.It("restores modified records if the zone policy corrects drifts", func() {
+		zone.SetPolicy(nil)
+		Expect(reconcile(ownedSet("2.2.2.2"))).To(BeFalse())
+		Expect(condition().Reason).To(Equal("InSync"))
+		Expect(events).To(HaveLen(1))
+	})
+})
@@ -0,0 +1,118 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// ValidateEntry performs the static validation of a DNSEntry.
+// It is used by the dns controller on reconciliation and by the validating webhook for DNSEntries.
+func ValidateEntry(entry *api.DNSEntry) []string {
+	var errs []string
+	spec := &entry.Spec
+
+	if err := dns.ValidateDomainName(spec.DNSName); err != nil {
+		errs = append(errs, err.Error())
+	}
+	if spec.TTL != nil && *spec.TTL <= 0 {
+		errs = append(errs, "TTL must be greater than zero")
+	}
+	if spec.CNameLookupInterval != nil && *spec.CNameLookupInterval <= 0 {
+		errs = append(errs, "cnameLookupInterval must be greater than zero")
+	}
+	if spec.ProviderRef != nil && spec.ProviderRef.Name == "" {
+		errs = append(errs, "providerRef without name")
+	}
+	if spec.ProviderSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(spec.ProviderSelector); err != nil {
+			errs = append(errs, fmt.Sprintf("invalid providerSelector: %s", err))
+		}
+	}
+	if spec.Reference != nil {
+		if spec.Reference.Name == "" {
+			errs = append(errs, "reference without name")
+		}
+		if len(spec.Targets) > 0 {
+			errs = append(errs, "targets specified together with entry reference")
+		}
+		if len(spec.Text) > 0 {
+			errs = append(errs, "text specified together with entry reference")
+		}
+		if len(spec.PrivateTargets) > 0 {
+			errs = append(errs, "privateTargets specified together with entry reference")
+		}
+		return errs
+	}
+	if len(spec.Targets) > 0 && len(spec.Text) > 0 {
+		errs = append(errs, "only text or targets possible")
+	}
+	for i, t := range spec.Targets {
+		if err := validateTarget(t); err != nil {
+			errs = append(errs, fmt.Sprintf("target %d: %s", i+1, err))
+		}
+	}
+	if len(spec.PrivateTargets) > 0 && len(spec.Targets) == 0 {
+		errs = append(errs, "privateTargets require targets")
+	}
+	for i, t := range spec.PrivateTargets {
+		if err := validateTarget(t); err != nil {
+			errs = append(errs, fmt.Sprintf("private target %d: %s", i+1, err))
+		}
+	}
+	if len(spec.Text) > 0 {
+		empty := true
+		for _, t := range spec.Text {
+			if t != "" {
+				empty = false
+				break
+			}
+		}
+		if empty {
+			errs = append(errs, "dns entry has only empty text")
+		}
+	}
+	if len(spec.Targets) == 0 && len(spec.Text) == 0 {
+		errs = append(errs, "no target or text specified")
+	}
+	return errs
+}
+
+func validateTarget(target string) error {
+	if strings.TrimSpace(target) == "" {
+		return fmt.Errorf("must not be empty")
+	}
+	if net.ParseIP(target) != nil {
+		return nil
+	}
+	check := strings.ToLower(dns.NormalizeHostname(target))
+	if strings.HasPrefix(check, "_") {
+		check = "x" + check[1:]
+	}
+	if errs := validation.IsDNS1123Subdomain(check); len(errs) > 0 {
+		return fmt.Errorf("%q is neither an IP address nor a valid domain name (%v)", target, errs)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgo.Describe("Entry validation", func() {
+	entry := func(dnsName string, targets ...string) *api.DNSEntry {
+		return &api.DNSEntry{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "e1"},
+			Spec:       api.DNSEntrySpec{DNSName: dnsName, Targets: targets},
+		}
+	}
+
+	ginkgo.It("accepts valid entries", func() {
+		Expect(ValidateEntry(entry("a.example.com", "1.2.3.4", "b.example.com."))).To(BeEmpty())
+		Expect(ValidateEntry(entry("*.example.com", "_service.example.com"))).To(BeEmpty())
+
+		splitHorizon := entry("a.example.com", "1.2.3.4")
+		splitHorizon.Spec.PrivateTargets = []string{"10.0.0.1"}
+		Expect(ValidateEntry(splitHorizon)).To(BeEmpty())
+	})
+
+	ginkgo.It("rejects invalid entries", func() {
+		ttl := int64(0)
+		invalidTTL := entry("a.example.com", "1.2.3.4")
+		invalidTTL.Spec.TTL = &ttl
+		textAndTargets := entry("a.example.com", "b.example.com")
+		textAndTargets.Spec.Text = []string{"foo"}
+		noRefName := entry("a.example.com", "1.2.3.4")
+		noRefName.Spec.ProviderRef = &api.ProviderReference{Namespace: "default"}
+		badSelector := entry("a.example.com", "1.2.3.4")
+		badSelector.Spec.ProviderSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"a b": "c"}}
+		privateOnly := entry("a.example.com")
+		privateOnly.Spec.Text = []string{"foo"}
+		privateOnly.Spec.PrivateTargets = []string{"10.0.0.1"}
+		refWithTargets := entry("a.example.com", "1.2.3.4")
+		refWithTargets.Spec.Reference = &api.EntryReference{Name: "other"}
+
+		for _, e := range []*api.DNSEntry{
+			entry("a..example.com", "1.2.3.4"),
+			entry("a.example.com", "no spaces.example.com"),
+			entry("a.example.com"),
+			invalidTTL,
+			textAndTargets,
+			noRefName,
+			badSelector,
+			privateOnly,
+			refWithTargets,
+		} {
+			Expect(ValidateEntry(e)).NotTo(BeEmpty(), "%+v", e.Spec)
+		}
+	})
+
+	ginkgo.It("is applied by the controller on reconciliation", func() {
+		e := entry("a.example.com", "1.2.3.4")
+		e.Spec.ProviderSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"a b": "c"}}
+		version := &EntryVersion{object: &dnsutils.DNSEntryObject{Object: &testEntryObject{entry: e}}}
+		_, _, _, err := validate(logger.New(), &state{}, version, &EntryPremise{})
+		Expect(err).To(MatchError(ContainSubstring("invalid providerSelector")))
+	})
+})
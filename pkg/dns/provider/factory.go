@@ -24,6 +24,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/extension"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
 type DNSHandlerCreatorFunction func(config *DNSHandlerConfig) (DNSHandler, error)
@@ -34,6 +35,7 @@ type Factory struct {
 	optionCreator         extension.OptionSourceCreator
 	genericDefaults       *GenericFactoryOptions
 	supportZoneStateCache bool
+	configSchema          *ProviderConfigSchema
 }
 
 var _ DNSHandlerFactory = &Factory{}
@@ -70,6 +72,12 @@ func (this *Factory) SetOptionSourceByExample(proto config.OptionSource, default
 	return this.SetGenericFactoryOptionDefaults(defaults...)
 }
 
+// SetProviderConfigSchema sets the schema used to validate the provider config and the credentials.
+func (this *Factory) SetProviderConfigSchema(schema ProviderConfigSchema) *Factory {
+	this.configSchema = &schema
+	return this
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 
 func (this *Factory) IsResponsibleFor(object *dnsutils.DNSProviderObject) bool {
@@ -109,6 +117,13 @@ func (this *Factory) SupportZoneStateCache(typecode string) (bool, error) {
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
 
+func (this *Factory) ValidateProviderConfig(typecode string, config *runtime.RawExtension, properties utils.Properties) ([]string, error) {
+	if typecode == this.typecode {
+		return this.configSchema.ValidateProviderConfig(config, properties)
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
+
 ///////////////////////////////////////////////////////////////////////////////
 
 type CompoundFactory struct {
@@ -207,3 +222,11 @@ func (this *CompoundFactory) SupportZoneStateCache(typecode string) (bool, error
 	}
 	return false, fmt.Errorf("not responsible for %q", typecode)
 }
+
+func (this *CompoundFactory) ValidateProviderConfig(typecode string, config *runtime.RawExtension, properties utils.Properties) ([]string, error) {
+	f := this.factories[typecode]
+	if f != nil {
+		return f.ValidateProviderConfig(typecode, config, properties)
+	}
+	return nil, fmt.Errorf("not responsible for %q", typecode)
+}
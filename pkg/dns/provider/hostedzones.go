@@ -0,0 +1,347 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// DNSHostedZone objects reflecting the hosted zones discovered by the providers
+////////////////////////////////////////////////////////////////////////////////
+
+type hostedZoneInfo struct {
+	zoneid    string
+	ptype     string
+	domain    string
+	forwarded []string
+	private   bool
+	records   *int
+	entries   int
+	lastError string
+}
+
+// HostedZoneReports maps the object names of DNSHostedZone objects to the status to store.
+// A nil status indicates that the hosted zone is gone.
+type HostedZoneReports map[string]*api.DNSHostedZoneStatus
+
+// hostedZoneCache keeps the information about the hosted zones gathered by the zone
+// reconciliations and the zone caches of the accounts.
+type hostedZoneCache struct {
+	lock     sync.Mutex
+	zones    map[string]*hostedZoneInfo
+	reads    map[string]time.Time
+	cached   utils.StringSet
+	changed  utils.StringSet
+	reported map[string]*api.DNSHostedZoneStatus
+	names    map[string]string
+}
+
+var _ ZoneCacheListener = &hostedZoneCache{}
+
+func newHostedZoneCache() *hostedZoneCache {
+	return &hostedZoneCache{
+		zones:    map[string]*hostedZoneInfo{},
+		reads:    map[string]time.Time{},
+		cached:   utils.StringSet{},
+		changed:  utils.StringSet{},
+		reported: map[string]*api.DNSHostedZoneStatus{},
+		names:    map[string]string{},
+	}
+}
+
+// Report stores the result of the reconciliation of a hosted zone. If the zone
+// state could not be read, the record count of the last reconciliation is kept.
+func (this *hostedZoneCache) Report(zone *dnsHostedZone, records *int, entries int, err error) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	info := &hostedZoneInfo{
+		zoneid:    zone.Id(),
+		ptype:     zone.ProviderType(),
+		domain:    zone.Domain(),
+		forwarded: zone.ForwardedDomains(),
+		private:   zone.IsPrivate(),
+		records:   records,
+		entries:   entries,
+	}
+	if old := this.zones[zone.Id()]; old != nil && records == nil {
+		info.records = old.records
+	}
+	if err != nil {
+		info.lastError = err.Error()
+	}
+	this.zones[zone.Id()] = info
+	this.names[zone.Id()] = HostedZoneName(info.ptype, info.zoneid)
+	this.changed.Add(zone.Id())
+}
+
+// Delete removes the information of a deleted hosted zone.
+func (this *hostedZoneCache) Delete(zoneid string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.reads, zoneid)
+	this.cached.Remove(zoneid)
+	if this.zones[zoneid] != nil {
+		delete(this.zones, zoneid)
+		this.changed.Add(zoneid)
+	}
+}
+
+func (this *hostedZoneCache) ZoneStateRead(zoneid string, t time.Time) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.reads[zoneid] = t
+	this.cached.Add(zoneid)
+	this.changed.Add(zoneid)
+}
+
+func (this *hostedZoneCache) ZoneStateDropped(zoneid string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.cached.Remove(zoneid)
+	this.changed.Add(zoneid)
+}
+
+// GetChangedReports returns the status of the hosted zones changed since the last call.
+// The cache state is determined with the given state TTL or reported as disabled if
+// the state TTL getter is nil.
+func (this *hostedZoneCache) GetChangedReports(stateTTL StateTTLGetter) HostedZoneReports {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	reports := HostedZoneReports{}
+	for zoneid := range this.changed {
+		name, ok := this.names[zoneid]
+		if !ok {
+			// zone not reconciled yet
+			continue
+		}
+		var status *api.DNSHostedZoneStatus
+		if info := this.zones[zoneid]; info != nil {
+			status = this.status(info, stateTTL)
+		}
+		old, ok := this.reported[zoneid]
+		if ok && reflect.DeepEqual(old, status) {
+			continue
+		}
+		reports[name] = status
+		if this.zones[zoneid] != nil {
+			this.reported[zoneid] = status
+		} else {
+			delete(this.reported, zoneid)
+			delete(this.names, zoneid)
+		}
+	}
+	this.changed = utils.StringSet{}
+	return reports
+}
+
+func (this *hostedZoneCache) status(info *hostedZoneInfo, stateTTL StateTTLGetter) *api.DNSHostedZoneStatus {
+	status := &api.DNSHostedZoneStatus{
+		ZoneID:       info.zoneid,
+		ProviderType: info.ptype,
+		DomainName:   info.domain,
+		Private:      info.private,
+		RecordCount:  info.records,
+		EntryCount:   info.entries,
+	}
+	if len(info.forwarded) > 0 {
+		status.ForwardedDomains = append([]string{}, info.forwarded...)
+		sort.Strings(status.ForwardedDomains)
+	}
+	if info.lastError != "" {
+		status.LastError = &info.lastError
+	}
+	read, ok := this.reads[info.zoneid]
+	if ok {
+		status.LastFullReadTime = &metav1.Time{Time: read}
+	}
+	switch {
+	case stateTTL == nil:
+		status.CacheState = api.ZoneCacheStateDisabled
+	case !ok || !this.cached.Contains(info.zoneid):
+		status.CacheState = api.ZoneCacheStateEmpty
+	case time.Now().After(read.Add(stateTTL(info.zoneid))):
+		status.CacheState = api.ZoneCacheStateExpired
+	default:
+		status.CacheState = api.ZoneCacheStateCached
+	}
+	return status
+}
+
+// GetStaleReports returns deletion reports for the DNSHostedZone objects of unknown
+// hosted zones. Only objects of the given provider types are considered, as the
+// objects of other types may be maintained by other controllers.
+func (this *hostedZoneCache) GetStaleReports(objects []*api.DNSHostedZone, known utils.StringSet, ptypes utils.StringSet) HostedZoneReports {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	reports := HostedZoneReports{}
+	for _, obj := range objects {
+		if !ptypes.Contains(obj.Status.ProviderType) || known.Contains(obj.Name) {
+			continue
+		}
+		if _, ok := this.names[obj.Status.ZoneID]; ok {
+			continue
+		}
+		reports[obj.Name] = nil
+	}
+	return reports
+}
+
+// HostedZoneName returns the object name of the DNSHostedZone object for a hosted zone.
+func HostedZoneName(ptype, zoneid string) string {
+	return zoneObjectName(ptype, zoneid)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+var _ ZoneCacheListener = &state{}
+var _ ZoneStateListener = &state{}
+
+// ZoneStateRead forwards complete reads of zone states of the zone caches to the hosted zone cache.
+func (this *state) ZoneStateRead(zoneid string, t time.Time) {
+	this.hostedZones.ZoneStateRead(zoneid, t)
+}
+
+// ZoneStateDropped forwards dropped zone states of the zone caches to the hosted zone cache.
+func (this *state) ZoneStateDropped(zoneid string) {
+	this.hostedZones.ZoneStateDropped(zoneid)
+}
+
+// ZoneStateChanged informs the registered listeners about changed cached zone states.
+func (this *state) ZoneStateChanged(zoneid string) {
+	for _, l := range this.zoneStateListeners {
+		l.ZoneStateChanged(zoneid)
+	}
+}
+
+func (this *state) UpdateHostedZones(log logger.LogContext) {
+	if !this.initialized {
+		return
+	}
+	var stateTTL StateTTLGetter
+	if this.config.ZoneStateCaching {
+		if syncPeriod := this.GetContext().GetPoolPeriod(DNS_POOL); syncPeriod != nil {
+			stateTTL = this.CreateStateTTLGetter(*syncPeriod)
+		}
+	}
+	if reports := this.hostedZones.GetChangedReports(stateTTL); len(reports) > 0 {
+		log.Infof("found %d changed hosted zones", len(reports))
+		this.hostedzoneupd <- reports
+	}
+}
+
+// cleanupHostedZones deletes the DNSHostedZone objects of hosted zones not known
+// after the initial setup of the providers, for example of zones deleted while the
+// controller was not running.
+func (this *state) cleanupHostedZones() {
+	list, err := this.hostedzoneresc.List(metav1.ListOptions{})
+	if err != nil {
+		this.context.Warnf("cannot list hosted zones: %s", err)
+		return
+	}
+	var objects []*api.DNSHostedZone
+	for _, obj := range list {
+		if zone, ok := obj.Data().(*api.DNSHostedZone); ok {
+			objects = append(objects, zone)
+		}
+	}
+	known := utils.StringSet{}
+	this.lock.RLock()
+	for _, zone := range this.zones {
+		known.Add(HostedZoneName(zone.ProviderType(), zone.Id()))
+	}
+	this.lock.RUnlock()
+	if reports := this.hostedZones.GetStaleReports(objects, known, this.config.Enabled); len(reports) > 0 {
+		this.context.Infof("found %d hosted zone objects of unknown zones", len(reports))
+		this.hostedzoneupd <- reports
+	}
+}
+
+func startHostedZoneUpdater(ctx Context, resc resources.Interface) chan HostedZoneReports {
+	log := ctx.AddIndent("hostedzones: ")
+
+	requests := make(chan HostedZoneReports, 2)
+	go func() {
+		log.Infof("starting hosted zone updater")
+		for {
+			select {
+			case <-ctx.GetContext().Done():
+				log.Infof("stopping hosted zone updater")
+				return
+			case reports := <-requests:
+				for name, status := range reports {
+					updateHostedZone(log, resc, name, status)
+				}
+			}
+		}
+	}()
+	return requests
+}
+
+func updateHostedZone(log logger.LogContext, resc resources.Interface, name string, status *api.DNSHostedZoneStatus) {
+	if status == nil {
+		if err := resc.DeleteByName(resources.NewObjectName(name)); err != nil {
+			if !errors.IsNotFound(err) {
+				log.Errorf("cannot delete hosted zone %s: %s", name, err)
+			}
+		} else {
+			log.Infof("deleted hosted zone %s", name)
+		}
+		return
+	}
+
+	log.Infof("updating hosted zone %s (%s)", name, status.DomainName)
+	_, _, err := resc.CreateOrModifyByName(resources.NewObjectName(name), func(data resources.ObjectData) (bool, error) {
+		obj, ok := data.(*api.DNSHostedZone)
+		if !ok {
+			return false, fmt.Errorf("invalid hosted zone object type %T", data)
+		}
+		if reflect.DeepEqual(sanitizeHostedZoneStatus(&obj.Status), sanitizeHostedZoneStatus(status)) {
+			return false, nil
+		}
+		obj.Status = *status
+		obj.Status.LastUpdateTime = &metav1.Time{Time: time.Now()}
+		return true, nil
+	})
+	if err != nil {
+		log.Errorf("cannot update hosted zone %s: %s", name, err)
+	}
+}
+
+func sanitizeHostedZoneStatus(status *api.DNSHostedZoneStatus) *api.DNSHostedZoneStatus {
+	s := status.DeepCopy()
+	s.LastUpdateTime = nil
+	if s.LastFullReadTime != nil {
+		s.LastFullReadTime = &metav1.Time{Time: s.LastFullReadTime.Truncate(time.Second)}
+	}
+	return s
+}
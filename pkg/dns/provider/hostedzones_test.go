@@ -0,0 +1,98 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Hosted zone reports", func() {
+	zone := newDNSHostedZone(time.Second, NewDNSHostedZone("test", "zone1", "example.com", "", []string{"sub.example.com"}, true))
+	name := HostedZoneName("test", "zone1")
+	ttl := func(string) time.Duration { return time.Hour }
+
+	ginkgo.It("reports reconciled zones with their cache state", func() {
+		cache := newHostedZoneCache()
+		cache.ZoneStateRead("zone1", time.Now())
+		Expect(cache.GetChangedReports(ttl)).To(BeEmpty())
+
+		records := 5
+		cache.Report(zone, &records, 2, nil)
+		reports := cache.GetChangedReports(ttl)
+		Expect(reports).To(HaveLen(1))
+		status := reports[name]
+		Expect(status.DomainName).To(Equal("example.com"))
+		Expect(status.ForwardedDomains).To(ConsistOf("sub.example.com"))
+		Expect(status.Private).To(BeTrue())
+		Expect(*status.RecordCount).To(Equal(5))
+		Expect(status.EntryCount).To(Equal(2))
+		Expect(status.LastFullReadTime).NotTo(BeNil())
+		Expect(status.CacheState).To(Equal(api.ZoneCacheStateCached))
+		Expect(status.LastError).To(BeNil())
+
+		cache.Report(zone, &records, 2, nil)
+		Expect(cache.GetChangedReports(ttl)).To(BeEmpty())
+
+		cache.ZoneStateDropped("zone1")
+		cache.Report(zone, nil, 2, fmt.Errorf("throttled"))
+		status = cache.GetChangedReports(ttl)[name]
+		Expect(*status.RecordCount).To(Equal(5))
+		Expect(*status.LastError).To(Equal("throttled"))
+		Expect(status.CacheState).To(Equal(api.ZoneCacheStateEmpty))
+	})
+
+	ginkgo.It("reports disabled caches and deleted zones", func() {
+		cache := newHostedZoneCache()
+		cache.Report(zone, nil, 0, nil)
+		Expect(cache.GetChangedReports(nil)[name].CacheState).To(Equal(api.ZoneCacheStateDisabled))
+
+		cache.Delete("zone1")
+		reports := cache.GetChangedReports(nil)
+		Expect(reports).To(HaveKey(name))
+		Expect(reports[name]).To(BeNil())
+		Expect(cache.GetChangedReports(nil)).To(BeEmpty())
+	})
+
+	ginkgo.It("reports objects of unknown zones of enabled provider types as stale", func() {
+		object := func(ptype, zoneid string) *api.DNSHostedZone {
+			return &api.DNSHostedZone{
+				ObjectMeta: metav1.ObjectMeta{Name: HostedZoneName(ptype, zoneid)},
+				Status:     api.DNSHostedZoneStatus{ProviderType: ptype, ZoneID: zoneid},
+			}
+		}
+		cache := newHostedZoneCache()
+		cache.Report(newDNSHostedZone(time.Second, NewDNSHostedZone("test", "reconciled", "example.org", "", nil, false)), nil, 0, nil)
+		objects := []*api.DNSHostedZone{
+			object("test", "zone1"),
+			object("test", "gone"),
+			object("test", "reconciled"),
+			object("other", "foreign"),
+		}
+		reports := cache.GetStaleReports(objects, utils.NewStringSet(name), utils.NewStringSet("test"))
+		Expect(reports).To(HaveLen(1))
+		Expect(reports).To(HaveKey(HostedZoneName("test", "gone")))
+		Expect(reports[HostedZoneName("test", "gone")]).To(BeNil())
+	})
+})
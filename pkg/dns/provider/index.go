@@ -0,0 +1,227 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// dns name index
+////////////////////////////////////////////////////////////////////////////////
+
+// dnsNameIndex keeps the active entry for every zoned dns name.
+// The names are additionally indexed by hosted zone to restrict
+// a zone reconciliation to the entries of the zone.
+// Entries contributing to a shared record set are kept per dns name,
+// the active entry is one of them.
+// Entries maintained in additional zones are kept per additional zone.
+type dnsNameIndex struct {
+	names      DNSNames
+	zones      map[string]DNSNames
+	shared     map[ZonedDNSName]map[resources.ObjectName]*Entry
+	additional map[string]Entries
+	addzones   map[resources.ObjectName]utils.StringSet
+}
+
+func newDNSNameIndex() *dnsNameIndex {
+	return &dnsNameIndex{
+		names:      DNSNames{},
+		zones:      map[string]DNSNames{},
+		shared:     map[ZonedDNSName]map[resources.ObjectName]*Entry{},
+		additional: map[string]Entries{},
+		addzones:   map[resources.ObjectName]utils.StringSet{},
+	}
+}
+
+func (this *dnsNameIndex) Get(name ZonedDNSName) *Entry {
+	return this.names[name]
+}
+
+func (this *dnsNameIndex) Set(name ZonedDNSName, e *Entry) {
+	this.names[name] = e
+	zone := this.zones[name.ZoneID]
+	if zone == nil {
+		zone = DNSNames{}
+		this.zones[name.ZoneID] = zone
+	}
+	zone[name] = e
+}
+
+func (this *dnsNameIndex) Delete(name ZonedDNSName) {
+	delete(this.names, name)
+	if zone := this.zones[name.ZoneID]; zone != nil {
+		delete(zone, name)
+		if len(zone) == 0 {
+			delete(this.zones, name.ZoneID)
+		}
+	}
+}
+
+// ForZone returns the entries of a hosted zone. The result must not be modified.
+func (this *dnsNameIndex) ForZone(zoneid string) DNSNames {
+	return this.zones[zoneid]
+}
+
+// AddShared adds an entry contributing to the shared record set of a dns name.
+func (this *dnsNameIndex) AddShared(name ZonedDNSName, e *Entry) {
+	set := this.shared[name]
+	if set == nil {
+		set = map[resources.ObjectName]*Entry{}
+		this.shared[name] = set
+	}
+	set[e.ObjectName()] = e
+}
+
+func (this *dnsNameIndex) RemoveShared(name ZonedDNSName, e *Entry) {
+	if set := this.shared[name]; set != nil {
+		delete(set, e.ObjectName())
+		if len(set) == 0 {
+			delete(this.shared, name)
+		}
+	}
+}
+
+// Shared returns the entries contributing to the shared record set of a dns name.
+// The result must not be modified.
+func (this *dnsNameIndex) Shared(name ZonedDNSName) map[resources.ObjectName]*Entry {
+	return this.shared[name]
+}
+
+// SetAdditional sets the additional zones of an entry. It returns the zones
+// the entry has been removed from.
+func (this *dnsNameIndex) SetAdditional(e *Entry, zoneids utils.StringSet) utils.StringSet {
+	removed := utils.StringSet{}
+	for zoneid := range this.addzones[e.ObjectName()] {
+		if zoneids.Contains(zoneid) {
+			continue
+		}
+		removed.Add(zoneid)
+		if entries := this.additional[zoneid]; entries != nil {
+			delete(entries, e.ObjectName())
+			if len(entries) == 0 {
+				delete(this.additional, zoneid)
+			}
+		}
+	}
+	if len(zoneids) == 0 {
+		delete(this.addzones, e.ObjectName())
+		return removed
+	}
+	for zoneid := range zoneids {
+		entries := this.additional[zoneid]
+		if entries == nil {
+			entries = Entries{}
+			this.additional[zoneid] = entries
+		}
+		entries[e.ObjectName()] = e
+	}
+	this.addzones[e.ObjectName()] = zoneids.Copy()
+	return removed
+}
+
+// AdditionalForZone returns the entries maintained in a hosted zone as additional zone.
+// The result must not be modified.
+func (this *dnsNameIndex) AdditionalForZone(zoneid string) Entries {
+	return this.additional[zoneid]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// provider index
+////////////////////////////////////////////////////////////////////////////////
+
+// providerIndex maps domain names to the providers including this domain
+// or serving a hosted zone for it. It is used to determine the candidate
+// providers for a dns name by its domain suffixes.
+type providerIndex struct {
+	domains map[string]map[resources.ObjectName]*dnsProviderVersion
+	keys    map[resources.ObjectName][]string
+}
+
+func newProviderIndex() *providerIndex {
+	return &providerIndex{
+		domains: map[string]map[resources.ObjectName]*dnsProviderVersion{},
+		keys:    map[resources.ObjectName][]string{},
+	}
+}
+
+// Set (re-)indexes a provider version replacing a previous version with the same name.
+func (this *providerIndex) Set(p *dnsProviderVersion) {
+	this.set(p.ObjectName(), p)
+}
+
+func (this *providerIndex) set(name resources.ObjectName, p *dnsProviderVersion) {
+	this.Delete(name)
+	var keys []string
+	add := func(domain string) {
+		set := this.domains[domain]
+		if set == nil {
+			set = map[resources.ObjectName]*dnsProviderVersion{}
+			this.domains[domain] = set
+		}
+		if _, ok := set[name]; !ok {
+			set[name] = p
+			keys = append(keys, domain)
+		}
+	}
+	for d := range p.included {
+		add(d)
+	}
+	for _, z := range p.zones {
+		add(z.Domain())
+	}
+	this.keys[name] = keys
+}
+
+func (this *providerIndex) Delete(name resources.ObjectName) {
+	for _, d := range this.keys[name] {
+		if set := this.domains[d]; set != nil {
+			delete(set, name)
+			if len(set) == 0 {
+				delete(this.domains, d)
+			}
+		}
+	}
+	delete(this.keys, name)
+}
+
+// Candidates returns the providers indexed for any domain suffix of the given dns name.
+// Only these providers may match the dns name by their included domains or hosted zones.
+func (this *providerIndex) Candidates(dnsname string) []*dnsProviderVersion {
+	var result []*dnsProviderVersion
+	var found map[resources.ObjectName]struct{}
+	for name := dnsname; ; {
+		for n, p := range this.domains[name] {
+			if found == nil {
+				found = map[resources.ObjectName]struct{}{}
+			}
+			if _, ok := found[n]; !ok {
+				found[n] = struct{}{}
+				result = append(result, p)
+			}
+		}
+		i := strings.Index(name, ".")
+		if i < 0 {
+			break
+		}
+		name = name[i+1:]
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = ginkgo.Describe("Indices", func() {
+	ginkgo.It("indexes dns names by zone", func() {
+		index := newDNSNameIndex()
+		e1, e2 := &Entry{}, &Entry{}
+		n1 := ZonedDNSName{ZoneID: "z1", DNSName: "a.example.com"}
+		n2 := ZonedDNSName{ZoneID: "z2", DNSName: "a.example.org"}
+		index.Set(n1, e1)
+		index.Set(n2, e2)
+		Expect(index.Get(n1)).To(BeIdenticalTo(e1))
+		Expect(index.ForZone("z1")).To(Equal(DNSNames{n1: e1}))
+
+		index.Delete(n1)
+		Expect(index.Get(n1)).To(BeNil())
+		Expect(index.ForZone("z1")).To(BeEmpty())
+		Expect(index.ForZone("z2")).To(HaveLen(1))
+	})
+
+	ginkgo.It("finds candidate providers by domain suffix", func() {
+		index := newProviderIndex()
+		p1 := &dnsProviderVersion{included: utils.NewStringSet("example.com")}
+		p2 := &dnsProviderVersion{included: utils.NewStringSet("sub.example.com", "example.org"),
+			zones: DNSHostedZones{NewDNSHostedZone("test", "z1", "example.com", "", nil, false)}}
+		name1 := resources.NewObjectName("default", "p1")
+		name2 := resources.NewObjectName("default", "p2")
+		index.set(name1, p1)
+		index.set(name2, p2)
+
+		Expect(index.Candidates("a.sub.example.com")).To(ConsistOf(p1, p2))
+		Expect(index.Candidates("example.org")).To(ConsistOf(p2))
+		Expect(index.Candidates("example.net")).To(BeEmpty())
+
+		index.set(name2, &dnsProviderVersion{included: utils.NewStringSet("example.net")})
+		Expect(index.Candidates("a.sub.example.com")).To(ConsistOf(p1))
+		Expect(index.Candidates("example.net")).To(HaveLen(1))
+
+		index.Delete(name1)
+		Expect(index.Candidates("a.sub.example.com")).To(BeEmpty())
+	})
+})
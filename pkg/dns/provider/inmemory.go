@@ -20,6 +20,8 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
 	"github.com/gardener/external-dns-management/pkg/dns"
 )
 
@@ -121,6 +123,44 @@ func (m *InMemory) Apply(zoneID string, request *ChangeRequest, metrics Metrics)
 	return nil
 }
 
+// UpdateDNSSets replaces the DNS sets for the given names of a zone.
+// Names without DNS set in sets are removed from the zone.
+func (m *InMemory) UpdateDNSSets(zoneID string, names utils.StringSet, sets dns.DNSSets) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.zones[zoneID]
+	if !ok {
+		return fmt.Errorf("DNSZone %s not hosted", zoneID)
+	}
+	for name := range names {
+		if set := sets[name]; set != nil {
+			data.dnssets[name] = set.Clone()
+		} else {
+			delete(data.dnssets, name)
+		}
+	}
+	return nil
+}
+
+// GetDNSSets returns a copy of the DNS sets for the given names of a zone.
+func (m *InMemory) GetDNSSets(zoneID string, names utils.StringSet) (dns.DNSSets, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	data, ok := m.zones[zoneID]
+	if !ok {
+		return nil, fmt.Errorf("DNSZone %s not hosted", zoneID)
+	}
+	sets := dns.DNSSets{}
+	for name := range names {
+		if set := data.dnssets[name]; set != nil {
+			sets[name] = set.Clone()
+		}
+	}
+	return sets, nil
+}
+
 func buildRecordSet(req *ChangeRequest) (string, *dns.RecordSet) {
 	var dnsset *dns.DNSSet
 	switch req.Action {
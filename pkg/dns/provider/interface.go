@@ -35,19 +35,25 @@ import (
 )
 
 type Config struct {
-	TTL                int64
-	CacheTTL           time.Duration
-	CacheDir           string
-	RescheduleDelay    time.Duration
-	StatusCheckPeriod  time.Duration
-	Ident              string
-	Dryrun             bool
-	ZoneStateCaching   bool
-	Delay              time.Duration
-	Enabled            utils.StringSet
-	Options            *FactoryOptions
-	Factory            DNSHandlerFactory
-	RemoteAccessConfig *embed.RemoteAccessServerConfig
+	TTL                     int64
+	CacheTTL                time.Duration
+	CacheDir                string
+	RescheduleDelay         time.Duration
+	StatusCheckPeriod       time.Duration
+	Ident                   string
+	Dryrun                  bool
+	ZoneStateCaching        bool
+	Delay                   time.Duration
+	AccountZoneLimit        int
+	ZoneLimit               int
+	Enabled                 utils.StringSet
+	Options                 *FactoryOptions
+	Factory                 DNSHandlerFactory
+	RemoteAccessConfig      *embed.RemoteAccessServerConfig
+	RemoteAccessAuditEvents bool
+	Sharding                *ShardingConfig
+	Resolver                *ResolverConfig
+	Propagation             *PropagationConfig
 }
 
 func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (*Config, error) {
@@ -90,8 +96,19 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		return nil, err
 	}
 
+	remoteAccessAuditEvents, _ := c.GetBoolOption(OPT_REMOTE_ACCESS_AUDIT_EVENTS)
+
 	disableZoneStateCaching, _ := c.GetBoolOption(OPT_DISABLE_ZONE_STATE_CACHING)
 
+	accountZoneLimit, err := c.GetIntOption(OPT_ACCOUNT_ZONE_CONCURRENCY)
+	if err != nil {
+		accountZoneLimit = 1
+	}
+	zoneLimit, err := c.GetIntOption(OPT_ZONE_CONCURRENCY)
+	if err != nil {
+		zoneLimit = DNS_POOL_SIZE
+	}
+
 	enabled := utils.StringSet{}
 	types, err := c.GetStringOption(OPT_PROVIDERTYPES)
 	if err != nil || types == "" {
@@ -110,23 +127,44 @@ func NewConfigForController(c controller.Interface, factory DNSHandlerFactory) (
 		}
 	}
 
+	sharding, err := createShardingConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver, err := createResolverConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
+	propagation, err := createPropagationConfig(c)
+	if err != nil {
+		return nil, err
+	}
+
 	osrc, _ := c.GetOptionSource(FACTORY_OPTIONS)
 	fopts := GetFactoryOptions(osrc)
 
 	return &Config{
-		Ident:              ident,
-		TTL:                int64(ttl),
-		CacheTTL:           time.Duration(cttl) * time.Second,
-		CacheDir:           cdir,
-		RescheduleDelay:    rescheduleDelay,
-		StatusCheckPeriod:  statuscheckperiod,
-		Dryrun:             dryrun,
-		ZoneStateCaching:   !disableZoneStateCaching,
-		Delay:              delay,
-		Enabled:            enabled,
-		Options:            fopts,
-		Factory:            factory,
-		RemoteAccessConfig: remoteAccessConfig,
+		Ident:                   ident,
+		TTL:                     int64(ttl),
+		CacheTTL:                time.Duration(cttl) * time.Second,
+		CacheDir:                cdir,
+		RescheduleDelay:         rescheduleDelay,
+		StatusCheckPeriod:       statuscheckperiod,
+		Dryrun:                  dryrun,
+		ZoneStateCaching:        !disableZoneStateCaching,
+		Delay:                   delay,
+		AccountZoneLimit:        accountZoneLimit,
+		ZoneLimit:               zoneLimit,
+		Enabled:                 enabled,
+		Options:                 fopts,
+		Factory:                 factory,
+		RemoteAccessConfig:      remoteAccessConfig,
+		RemoteAccessAuditEvents: remoteAccessAuditEvents,
+		Sharding:                sharding,
+		Resolver:                resolver,
+		Propagation:             propagation,
 	}, nil
 }
 
@@ -187,6 +225,10 @@ const (
 	M_CREATERECORDS = "create_records"
 	M_DELETERECORDS = "delete_records"
 
+	M_GETZONE    = "get_zone"
+	M_CREATEZONE = "create_zone"
+	M_DELETEZONE = "delete_zone"
+
 	M_CACHED_GETZONES     = "cached_getzones"
 	M_CACHED_GETZONESTATE = "cached_getzonestate"
 )
@@ -210,6 +252,20 @@ type DNSHandler interface {
 	Release()
 }
 
+// ZoneManager is an optional interface of a DNSHandler supporting the creation
+// and deletion of hosted zones.
+type ZoneManager interface {
+	// CreateZone creates a hosted zone for a domain and returns its zone id.
+	// The reference identifies the request and is used to avoid duplicate zones
+	// if the creation is repeated.
+	CreateZone(logger logger.LogContext, domain, reference string) (string, error)
+	// GetNameServers returns the name servers of a hosted zone.
+	GetNameServers(zoneid string) ([]string, error)
+	// DeleteZone deletes a hosted zone. It must not contain records besides
+	// the records maintained by the DNS system itself (SOA and NS records).
+	DeleteZone(logger logger.LogContext, zoneid string) error
+}
+
 type DefaultDNSHandler struct {
 	providerType string
 }
@@ -238,10 +294,21 @@ type DNSHandlerFactory interface {
 	Create(typecode string, config *DNSHandlerConfig) (DNSHandler, error)
 	IsResponsibleFor(object *dnsutils.DNSProviderObject) bool
 	SupportZoneStateCache(typecode string) (bool, error)
+	// ValidateProviderConfig validates the provider config and the credentials of a provider of the given type.
+	// Errors are returned for unknown config fields and missing credentials, warnings for unknown credential keys.
+	ValidateProviderConfig(typecode string, config *runtime.RawExtension, properties utils.Properties) ([]string, error)
 }
 
 type DNSProviders map[resources.ObjectName]DNSProvider
 
+func (this DNSProviders) AccountHashes() utils.StringSet {
+	hashes := utils.StringSet{}
+	for _, p := range this {
+		hashes.Add(p.AccountHash())
+	}
+	return hashes
+}
+
 type DNSProvider interface {
 	ObjectName() resources.ObjectName
 	Object() resources.Object
@@ -256,6 +323,7 @@ type DNSProvider interface {
 	ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, requests []*ChangeRequest) error
 
 	GetDedicatedDNSAccess() DedicatedDNSAccess
+	GetZoneManager() ZoneManager
 
 	Match(dns string) int
 	MatchZone(dns string) int
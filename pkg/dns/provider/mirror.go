@@ -0,0 +1,76 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Mirror groups
+//
+// Entries assigned to a provider of a mirror group are additionally maintained
+// in the zones of all other providers of the same group serving the dns name,
+// for example to migrate a domain to another infrastructure or to serve it
+// redundantly by several DNS services.
+////////////////////////////////////////////////////////////////////////////////
+
+// mirrorGroup returns the mirror group of a provider.
+func mirrorGroup(p DNSProvider) string {
+	if v, ok := p.(*dnsProviderVersion); ok {
+		return v.MirrorGroup()
+	}
+	return ""
+}
+
+// getMirrorZones determines the zones of the other providers of the mirror group
+// of the provider assigned to an entry. Only zones of valid providers matching the
+// provider selection are considered. Zones already used by the entry are skipped.
+// Zones handled by other replicas are marked with their shard.
+func (this *state) getMirrorZones(e dnsutils.DNSSpecification, provider DNSProvider, assigned *dnsHostedZone, used additionalZones, selection *providerSelection) additionalZones {
+	group := mirrorGroup(provider)
+	if group == "" {
+		return nil
+	}
+	dnsname := e.GetDNSName()
+	var providers []*dnsProviderVersion
+	for _, p := range this.providerIndex.Candidates(dnsname) {
+		if p.MirrorGroup() == group && p.ObjectName() != provider.ObjectName() &&
+			p.IsValid() && p.Match(dnsname) > 0 && selection.Matches(p) &&
+			access.CheckAccessWithRealms(e, "use", p.Object(), this.realms) == nil {
+			providers = append(providers, p)
+		}
+	}
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ObjectName().String() < providers[j].ObjectName().String() })
+
+	var zones additionalZones
+	for _, p := range providers {
+		zone := this.getProviderZoneForName(dnsname, p)
+		if zone == nil || zone.Id() == assigned.Id() {
+			continue
+		}
+		if used.Get(zone.Id()) != nil || zones.Get(zone.Id()) != nil {
+			continue
+		}
+		zones = append(zones, &additionalZone{zoneid: zone.Id(), ptype: zone.ProviderType(), provider: p, private: zone.IsPrivate(), shard: this.foreignShardOfZone(zone.Id())})
+	}
+	return zones
+}
@@ -0,0 +1,137 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type namedEntryObject struct {
+	resources.Object
+	name resources.ObjectName
+}
+
+func (this *namedEntryObject) ObjectName() resources.ObjectName {
+	return this.name
+}
+
+var _ = ginkgo.Describe("Additional zone cleanup of deleted entries", func() {
+	entry := func(name, activezone string) *Entry {
+		obj := &namedEntryObject{name: resources.NewObjectName("default", name)}
+		return &Entry{activezone: activezone, EntryVersion: &EntryVersion{object: &dnsutils.DNSEntryObject{Object: obj}}}
+	}
+
+	ginkgo.It("keeps entries pending until all additional zones are cleaned up", func() {
+		cleanups := newPendingZoneCleanups()
+		a, b := entry("a", "primary-a"), entry("b", "primary-b")
+		cleanups.Add(a, utils.NewStringSet("mirror1", "mirror2"))
+		cleanups.Add(b, utils.NewStringSet("mirror1"))
+
+		Expect(cleanups.ForZone("mirror1")).To(ConsistOf(a.ObjectName(), b.ObjectName()))
+		Expect(cleanups.ForZone("mirror2")).To(ConsistOf(a.ObjectName()))
+
+		Expect(cleanups.Done("mirror1", cleanups.ForZone("mirror1"))).To(Equal(utils.NewStringSet("primary-b")))
+		Expect(cleanups.IsPending(a.ObjectName())).To(BeTrue())
+		Expect(cleanups.IsPending(b.ObjectName())).To(BeFalse())
+
+		Expect(cleanups.Done("mirror2", nil)).To(BeEmpty())
+		Expect(cleanups.Done("mirror2", cleanups.ForZone("mirror2"))).To(Equal(utils.NewStringSet("primary-a")))
+		Expect(cleanups.IsPending(a.ObjectName())).To(BeFalse())
+	})
+
+	ginkgo.It("does not register cleaned up zones again", func() {
+		cleanups := newPendingZoneCleanups()
+		a := entry("a", "primary-a")
+		cleanups.Add(a, utils.NewStringSet("mirror1", "mirror2"))
+		Expect(cleanups.Done("mirror1", cleanups.ForZone("mirror1"))).To(BeEmpty())
+
+		cleanups.Add(a, utils.NewStringSet("mirror1", "mirror2"))
+		Expect(cleanups.ForZone("mirror1")).To(BeEmpty())
+		Expect(cleanups.Done("mirror2", cleanups.ForZone("mirror2"))).To(Equal(utils.NewStringSet("primary-a")))
+
+		cleanups.Add(a, utils.NewStringSet("mirror1", "mirror2"))
+		Expect(cleanups.IsPending(a.ObjectName())).To(BeFalse())
+		Expect(cleanups.ForZone("mirror2")).To(BeEmpty())
+
+		cleanups.Remove(a.ObjectName())
+		cleanups.Add(a, utils.NewStringSet("mirror1"))
+		Expect(cleanups.IsPending(a.ObjectName())).To(BeTrue())
+	})
+
+	ginkgo.It("ignores entries without additional zones", func() {
+		cleanups := newPendingZoneCleanups()
+		a := entry("a", "primary-a")
+		cleanups.Add(a, utils.StringSet{})
+		Expect(cleanups.IsPending(a.ObjectName())).To(BeFalse())
+	})
+})
+
+var _ = ginkgo.Describe("Additional zones of other shards", func() {
+	s := &state{
+		zones:  map[string]*dnsHostedZone{},
+		shards: &shards{config: ShardingConfig{ID: "a"}, ring: newShardRing("a", "b"), validUntil: time.Now().Add(time.Hour)},
+	}
+	var local, foreign string
+	for i := 0; local == "" || foreign == ""; i++ {
+		id := fmt.Sprintf("Z%d", i)
+		if s.isLocalZone(id) {
+			local = id
+		} else {
+			foreign = id
+		}
+	}
+
+	ginkgo.It("marks zones of other shards with their owner", func() {
+		Expect(s.foreignShardOfZone(local)).To(Equal(""))
+		Expect(s.foreignShardOfZone(foreign)).To(Equal("b"))
+	})
+
+	ginkgo.It("reports zones of other shards as not maintained", func() {
+		zones := additionalZones{
+			{zoneid: local, ptype: "test"},
+			{zoneid: foreign, ptype: "test", shard: s.foreignShardOfZone(foreign)},
+		}
+		Expect(zones.ZoneIds()).To(Equal(utils.NewStringSet(local)))
+
+		status := &api.DNSEntryStatus{AdditionalZones: []api.DNSEntryZoneStatus{
+			{Zone: local, State: api.STATE_READY},
+			{Zone: "obsolete", State: api.STATE_READY},
+		}}
+		Expect(pruneAdditionalZoneStatus(status, zones)).To(BeTrue())
+		mod := &utils.ModificationState{}
+		reportForeignAdditionalZones(mod, status, zones)
+		Expect(mod.IsModified()).To(BeTrue())
+		Expect(status.AdditionalZones).To(HaveLen(2))
+		Expect(status.AdditionalZones[0].State).To(Equal(api.STATE_READY))
+		Expect(status.AdditionalZones[1].Zone).To(Equal(foreign))
+		Expect(status.AdditionalZones[1].State).To(Equal(api.STATE_ERROR))
+		Expect(*status.AdditionalZones[1].Message).To(ContainSubstring(`handled by shard "b"`))
+
+		mod = &utils.ModificationState{}
+		reportForeignAdditionalZones(mod, status, zones)
+		Expect(mod.IsModified()).To(BeFalse())
+	})
+})
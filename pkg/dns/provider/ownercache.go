@@ -18,6 +18,8 @@ package provider
 
 import (
 	"context"
+	"reflect"
+	"sort"
 	"sync"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
@@ -49,6 +51,16 @@ type OwnerObjectInfo struct {
 
 type OwnerName string
 
+type OwnerAdoptions map[OwnerName]*api.DNSOwnerAdoptionStatus
+
+// OwnerShardReport is the part of the status of an owner object reported by a single shard.
+type OwnerShardReport struct {
+	Entries  ProviderTypeCounts          `json:"entries,omitempty"`
+	Adoption *api.DNSOwnerAdoptionStatus `json:"adoption,omitempty"`
+}
+
+type OwnerShardReports map[OwnerName]*OwnerShardReport
+
 type OwnerObjectInfos map[OwnerName]OwnerObjectInfo
 
 type OwnerDNSActivation struct {
@@ -87,10 +99,44 @@ type OwnerCache struct {
 	ownerids   OwnerIDInfos
 	pendingids utils.StringSet
 
+	adoptions        map[OwnerName]utils.StringSet
+	adoptionzones    map[string]AdoptionProgress
+	adopted          map[string]map[string]utils.StringSet
+	adoptionreported OwnerAdoptions
+
 	schedule *dnsutils.Schedule
 }
 
 var _ dns.Ownership = &OwnerCache{}
+var _ AdoptionOwnership = &OwnerCache{}
+
+// AdoptionOwnership is an ownership additionally providing
+// the owner ids configured to adopt records of a foreign owner id.
+type AdoptionOwnership interface {
+	dns.Ownership
+	GetAdoptersFor(id string) utils.StringSet
+}
+
+// AdoptionZoneProgress describes the adoption state of a single adopting
+// owner id found during the reconciliation of a hosted zone.
+type AdoptionZoneProgress struct {
+	Pending int
+	// Adopted contains the DNS names adopted during the reconciliation
+	Adopted   utils.StringSet
+	Conflicts []string
+}
+
+// AdoptionProgress maps adopting owner ids to their progress in a hosted zone.
+type AdoptionProgress map[string]*AdoptionZoneProgress
+
+func (this AdoptionProgress) Get(id string) *AdoptionZoneProgress {
+	p := this[id]
+	if p == nil {
+		p = &AdoptionZoneProgress{}
+		this[id] = p
+	}
+	return p
+}
 
 func NewOwnerCache(ctx ProviderCacheContext, config *Config) *OwnerCache {
 	this := &OwnerCache{
@@ -99,6 +145,11 @@ func NewOwnerCache(ctx ProviderCacheContext, config *Config) *OwnerCache {
 		ownerids:       OwnerIDInfos{config.Ident: {refcount: 1, entrycounts: ProviderTypeCounts{}}},
 		dnsactivations: OwnerDNSActivations{},
 		pendingids:     utils.StringSet{},
+
+		adoptions:        map[OwnerName]utils.StringSet{},
+		adoptionzones:    map[string]AdoptionProgress{},
+		adopted:          map[string]map[string]utils.StringSet{},
+		adoptionreported: OwnerAdoptions{},
 	}
 	this.schedule = dnsutils.NewSchedule(ctx.GetContext(), dnsutils.ScheduleExecutorFunction(this.expire))
 	return this
@@ -147,6 +198,168 @@ func (this *OwnerCache) GetIds() utils.StringSet {
 	return this.ownerids.KeySet()
 }
 
+// GetInactiveOwners returns the names of inactive owner objects for an owner id.
+func (this *OwnerCache) GetInactiveOwners(id string) []string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	var names []string
+	for n, o := range this.owners {
+		if o.id == id && !o.active {
+			names = append(names, string(n))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GetAdoptersFor returns the active owner ids configured to adopt
+// records of the given owner id.
+func (this *OwnerCache) GetAdoptersFor(id string) utils.StringSet {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	adopters := utils.StringSet{}
+	for n, ids := range this.adoptions {
+		if o, ok := this.owners[n]; ok && o.active && ids.Contains(id) && o.id != id {
+			adopters.Add(o.id)
+		}
+	}
+	return adopters
+}
+
+// UpdateAdoptions updates the owner ids adopted by an owner object.
+// It returns the set of adopted owner ids whose adoption state changed.
+func (this *OwnerCache) UpdateAdoptions(cachekey OwnerName, active bool, ids []string) utils.StringSet {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	changeset := utils.StringSet{}
+	old := this.adoptions[cachekey]
+	cur := utils.StringSet{}
+	if active {
+		cur.AddAll(ids)
+	}
+	for id := range old {
+		if !cur.Contains(id) {
+			changeset.Add(id)
+		}
+	}
+	for id := range cur {
+		if !old.Contains(id) {
+			changeset.Add(id)
+		}
+	}
+	if len(cur) > 0 {
+		this.adoptions[cachekey] = cur
+	} else {
+		delete(this.adoptions, cachekey)
+	}
+	return changeset
+}
+
+// ReportAdoption stores the adoption progress of a hosted zone.
+// The DNS names adopted in a hosted zone are kept until the zone is deleted (nil progress),
+// so that reporting the same adoption again does not change the number of adopted records.
+func (this *OwnerCache) ReportAdoption(zoneid string, progress AdoptionProgress) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if progress == nil {
+		delete(this.adopted, zoneid)
+	}
+	for id, p := range progress {
+		if len(p.Adopted) == 0 {
+			continue
+		}
+		zoneAdopted := this.adopted[zoneid]
+		if zoneAdopted == nil {
+			zoneAdopted = map[string]utils.StringSet{}
+			this.adopted[zoneid] = zoneAdopted
+		}
+		if zoneAdopted[id] == nil {
+			zoneAdopted[id] = utils.StringSet{}
+		}
+		zoneAdopted[id].AddSet(p.Adopted)
+	}
+	if len(progress) > 0 {
+		this.adoptionzones[zoneid] = progress
+	} else {
+		delete(this.adoptionzones, zoneid)
+	}
+}
+
+// UpdateAdoptionStatus calculates the adoption status for all owner objects
+// configured to adopt owner ids and returns the changed ones since the last call.
+// A nil status indicates that the adoption status has to be removed.
+func (this *OwnerCache) UpdateAdoptionStatus() OwnerAdoptions {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	changed := OwnerAdoptions{}
+	current := this.currentAdoptionStatus()
+	for n, status := range current {
+		if old := this.adoptionreported[n]; old == nil || !reflect.DeepEqual(old, status) {
+			changed[n] = status
+		}
+	}
+	for n := range this.adoptionreported {
+		if _, ok := current[n]; !ok {
+			changed[n] = nil
+		}
+	}
+	this.adoptionreported = current
+	return changed
+}
+
+func (this *OwnerCache) currentAdoptionStatus() OwnerAdoptions {
+	zones := []string{}
+	for zoneid := range this.adoptionzones {
+		zones = append(zones, zoneid)
+	}
+	sort.Strings(zones)
+
+	current := OwnerAdoptions{}
+	for n := range this.adoptions {
+		o := this.owners[n]
+		if !o.active {
+			continue
+		}
+		status := &api.DNSOwnerAdoptionStatus{}
+		for _, zoneAdopted := range this.adopted {
+			status.Adopted += len(zoneAdopted[o.id])
+		}
+		for _, zoneid := range zones {
+			if p := this.adoptionzones[zoneid][o.id]; p != nil {
+				status.Pending += p.Pending
+				status.Conflicts = append(status.Conflicts, p.Conflicts...)
+			}
+		}
+		current[n] = status
+	}
+	return current
+}
+
+// GetShardReports calculates the parts of the status of all active owner objects
+// contributed by the entries and zones of the local shard.
+func (this *OwnerCache) GetShardReports(statistic statistic.OwnerStatistic, types utils.StringSet) OwnerShardReports {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	adoptions := this.currentAdoptionStatus()
+	reports := OwnerShardReports{}
+	for n, o := range this.owners {
+		if !o.active {
+			continue
+		}
+		counts := ProviderTypeCounts{}
+		pts := statistic.Get(o.id)
+		for t := range types {
+			if v, ok := pts[t]; ok && v.Count() > 0 {
+				counts[t] = v.Count()
+			}
+		}
+		reports[n] = &OwnerShardReport{Entries: counts, Adoption: adoptions[n]}
+	}
+	return reports
+}
+
 func (this *OwnerCache) UpdateCountsWith(statistic statistic.OwnerStatistic, types utils.StringSet) OwnerCounts {
 	changed := OwnerCounts{}
 	this.lock.Lock()
@@ -19,14 +19,19 @@ package provider
 
 import (
 	"context"
+	"fmt"
 	"time"
 
+	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 const ident = "TEST"
@@ -241,4 +246,143 @@ var _ = ginkgo.Describe("Owner cache", func() {
 		Expect(cache.GetIds()).To(Equal(utils.NewStringSet(ident, "id1", "id2")))
 	})
 
+	ginkgo.It("tracks adopted owner ids of active owners", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		changed := cache.UpdateAdoptions(name1, true, []string{"old1", "old2"})
+		Expect(changed).To(Equal(utils.NewStringSet("old1", "old2")))
+		Expect(cache.GetAdoptersFor("old1")).To(Equal(utils.NewStringSet("id1")))
+		Expect(cache.GetAdoptersFor("id1")).To(BeEmpty())
+
+		changed = cache.UpdateAdoptions(name1, true, []string{"old1"})
+		Expect(changed).To(Equal(utils.NewStringSet("old2")))
+		Expect(cache.GetAdoptersFor("old2")).To(BeEmpty())
+
+		cache.updateOwnerData(name1, "id1", false)
+		Expect(cache.GetAdoptersFor("old1")).To(BeEmpty())
+		changed = cache.UpdateAdoptions(name1, false, []string{"old1"})
+		Expect(changed).To(Equal(utils.NewStringSet("old1")))
+	})
+
+	ginkgo.It("reports adoption status changes", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		cache.UpdateAdoptions(name1, true, []string{"old1"})
+		cache.ReportAdoption("zone1", AdoptionProgress{"id1": {Pending: 2, Adopted: utils.NewStringSet("b.example.com")}})
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: {Pending: 2, Adopted: 1}}))
+		Expect(cache.UpdateAdoptionStatus()).To(BeEmpty())
+
+		cache.ReportAdoption("zone1", AdoptionProgress{"id1": {Adopted: utils.NewStringSet("c.example.com", "d.example.com"), Conflicts: []string{"a.example.com"}}})
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: {Adopted: 3, Conflicts: []string{"a.example.com"}}}))
+
+		cache.UpdateAdoptions(name1, true, nil)
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: nil}))
+	})
+
+	ginkgo.It("counts an adoption reported twice only once", func() {
+		cache.updateOwnerData(name1, "id1", true)
+		cache.UpdateAdoptions(name1, true, []string{"old1"})
+		progress := AdoptionProgress{"id1": {Pending: 1, Adopted: utils.NewStringSet("a.example.com")}}
+		cache.ReportAdoption("zone1", progress)
+		cache.ReportAdoption("zone1", progress)
+		cache.ReportAdoption("zone2", AdoptionProgress{"id1": {Adopted: utils.NewStringSet("a.example.com")}})
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: {Pending: 1, Adopted: 2}}))
+
+		// adopted records are kept if nothing is left to adopt in a zone
+		cache.ReportAdoption("zone1", AdoptionProgress{})
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: {Adopted: 2}}))
+
+		cache.ReportAdoption("zone2", nil)
+		Expect(cache.UpdateAdoptionStatus()).To(Equal(OwnerAdoptions{name1: {Adopted: 1}}))
+	})
+
+})
+
+////////////////////////////////////////////////////////////////////////////////
+// adoption by the change model
+
+type adoptionTestOwnership struct {
+	testOwnership
+	adopters map[string]utils.StringSet
+}
+
+func (this *adoptionTestOwnership) GetAdoptersFor(id string) utils.StringSet {
+	return this.adopters[id]
+}
+
+type adoptionTestProvider struct {
+	DNSProvider
+	sets   dns.DNSSets
+	failed utils.StringSet
+}
+
+func (this *adoptionTestProvider) ObjectName() resources.ObjectName {
+	return resources.NewObjectName("default", "p1")
+}
+
+func (this *adoptionTestProvider) AccountHash() string {
+	return "account"
+}
+
+func (this *adoptionTestProvider) Match(dns string) int {
+	return 1
+}
+
+func (this *adoptionTestProvider) MapTarget(t Target) Target {
+	return t
+}
+
+func (this *adoptionTestProvider) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	return NewDNSZoneState(this.sets.Clone()), nil
+}
+
+func (this *adoptionTestProvider) ExecuteRequests(logger logger.LogContext, zone DNSHostedZone, state DNSZoneState, reqs []*ChangeRequest) error {
+	var err error
+	for _, r := range reqs {
+		set := r.Addition
+		if set == nil {
+			set = r.Deletion
+		}
+		if this.failed.Contains(set.Name) {
+			err = fmt.Errorf("change of %s failed", set.Name)
+			r.Done.Failed(err)
+		} else {
+			r.Done.Succeeded()
+		}
+	}
+	return err
+}
+
+var _ = ginkgo.Describe("Adoption by the change model", func() {
+	zone := newDNSHostedZone(time.Second, NewDNSHostedZone("test", "Z1", "example.com", "", nil, false))
+	oldset := func(name string) *dns.DNSSet {
+		set := dns.NewDNSSet(name)
+		set.SetOwner("old")
+		set.SetRecordSet(dns.RS_A, 300, "1.1.1.1")
+		return set
+	}
+	spec := &testTargetSpec{targets: []Target{dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 300)}}
+
+	ginkgo.It("counts only successfully executed changes as adopted", func() {
+		provider := &adoptionTestProvider{
+			sets:   dns.DNSSets{"a.example.com": oldset("a.example.com"), "b.example.com": oldset("b.example.com")},
+			failed: utils.NewStringSet("b.example.com"),
+		}
+		ownership := &adoptionTestOwnership{
+			testOwnership: testOwnership{ids: utils.NewStringSet("mine")},
+			adopters:      map[string]utils.StringSet{"old": utils.NewStringSet("mine")},
+		}
+		req := &zoneReconciliation{zone: zone, providers: DNSProviders{provider.ObjectName(): provider}}
+		changes := NewChangeModel(logger.New(), ownership, req, Config{Ident: "mine"})
+		Expect(changes.Setup()).To(Succeed())
+		Expect(changes.AdoptionProgress()["mine"].Pending).To(Equal(2))
+
+		for _, name := range []string{"a.example.com", "b.example.com"} {
+			result := changes.Apply(name, "default", time.Now(), nil, spec)
+			Expect(result.Modified).To(BeTrue())
+		}
+		Expect(changes.Update(logger.New())).NotTo(Succeed())
+
+		progress := changes.AdoptionProgress()["mine"]
+		Expect(progress.Adopted).To(Equal(utils.NewStringSet("a.example.com")))
+		Expect(progress.Pending).To(Equal(1))
+	})
 })
@@ -0,0 +1,429 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	miekgdns "github.com/miekg/dns"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+)
+
+// PropagationConfig describes the verification of changed records
+// at the authoritative nameservers of a hosted zone.
+type PropagationConfig struct {
+	Timeout  time.Duration
+	Interval time.Duration
+}
+
+func createPropagationConfig(c controller.Interface) (*PropagationConfig, error) {
+	enabled, err := c.GetBoolOption(OPT_PROPAGATION_CHECK)
+	if err != nil || !enabled {
+		return nil, nil
+	}
+	timeout, err := c.GetDurationOption(OPT_PROPAGATION_TIMEOUT)
+	if err != nil || timeout <= 0 {
+		return nil, fmt.Errorf("invalid %s: must be greater than zero", OPT_PROPAGATION_TIMEOUT)
+	}
+	interval, err := c.GetDurationOption(OPT_PROPAGATION_INTERVAL)
+	if err != nil || interval < time.Second {
+		return nil, fmt.Errorf("invalid %s (must be at least 1s)", OPT_PROPAGATION_INTERVAL)
+	}
+	return &PropagationConfig{Timeout: timeout, Interval: interval}, nil
+}
+
+type propagationState int
+
+const (
+	propagationPending propagationState = iota
+	propagationDone
+	propagationTimedOut
+)
+
+var propagationResults = map[propagationState]string{
+	propagationDone:     "propagated",
+	propagationTimedOut: "timeout",
+}
+
+// expectedRecords maps record types to the normalized values expected
+// at the authoritative nameservers.
+type expectedRecords map[string]utils.StringSet
+
+func newExpectedRecords(targets Targets) expectedRecords {
+	result := expectedRecords{}
+	for _, t := range targets {
+		rtype := t.GetRecordType()
+		set := result[rtype]
+		if set == nil {
+			set = utils.StringSet{}
+			result[rtype] = set
+		}
+		set.Add(normalizeRecordValue(rtype, t.GetHostName()))
+	}
+	return result
+}
+
+func (this expectedRecords) Equals(other expectedRecords) bool {
+	if len(this) != len(other) {
+		return false
+	}
+	for rtype, set := range this {
+		if !set.Equals(other[rtype]) {
+			return false
+		}
+	}
+	return true
+}
+
+func normalizeRecordValue(rtype, value string) string {
+	switch rtype {
+	case dns.RS_A, dns.RS_AAAA:
+		if ip := net.ParseIP(value); ip != nil {
+			return ip.String()
+		}
+	case dns.RS_CNAME:
+		return strings.ToLower(strings.TrimSuffix(value, "."))
+	case dns.RS_TXT:
+		if s, err := strconv.Unquote(value); err == nil {
+			return s
+		}
+	}
+	return value
+}
+
+// servedRecords are the values of a record type answered by a nameserver.
+// alias reports address records found for a CNAME query, as served for
+// provider specific alias records.
+type servedRecords struct {
+	values []string
+	alias  bool
+}
+
+// recordQueryFunc queries the values of a record type directly at a nameserver.
+type recordQueryFunc func(server, dnsname, rtype string) (servedRecords, error)
+
+type propagationCheck struct {
+	zoneid   string
+	ptype    string
+	domain   string
+	dnsname  string
+	expected expectedRecords
+	subset   bool
+	started  time.Time
+	state    propagationState
+	pending  []string
+	queued   bool
+}
+
+// propagationVerifier checks whether changed records are served by all authoritative
+// nameservers of a hosted zone. Checks are executed by a pool of background workers
+// and repeated until the records have been propagated or the timeout is reached.
+// The hosted zone is triggered once a check is completed.
+type propagationVerifier struct {
+	lock     sync.Mutex
+	ctx      context.Context
+	logger   logger.LogContext
+	config   PropagationConfig
+	resolver *cnameResolver
+	lookupNS func(domain string) ([]string, error)
+	query    recordQueryFunc
+	trigger  func(zoneid string)
+	checks   map[resources.ClusterObjectKey]*propagationCheck
+	queue    chan resources.ClusterObjectKey
+	schedule *dnsutils.Schedule
+}
+
+func newPropagationVerifier(ctx context.Context, logger logger.LogContext, config PropagationConfig, resolver *cnameResolver, trigger func(zoneid string)) *propagationVerifier {
+	this := &propagationVerifier{
+		ctx:      ctx,
+		logger:   logger,
+		config:   config,
+		resolver: resolver,
+		trigger:  trigger,
+		checks:   map[resources.ClusterObjectKey]*propagationCheck{},
+		queue:    make(chan resources.ClusterObjectKey, 1000),
+	}
+	this.lookupNS = this.nsLookup
+	this.query = this.dnsQuery
+	this.schedule = dnsutils.NewSchedule(ctx, dnsutils.ScheduleExecutorFunction(this.recheck))
+	return this
+}
+
+// Start starts the verification workers.
+func (this *propagationVerifier) Start() {
+	for i := 0; i < this.resolver.config.Workers; i++ {
+		go this.work()
+	}
+}
+
+// Verify reports the propagation state of the records of an entry. A new check is
+// started if there is none for the given records. Completed checks are removed.
+// For entries contributing to a shared record set (subset) the served records may
+// contain additional values. Their CNAME targets are not verified, because they
+// are mapped to address records.
+func (this *propagationVerifier) Verify(key resources.ClusterObjectKey, zone *dnsHostedZone, dnsname string, targets Targets, subset bool) (propagationState, string) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	expected := newExpectedRecords(targets)
+	if subset {
+		delete(expected, dns.RS_CNAME)
+	}
+	c := this.checks[key]
+	if c == nil || c.zoneid != zone.Id() || c.dnsname != dnsname || c.subset != subset || !c.expected.Equals(expected) {
+		c = &propagationCheck{
+			zoneid:   zone.Id(),
+			ptype:    zone.ProviderType(),
+			domain:   zone.Domain(),
+			dnsname:  dnsname,
+			expected: expected,
+			subset:   subset,
+			started:  time.Now(),
+		}
+		this.checks[key] = c
+		this.enqueue(key, c)
+	}
+	switch c.state {
+	case propagationDone:
+		delete(this.checks, key)
+		return c.state, fmt.Sprintf("records propagated after %s", time.Since(c.started).Round(time.Second))
+	case propagationTimedOut:
+		delete(this.checks, key)
+		return c.state, fmt.Sprintf("propagation not verified within %s", this.config.Timeout)
+	}
+	if len(c.pending) == 0 {
+		return c.state, fmt.Sprintf("%s: waiting for authoritative nameservers", MSG_PROPAGATING)
+	}
+	return c.state, fmt.Sprintf("%s: waiting for authoritative nameservers %s", MSG_PROPAGATING, strings.Join(c.pending, ", "))
+}
+
+// Release removes the check of an entry.
+func (this *propagationVerifier) Release(key resources.ClusterObjectKey) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.checks, key)
+}
+
+func (this *propagationVerifier) enqueue(key resources.ClusterObjectKey, c *propagationCheck) {
+	if c.queued {
+		return
+	}
+	select {
+	case this.queue <- key:
+		c.queued = true
+	default:
+		// queue full, retry later
+		this.scheduleAfter(key, time.Second)
+	}
+}
+
+func (this *propagationVerifier) scheduleAfter(key resources.ClusterObjectKey, d time.Duration) {
+	if this.ctx.Err() == nil {
+		this.schedule.ScheduleAfter(key, d)
+	}
+}
+
+func (this *propagationVerifier) recheck(key dnsutils.ScheduleKey) {
+	k := key.(resources.ClusterObjectKey)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if c := this.checks[k]; c != nil && c.state == propagationPending {
+		this.enqueue(k, c)
+	}
+}
+
+func (this *propagationVerifier) work() {
+	for {
+		select {
+		case <-this.ctx.Done():
+			return
+		case key := <-this.queue:
+			this.lock.Lock()
+			c := this.checks[key]
+			if c != nil {
+				c.queued = false
+			}
+			this.lock.Unlock()
+			if c != nil {
+				this.check(key, c)
+			}
+		}
+	}
+}
+
+// check queries all authoritative nameservers of the zone and updates the state of the check.
+// The check may have been replaced in the meantime, so the result is only stored for the
+// check still registered for the entry.
+func (this *propagationVerifier) check(key resources.ClusterObjectKey, c *propagationCheck) {
+	pending, err := this.pendingNameservers(c)
+	if err != nil {
+		this.logger.Warnf("propagation check of %s failed: %s", c.dnsname, err)
+	}
+
+	this.lock.Lock()
+	if this.checks[key] != c {
+		this.lock.Unlock()
+		return
+	}
+	duration := time.Since(c.started)
+	c.pending = pending
+	switch {
+	case err == nil && len(pending) == 0:
+		c.state = propagationDone
+	case duration >= this.config.Timeout:
+		c.state = propagationTimedOut
+	default:
+		this.scheduleAfter(key, this.config.Interval)
+		this.lock.Unlock()
+		return
+	}
+	this.lock.Unlock()
+
+	this.logger.Infof("propagation check of %s in zone %s completed: %s after %s", c.dnsname, c.zoneid,
+		propagationResults[c.state], duration.Round(time.Second))
+	metrics.ReportPropagationCheck(c.ptype, c.zoneid, propagationResults[c.state], duration)
+	this.trigger(c.zoneid)
+}
+
+// pendingNameservers returns the authoritative nameservers not yet serving the expected records.
+func (this *propagationVerifier) pendingNameservers(c *propagationCheck) ([]string, error) {
+	servers, err := this.lookupNS(c.domain)
+	if err != nil {
+		return nil, err
+	}
+	if len(servers) == 0 {
+		return nil, fmt.Errorf("no nameservers found for %s", c.domain)
+	}
+	var pending []string
+	for _, server := range servers {
+		for rtype, expected := range c.expected {
+			served, err := this.query(server, c.dnsname, rtype)
+			if err != nil {
+				this.logger.Debugf("query %s %s on %s failed: %s", rtype, c.dnsname, server, err)
+				pending = append(pending, server)
+				break
+			}
+			if !(rtype == dns.RS_CNAME && served.alias) && !servesExpected(served.values, expected, c.subset) {
+				pending = append(pending, server)
+				break
+			}
+		}
+	}
+	return pending, nil
+}
+
+func servesExpected(values []string, expected utils.StringSet, subset bool) bool {
+	served := utils.NewStringSetByArray(values)
+	if subset {
+		return len(served.Intersect(expected)) == len(expected)
+	}
+	return served.Equals(expected)
+}
+
+// nsLookup determines the authoritative nameservers of a domain using
+// the nameservers of the CNAME resolver.
+func (this *propagationVerifier) nsLookup(domain string) ([]string, error) {
+	var servers []string
+	if len(this.resolver.config.Nameservers) == 0 {
+		list, err := net.LookupNS(domain)
+		if err != nil {
+			return nil, err
+		}
+		for _, ns := range list {
+			servers = append(servers, strings.TrimSuffix(ns.Host, "."))
+		}
+	} else {
+		msg, err := this.resolver.exchange(domain, miekgdns.TypeNS)
+		if err != nil {
+			return nil, err
+		}
+		for _, rr := range msg.Answer {
+			if ns, ok := rr.(*miekgdns.NS); ok {
+				servers = append(servers, strings.TrimSuffix(ns.Ns, "."))
+			}
+		}
+	}
+	sort.Strings(servers)
+	return servers, nil
+}
+
+// dnsQuery queries a record type without recursion at an authoritative nameserver.
+// CNAME records are queried with type A, so that alias records served as
+// addresses are detected. Truncated answers are repeated over TCP.
+func (this *propagationVerifier) dnsQuery(server, dnsname, rtype string) (servedRecords, error) {
+	result := servedRecords{}
+	qtype := miekgdns.StringToType[rtype]
+	if rtype == dns.RS_CNAME {
+		qtype = miekgdns.TypeA
+	}
+	client := &miekgdns.Client{Timeout: this.resolver.config.Timeout}
+	msg := &miekgdns.Msg{}
+	msg.SetQuestion(miekgdns.Fqdn(dnsname), qtype)
+	msg.SetEdns0(4096, false)
+	msg.RecursionDesired = false
+	address := net.JoinHostPort(server, "53")
+	r, _, err := client.Exchange(msg, address)
+	if err == nil && r.Truncated {
+		tcpClient := &miekgdns.Client{Net: "tcp", Timeout: this.resolver.config.Timeout}
+		r, _, err = tcpClient.Exchange(msg, address)
+	}
+	if err != nil {
+		return result, err
+	}
+	switch r.Rcode {
+	case miekgdns.RcodeSuccess, miekgdns.RcodeNameError:
+	default:
+		return result, fmt.Errorf("%s", miekgdns.RcodeToString[r.Rcode])
+	}
+	addresses := false
+	for _, rr := range r.Answer {
+		if !strings.EqualFold(rr.Header().Name, miekgdns.Fqdn(dnsname)) {
+			continue
+		}
+		switch rec := rr.(type) {
+		case *miekgdns.A:
+			addresses = true
+			if rtype == dns.RS_A {
+				result.values = append(result.values, rec.A.String())
+			}
+		case *miekgdns.AAAA:
+			result.values = append(result.values, rec.AAAA.String())
+		case *miekgdns.CNAME:
+			if rtype == dns.RS_CNAME {
+				result.values = append(result.values, normalizeRecordValue(rtype, rec.Target))
+			}
+		case *miekgdns.TXT:
+			result.values = append(result.values, strings.Join(rec.Txt, ""))
+		}
+	}
+	result.alias = rtype == dns.RS_CNAME && addresses && len(result.values) == 0
+	return result, nil
+}
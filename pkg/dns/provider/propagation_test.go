@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgo.Describe("Propagation verifier", func() {
+	var (
+		ctx       context.Context
+		cancel    context.CancelFunc
+		verifier  *propagationVerifier
+		lock      sync.Mutex
+		served    map[string]servedRecords
+		triggered []string
+	)
+	key := resources.NewClusterKey("default", entryGroupKind, "ns", "e1")
+	zone := newDNSHostedZone(time.Second, NewDNSHostedZone("test", "zone1", "example.com", "", nil, false))
+	targets := Targets{dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 60), dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 60)}
+
+	triggeredZones := func() []string {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]string{}, triggered...)
+	}
+	serve := func(server string, values ...string) {
+		lock.Lock()
+		defer lock.Unlock()
+		served[server] = servedRecords{values: values}
+	}
+	verify := func(targets Targets) func() propagationState {
+		return func() propagationState {
+			state, _ := verifier.Verify(key, zone, "a.example.com", targets, false)
+			return state
+		}
+	}
+
+	ginkgo.BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		lock.Lock()
+		served = map[string]servedRecords{}
+		triggered = nil
+		lock.Unlock()
+		resolver := newCNAMEResolver(ctx, logger.New(), ResolverConfig{Nameservers: []string{"127.0.0.1:53"}, Workers: 2}, nil)
+		config := PropagationConfig{Timeout: 300 * time.Millisecond, Interval: 10 * time.Millisecond}
+		verifier = newPropagationVerifier(ctx, logger.New(), config, resolver, func(zoneid string) {
+			lock.Lock()
+			defer lock.Unlock()
+			triggered = append(triggered, zoneid)
+		})
+		verifier.lookupNS = func(domain string) ([]string, error) {
+			return []string{"ns1.example.com", "ns2.example.com"}, nil
+		}
+		verifier.query = func(server, dnsname, rtype string) (servedRecords, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			return served[server], nil
+		}
+		verifier.Start()
+	})
+
+	ginkgo.AfterEach(func() {
+		cancel()
+	})
+
+	ginkgo.It("stays pending until all nameservers serve the records", func() {
+		serve("ns1.example.com", "2.2.2.2", "1.1.1.1")
+		state, msg := verifier.Verify(key, zone, "a.example.com", targets, false)
+		Expect(state).To(Equal(propagationPending))
+		Expect(msg).To(HavePrefix(MSG_PROPAGATING))
+		Eventually(func() string {
+			_, msg := verifier.Verify(key, zone, "a.example.com", targets, false)
+			return msg
+		}).Should(HaveSuffix("ns2.example.com"))
+
+		serve("ns2.example.com", "1.1.1.1", "2.2.2.2")
+		Eventually(triggeredZones).Should(Equal([]string{"zone1"}))
+		Expect(verify(targets)()).To(Equal(propagationDone))
+	})
+
+	ginkgo.It("times out if records are not served", func() {
+		serve("ns1.example.com", "1.1.1.1")
+		serve("ns2.example.com", "1.1.1.1")
+		Expect(verify(targets)()).To(Equal(propagationPending))
+		Eventually(triggeredZones).Should(HaveLen(1))
+		Expect(verify(targets)()).To(Equal(propagationTimedOut))
+	})
+
+	ginkgo.It("restarts the check for changed records", func() {
+		serve("ns1.example.com", "1.1.1.1")
+		serve("ns2.example.com", "1.1.1.1")
+		Expect(verify(targets)()).To(Equal(propagationPending))
+		Eventually(verify(targets[:1])).Should(Equal(propagationDone))
+	})
+
+	ginkgo.It("normalizes expected record values", func() {
+		expected := newExpectedRecords(Targets{
+			dnsutils.NewTarget(dns.RS_CNAME, "Target.Example.com.", 60),
+			dnsutils.NewText("some text", 60),
+		})
+		Expect(expected[dns.RS_CNAME].Contains("target.example.com")).To(BeTrue())
+		Expect(expected[dns.RS_TXT].Contains("some text")).To(BeTrue())
+	})
+})
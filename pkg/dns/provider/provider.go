@@ -32,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
 	"github.com/gardener/external-dns-management/pkg/dns/provider/selection"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
@@ -176,6 +177,7 @@ func (this *AccountCache) Get(logger logger.LogContext, provider *dnsutils.DNSPr
 			zonesTTL:              this.ttl,
 			stateTTLGetter:        state.CreateStateTTLGetter(*syncPeriod),
 			disableZoneStateCache: !state.config.ZoneStateCaching,
+			listener:              state,
 		}
 
 		cfg := DNSHandlerConfig{
@@ -258,7 +260,8 @@ type dnsProviderVersion struct {
 	account *DNSAccount
 	valid   bool
 
-	defaultTTL int64
+	defaultTTL  int64
+	mirrorGroup string
 
 	secret      resources.ObjectName
 	def_include utils.StringSet
@@ -271,6 +274,8 @@ type dnsProviderVersion struct {
 	included  utils.StringSet
 	excluded  utils.StringSet
 	rateLimit *api.RateLimit
+
+	configWarnings []string
 }
 
 var _ DNSProvider = &dnsProviderVersion{}
@@ -287,6 +292,12 @@ func (this *dnsProviderVersion) DefaultTTL() int64 {
 	return this.defaultTTL
 }
 
+// MirrorGroup returns the mirror group of the provider, or an empty string
+// if the provider is not part of a mirror group.
+func (this *dnsProviderVersion) MirrorGroup() string {
+	return this.mirrorGroup
+}
+
 func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if this.account != v.account {
 		return false
@@ -303,6 +314,9 @@ func (this *dnsProviderVersion) equivalentTo(v *dnsProviderVersion) bool {
 	if !reflect.DeepEqual(this.defaultTTL, v.defaultTTL) {
 		return false
 	}
+	if this.mirrorGroup != v.mirrorGroup {
+		return false
+	}
 	if this.secret != nil && v.secret != nil && this.secret != v.secret {
 		return false
 	} else {
@@ -343,6 +357,7 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 	} else {
 		this.defaultTTL = state.config.TTL
 	}
+	this.mirrorGroup = provider.Spec().MirrorGroup
 
 	if last != nil && last.ObjectName() != this.ObjectName() {
 		panic(fmt.Errorf("provider name mismatch %q<=>%q", last.ObjectName(), this.ObjectName()))
@@ -372,6 +387,14 @@ func updateDNSProvider(logger logger.LogContext, state *state, provider *dnsutil
 		return this, this.failed(logger, false, fmt.Errorf("no secret specified"), false)
 	}
 
+	this.configWarnings, err = state.GetHandlerFactory().ValidateProviderConfig(provider.TypeCode(), provider.Spec().ProviderConfig, props)
+	if err != nil {
+		return this, this.failed(logger, false, fmt.Errorf("invalid provider configuration: %s", err), false)
+	}
+	for _, warning := range this.configWarnings {
+		logger.Warnf("provider configuration: %s", warning)
+	}
+
 	this.account, err = state.GetDNSAccount(logger, provider, props)
 	if err != nil {
 		return this, this.failed(logger, false, err, true)
@@ -519,6 +542,7 @@ func (this *dnsProviderVersion) MapTarget(t Target) Target {
 
 func (this *dnsProviderVersion) setError(modified bool, err error) error {
 	modified = this.object.SetStateWithError(api.STATE_ERROR, err) || modified
+	modified = this.object.AssureConditions(api.STATE_ERROR, err.Error(), perrs.IsThrottlingError(err)) || modified
 	if modified {
 		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
 		return this.object.UpdateStatus()
@@ -573,10 +597,15 @@ func (this *dnsProviderVersion) succeeded(logger logger.LogContext, modified boo
 	status := &this.object.DNSProvider().Status
 	mod := resources.NewModificationState(this.object, modified)
 	mod.AssureStringValue(&status.State, api.STATE_READY)
-	mod.AssureStringPtrValue(&status.Message, "provider operational")
+	msg := "provider operational"
+	if len(this.configWarnings) > 0 {
+		msg = fmt.Sprintf("%s (warning: %s)", msg, strings.Join(this.configWarnings, ", "))
+	}
+	mod.AssureStringPtrValue(&status.Message, msg)
 	mod.AssureInt64Value(&status.ObservedGeneration, this.object.DNSProvider().Generation)
 	mod.AssureInt64PtrValue(&status.DefaultTTL, this.defaultTTL)
 	assureRateLimit(mod, &status.RateLimit, this.rateLimit)
+	mod.Modify(this.object.AssureConditions(api.STATE_READY, msg, false))
 	if mod.IsModified() {
 		dnsutils.SetLastUpdateTime(&this.object.Status().LastUptimeTime)
 	}
@@ -603,3 +632,8 @@ func (this *dnsProviderVersion) GetDedicatedDNSAccess() DedicatedDNSAccess {
 	h, _ := this.account.handler.(DedicatedDNSAccess)
 	return h
 }
+
+func (this *dnsProviderVersion) GetZoneManager() ZoneManager {
+	h, _ := this.account.handler.(ZoneManager)
+	return h
+}
@@ -0,0 +1,121 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+// providerSelection restricts the providers considered for an entry by the
+// provider reference or the provider selector of the entry spec.
+// Providers serving the dns name of the entry, but rejected by the selection,
+// are recorded to explain the selection in the status of the entry.
+type providerSelection struct {
+	ref      resources.ObjectName
+	selector labels.Selector
+	rejected []string
+}
+
+// newProviderSelection returns the provider selection of an entry
+// or nil if the entry does not restrict the providers.
+func newProviderSelection(e dnsutils.DNSSpecification) (*providerSelection, error) {
+	ref := e.GetProviderRef()
+	sel := e.GetProviderSelector()
+	if ref == nil && sel == nil {
+		return nil, nil
+	}
+	this := &providerSelection{}
+	if ref != nil {
+		namespace := ref.Namespace
+		if namespace == "" {
+			namespace = e.GetNamespace()
+		}
+		this.ref = resources.NewObjectName(namespace, ref.Name)
+	}
+	if sel != nil {
+		selector, err := metav1.LabelSelectorAsSelector(sel)
+		if err != nil {
+			return nil, fmt.Errorf("invalid provider selector: %s", err)
+		}
+		this.selector = selector
+	}
+	return this, nil
+}
+
+// Accepts checks whether a provider serving the dns name of the entry may be used.
+// Rejected providers are recorded.
+func (this *providerSelection) Accepts(p *dnsProviderVersion) bool {
+	ok := this.Matches(p)
+	if !ok {
+		this.rejected = append(this.rejected, p.ObjectName().String())
+	}
+	return ok
+}
+
+// Matches checks whether a provider matches the selection.
+func (this *providerSelection) Matches(p *dnsProviderVersion) bool {
+	if this == nil {
+		return true
+	}
+	if this.ref != nil && p.ObjectName().String() != this.ref.String() {
+		return false
+	}
+	if this.selector != nil && !this.selector.Matches(labels.Set(p.Object().GetLabels())) {
+		return false
+	}
+	return true
+}
+
+func (this *providerSelection) constraints() string {
+	var constraints []string
+	if this.ref != nil {
+		constraints = append(constraints, fmt.Sprintf("providerRef %s", this.ref))
+	}
+	if this.selector != nil {
+		constraints = append(constraints, fmt.Sprintf("providerSelector %q", this.selector))
+	}
+	return strings.Join(constraints, " and ")
+}
+
+func (this *providerSelection) rejections() string {
+	if len(this.rejected) == 0 {
+		return ""
+	}
+	sort.Strings(this.rejected)
+	return fmt.Sprintf(" (rejected %s)", strings.Join(this.rejected, ", "))
+}
+
+// Describe explains the selection of a provider.
+func (this *providerSelection) Describe() string {
+	if this == nil {
+		return ""
+	}
+	return fmt.Sprintf("selected by %s%s", this.constraints(), this.rejections())
+}
+
+// Error explains why no provider could be selected for a dns name.
+func (this *providerSelection) Error(dnsname string) error {
+	return fmt.Errorf("no provider for %s matching %s%s", dnsname, this.constraints(), this.rejections())
+}
@@ -17,6 +17,7 @@
 package provider
 
 import (
+	"bytes"
 	"fmt"
 	"strings"
 	"sync"
@@ -24,6 +25,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 	corev1 "k8s.io/api/core/v1"
 )
@@ -78,23 +80,81 @@ func createRemoteAccessConfig(c controller.Interface) (*embed.RemoteAccessServer
 		}
 		values[key] = value
 	}
-	parts := strings.Split(values[OPT_REMOTE_ACCESS_SERVER_SECRET_NAME], "/")
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid format for %s: expected '<namespace>/<name>'", OPT_REMOTE_ACCESS_SERVER_SECRET_NAME)
+	secretName, err := parseSecretName(OPT_REMOTE_ACCESS_SERVER_SECRET_NAME, values[OPT_REMOTE_ACCESS_SERVER_SECRET_NAME])
+	if err != nil {
+		return nil, err
 	}
-	secretName := resources.NewObjectName(parts[0], parts[1])
-	return &embed.RemoteAccessServerConfig{
+	cfg := &embed.RemoteAccessServerConfig{
 		Port:                 remoteAccessPort,
 		CACertFilename:       values[OPT_REMOTE_ACCESS_CACERT],
 		SecretName:           secretName,
 		ServerSecretProvider: &serverSecretProvider{},
-	}, nil
+	}
+	if value, _ := c.GetStringOption(OPT_REMOTE_ACCESS_POLICY_SECRET_NAME); value != "" {
+		cfg.PolicySecretName, err = parseSecretName(OPT_REMOTE_ACCESS_POLICY_SECRET_NAME, value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.PolicySecretProvider = &serverSecretProvider{}
+	}
+	if value, _ := c.GetStringOption(OPT_REMOTE_ACCESS_REVOCATION_LIST); value != "" {
+		cfg.RevocationListSecretName, err = parseSecretName(OPT_REMOTE_ACCESS_REVOCATION_LIST, value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RevocationListSecretProvider = &serverSecretProvider{}
+	}
+	if cfg.TokenTTL, err = c.GetDurationOption(OPT_REMOTE_ACCESS_TOKEN_TTL); err != nil {
+		return nil, err
+	}
+	if cfg.TokenTTL < 0 {
+		return nil, fmt.Errorf("invalid %s: must not be negative", OPT_REMOTE_ACCESS_TOKEN_TTL)
+	}
+	if value, _ := c.GetStringOption(OPT_REMOTE_ACCESS_TOKEN_SECRET_NAME); value != "" {
+		cfg.TokenSecretName, err = parseSecretName(OPT_REMOTE_ACCESS_TOKEN_SECRET_NAME, value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.TokenSecretProvider = &serverSecretProvider{}
+	}
+	if value, _ := c.GetStringOption(OPT_REMOTE_ACCESS_LIMITS_SECRET_NAME); value != "" {
+		cfg.LimitsSecretName, err = parseSecretName(OPT_REMOTE_ACCESS_LIMITS_SECRET_NAME, value)
+		if err != nil {
+			return nil, err
+		}
+		cfg.LimitsSecretProvider = &serverSecretProvider{}
+	}
+
+	var sinks audit.Sinks
+	if filename, _ := c.GetStringOption(OPT_REMOTE_ACCESS_AUDIT_LOG_FILE); filename != "" {
+		sink, err := audit.NewFileSink(c, filename)
+		if err != nil {
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if url, _ := c.GetStringOption(OPT_REMOTE_ACCESS_AUDIT_WEBHOOK_URL); url != "" {
+		sinks = append(sinks, audit.NewWebhookSink(c, url))
+	}
+	if len(sinks) > 0 {
+		cfg.AuditSink = sinks
+	}
+	return cfg, nil
+}
+
+func parseSecretName(option, value string) (resources.ObjectName, error) {
+	parts := strings.Split(value, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid format for %s: expected '<namespace>/<name>'", option)
+	}
+	return resources.NewObjectName(parts[0], parts[1]), nil
 }
 
 type serverSecretProvider struct {
 	lock     sync.Mutex
 	handlers []embed.ServerSecretUpdateHandler
 	secret   *corev1.Secret
+	updated  bool
 }
 
 var _ embed.ServerSecretProvider = &serverSecretProvider{}
@@ -104,14 +164,63 @@ func (s *serverSecretProvider) UpdateSecret(secret *corev1.Secret) {
 	defer s.lock.Unlock()
 
 	s.secret = secret
+	s.updated = true
 	for _, handler := range s.handlers {
 		handler(secret)
 	}
 }
 
+// AddUpdateHandler adds a handler for secret updates.
+// If the secret has already been set, the handler is called immediately.
 func (s *serverSecretProvider) AddUpdateHandler(handler embed.ServerSecretUpdateHandler) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	s.handlers = append(s.handlers, handler)
+	if s.updated {
+		handler(s.secret)
+	}
+}
+
+var _ audit.EventRecorder = &state{}
+
+// RecordProviderEvent reports an event on a DNSProvider object.
+func (this *state) RecordProviderEvent(namespace, name, eventtype, reason, message string) {
+	this.lock.RLock()
+	p := this.providers[resources.NewObjectName(namespace, name)]
+	this.lock.RUnlock()
+	if p != nil {
+		p.object.Event(eventtype, reason, message)
+	}
+}
+
+// revokedSessionsStore stores the revoked sessions of the remote access server in the token secret.
+type revokedSessionsStore struct {
+	secretresc resources.Interface
+	name       resources.ObjectName
+}
+
+var _ embed.SessionRevocationStore = &revokedSessionsStore{}
+
+func (s *revokedSessionsStore) ModifyRevokedSessions(modifier func(data []byte) ([]byte, error)) error {
+	secret := &corev1.Secret{}
+	if _, err := s.secretresc.GetInto(s.name, secret); err != nil {
+		return err
+	}
+	_, _, err := s.secretresc.Modify(secret, func(data resources.ObjectData) (bool, error) {
+		secret := data.(*corev1.Secret)
+		value, err := modifier(secret.Data[embed.RevokedSessionsKey])
+		if err != nil {
+			return false, err
+		}
+		if bytes.Equal(value, secret.Data[embed.RevokedSessionsKey]) {
+			return false, nil
+		}
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		secret.Data[embed.RevokedSessionsKey] = value
+		return true, nil
+	})
+	return err
 }
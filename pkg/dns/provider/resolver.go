@@ -0,0 +1,397 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	miekgdns "github.com/miekg/dns"
+
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+const resolvConf = "/etc/resolv.conf"
+
+// ResolverConfig describes the resolution of CNAME targets of multi-target entries.
+type ResolverConfig struct {
+	Nameservers []string
+	Workers     int
+	MaxTargets  int
+	MinRefresh  time.Duration
+	Timeout     time.Duration
+}
+
+func defaultResolverConfig() *ResolverConfig {
+	return &ResolverConfig{Workers: 5, MaxTargets: 25, MinRefresh: 30 * time.Second, Timeout: 5 * time.Second}
+}
+
+func createResolverConfig(c controller.Interface) (*ResolverConfig, error) {
+	cfg := defaultResolverConfig()
+	if servers, _ := c.GetStringOption(OPT_RESOLVER_NAMESERVERS); servers != "" {
+		for _, s := range strings.Split(servers, ",") {
+			if s = strings.TrimSpace(s); s == "" {
+				continue
+			}
+			if _, _, err := net.SplitHostPort(s); err != nil {
+				s = net.JoinHostPort(s, "53")
+			}
+			cfg.Nameservers = append(cfg.Nameservers, s)
+		}
+	}
+	if workers, err := c.GetIntOption(OPT_RESOLVER_WORKERS); err == nil {
+		if workers <= 0 {
+			return nil, fmt.Errorf("invalid %s: must be greater than zero", OPT_RESOLVER_WORKERS)
+		}
+		cfg.Workers = workers
+	}
+	if max, err := c.GetIntOption(OPT_RESOLVER_MAX_TARGETS); err == nil {
+		if max <= 1 {
+			return nil, fmt.Errorf("invalid %s: must be greater than one", OPT_RESOLVER_MAX_TARGETS)
+		}
+		cfg.MaxTargets = max
+	}
+	if refresh, err := c.GetDurationOption(OPT_RESOLVER_MIN_REFRESH); err == nil && refresh > 0 {
+		cfg.MinRefresh = refresh
+	}
+	return cfg, nil
+}
+
+// HostAddresses are the addresses a CNAME target is resolved to.
+type HostAddresses struct {
+	IPv4 []string
+	IPv6 []string
+	Err  error
+}
+
+// hostLookupFunc looks up the addresses of a host name together with
+// the minimum TTL of the records involved.
+type hostLookupFunc func(hostname string) (HostAddresses, time.Duration)
+
+type resolvedHost struct {
+	addrs    HostAddresses
+	resolved bool
+	queued   bool
+	users    map[resources.ClusterObjectKey]time.Duration
+}
+
+// interval returns the maximum refresh interval requested by the users of the host.
+func (this *resolvedHost) interval() time.Duration {
+	var result time.Duration
+	for _, iv := range this.users {
+		if result == 0 || iv < result {
+			result = iv
+		}
+	}
+	return result
+}
+
+// cnameResolver resolves the targets of multi-target CNAME entries to addresses.
+// Lookups are done by a pool of background workers. The results are cached and
+// refreshed according to the TTL of the records, bounded by the lookup interval
+// of the entries. Entries are triggered whenever the addresses of one of their
+// targets change.
+type cnameResolver struct {
+	lock     sync.Mutex
+	ctx      context.Context
+	logger   logger.LogContext
+	config   ResolverConfig
+	lookup   hostLookupFunc
+	trigger  func(key resources.ClusterObjectKey)
+	hosts    map[string]*resolvedHost
+	entries  map[resources.ClusterObjectKey]utils.StringSet
+	queue    chan string
+	schedule *dnsutils.Schedule
+}
+
+func newCNAMEResolver(ctx context.Context, logger logger.LogContext, config ResolverConfig, trigger func(key resources.ClusterObjectKey)) *cnameResolver {
+	this := &cnameResolver{
+		ctx:     ctx,
+		logger:  logger,
+		config:  config,
+		trigger: trigger,
+		hosts:   map[string]*resolvedHost{},
+		entries: map[resources.ClusterObjectKey]utils.StringSet{},
+		queue:   make(chan string, 1000),
+	}
+	this.lookup = this.dnsLookup
+	if len(config.Nameservers) == 0 {
+		if cc, err := miekgdns.ClientConfigFromFile(resolvConf); err == nil && len(cc.Servers) > 0 {
+			for _, s := range cc.Servers {
+				this.config.Nameservers = append(this.config.Nameservers, net.JoinHostPort(s, cc.Port))
+			}
+		} else {
+			logger.Infof("no nameservers found in %s, using system resolver for CNAME targets", resolvConf)
+			this.lookup = this.systemLookup
+		}
+	}
+	this.schedule = dnsutils.NewSchedule(ctx, dnsutils.ScheduleExecutorFunction(this.refresh))
+	return this
+}
+
+// Start starts the lookup workers.
+func (this *cnameResolver) Start() {
+	for i := 0; i < this.config.Workers; i++ {
+		go this.work()
+	}
+}
+
+func (this *cnameResolver) MaxTargets() int {
+	return this.config.MaxTargets
+}
+
+// Resolve returns the cached addresses of the given hosts for an entry and registers
+// the entry for updates. Hosts not resolved yet are queued for lookup and reported
+// as pending. Hosts not used by the entry anymore are released.
+func (this *cnameResolver) Resolve(key resources.ClusterObjectKey, interval time.Duration, hostnames []string) (map[string]HostAddresses, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	pending := false
+	result := map[string]HostAddresses{}
+	used := utils.NewStringSet(hostnames...)
+	for name := range this.entries[key] {
+		if !used.Contains(name) {
+			this.release(key, name)
+		}
+	}
+	this.entries[key] = used
+	for _, name := range hostnames {
+		h := this.hosts[name]
+		if h == nil {
+			h = &resolvedHost{users: map[resources.ClusterObjectKey]time.Duration{}}
+			this.hosts[name] = h
+		}
+		h.users[key] = interval
+		if h.resolved {
+			result[name] = h.addrs
+		} else {
+			pending = true
+			this.enqueue(name, h)
+		}
+	}
+	return result, pending
+}
+
+// Lookup returns the cached addresses of a host registered by an entry.
+// It returns false if the host is unknown or has not been resolved yet.
+func (this *cnameResolver) Lookup(hostname string) (HostAddresses, bool) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	if h := this.hosts[hostname]; h != nil && h.resolved {
+		return h.addrs, true
+	}
+	return HostAddresses{}, false
+}
+
+// Release removes all host registrations of an entry.
+func (this *cnameResolver) Release(key resources.ClusterObjectKey) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+
+	for name := range this.entries[key] {
+		this.release(key, name)
+	}
+	delete(this.entries, key)
+}
+
+func (this *cnameResolver) release(key resources.ClusterObjectKey, name string) {
+	if h := this.hosts[name]; h != nil {
+		delete(h.users, key)
+		if len(h.users) == 0 {
+			delete(this.hosts, name)
+		}
+	}
+}
+
+func (this *cnameResolver) enqueue(name string, h *resolvedHost) {
+	if h.queued {
+		return
+	}
+	select {
+	case this.queue <- name:
+		h.queued = true
+	default:
+		// queue full, retry later
+		this.scheduleAfter(name, time.Second)
+	}
+}
+
+func (this *cnameResolver) scheduleAfter(name string, d time.Duration) {
+	if this.ctx.Err() == nil {
+		this.schedule.ScheduleAfter(name, d)
+	}
+}
+
+func (this *cnameResolver) refresh(key dnsutils.ScheduleKey) {
+	name := key.(string)
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if h := this.hosts[name]; h != nil {
+		this.enqueue(name, h)
+	}
+}
+
+func (this *cnameResolver) work() {
+	for {
+		select {
+		case <-this.ctx.Done():
+			return
+		case name := <-this.queue:
+			addrs, ttl := this.lookup(name)
+			this.update(name, addrs, ttl)
+		}
+	}
+}
+
+// update stores a lookup result, schedules the next refresh and triggers
+// the entries using the host if the addresses have changed.
+func (this *cnameResolver) update(name string, addrs HostAddresses, ttl time.Duration) {
+	this.lock.Lock()
+	h := this.hosts[name]
+	if h == nil {
+		this.lock.Unlock()
+		return
+	}
+	h.queued = false
+	if addrs.Err != nil && h.resolved && h.addrs.Err == nil && isTemporaryLookupError(addrs.Err) {
+		// keep last valid addresses on temporary failures
+		this.logger.Warnf("lookup of %s failed temporarily: %s", name, addrs.Err)
+		addrs = h.addrs
+		ttl = this.config.MinRefresh
+	}
+	if addrs.Err != nil {
+		ttl = this.config.MinRefresh
+	}
+	changed := !h.resolved || !reflect.DeepEqual(h.addrs, addrs)
+	h.addrs = addrs
+	h.resolved = true
+	var keys []resources.ClusterObjectKey
+	if changed {
+		for k := range h.users {
+			keys = append(keys, k)
+		}
+	}
+	this.scheduleAfter(name, this.refreshInterval(ttl, h.interval()))
+	this.lock.Unlock()
+
+	for _, k := range keys {
+		this.trigger(k)
+	}
+}
+
+// refreshInterval limits the TTL of the records by the lookup interval of the entries.
+// An unknown TTL (zero) is replaced by the lookup interval.
+func (this *cnameResolver) refreshInterval(ttl, max time.Duration) time.Duration {
+	if max > 0 && (ttl == 0 || ttl > max) {
+		ttl = max
+	}
+	if ttl < this.config.MinRefresh {
+		ttl = this.config.MinRefresh
+	}
+	return ttl
+}
+
+type temporaryLookupError struct {
+	error
+}
+
+func isTemporaryLookupError(err error) bool {
+	_, ok := err.(*temporaryLookupError)
+	return ok
+}
+
+// dnsLookup resolves the A and AAAA records of a host using the configured nameservers.
+func (this *cnameResolver) dnsLookup(hostname string) (HostAddresses, time.Duration) {
+	result := HostAddresses{}
+	var minTTL uint32
+	for _, qtype := range []uint16{miekgdns.TypeA, miekgdns.TypeAAAA} {
+		msg, err := this.exchange(hostname, qtype)
+		if err != nil {
+			result.Err = err
+			return result, 0
+		}
+		for _, rr := range msg.Answer {
+			if minTTL == 0 || rr.Header().Ttl < minTTL {
+				minTTL = rr.Header().Ttl
+			}
+			switch r := rr.(type) {
+			case *miekgdns.A:
+				result.IPv4 = append(result.IPv4, r.A.String())
+			case *miekgdns.AAAA:
+				result.IPv6 = append(result.IPv6, r.AAAA.String())
+			}
+		}
+	}
+	if len(result.IPv4) == 0 && len(result.IPv6) == 0 {
+		result.Err = fmt.Errorf("%s has no IPv4/IPv6 address", hostname)
+	}
+	sort.Strings(result.IPv4)
+	sort.Strings(result.IPv6)
+	return result, time.Duration(minTTL) * time.Second
+}
+
+// exchange queries the nameservers one after the other until an answer is found.
+// Truncated UDP answers of hosts with many addresses are repeated over TCP.
+func (this *cnameResolver) exchange(hostname string, qtype uint16) (*miekgdns.Msg, error) {
+	client := &miekgdns.Client{Timeout: this.config.Timeout}
+	tcpClient := &miekgdns.Client{Net: "tcp", Timeout: this.config.Timeout}
+	msg := &miekgdns.Msg{}
+	msg.SetQuestion(miekgdns.Fqdn(hostname), qtype)
+	msg.SetEdns0(4096, false)
+	var err error
+	for _, server := range this.config.Nameservers {
+		var r *miekgdns.Msg
+		r, _, err = client.Exchange(msg, server)
+		if err == nil && r.Truncated {
+			r, _, err = tcpClient.Exchange(msg, server)
+		}
+		if err != nil {
+			err = &temporaryLookupError{fmt.Errorf("lookup %s on %s: %s", hostname, server, err)}
+			continue
+		}
+		switch r.Rcode {
+		case miekgdns.RcodeSuccess:
+			return r, nil
+		case miekgdns.RcodeNameError:
+			return nil, fmt.Errorf("lookup %s: no such host", hostname)
+		default:
+			err = &temporaryLookupError{fmt.Errorf("lookup %s on %s: %s", hostname, server, miekgdns.RcodeToString[r.Rcode])}
+		}
+	}
+	return nil, err
+}
+
+// systemLookup uses the system resolver if no nameservers are available.
+// The TTL of the records is unknown in this case.
+func (this *cnameResolver) systemLookup(hostname string) (HostAddresses, time.Duration) {
+	ipv4addrs, ipv6addrs, err := lookupHosts(hostname)
+	sort.Strings(ipv4addrs)
+	sort.Strings(ipv6addrs)
+	return HostAddresses{IPv4: ipv4addrs, IPv6: ipv6addrs, Err: err}, 0
+}
@@ -0,0 +1,244 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	miekgdns "github.com/miekg/dns"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type testTargetSpec struct {
+	targets []Target
+}
+
+func (this *testTargetSpec) Kind() string {
+	return api.DNSEntryKind
+}
+
+func (this *testTargetSpec) OwnerId() string {
+	return ""
+}
+
+func (this *testTargetSpec) Targets() []Target {
+	return this.targets
+}
+
+func (this *testTargetSpec) Responsible(set *dns.DNSSet, ownership dns.Ownership) bool {
+	return true
+}
+
+var _ = ginkgo.Describe("CNAME resolver", func() {
+	var (
+		ctx       context.Context
+		cancel    context.CancelFunc
+		resolver  *cnameResolver
+		lock      sync.Mutex
+		addresses map[string]HostAddresses
+		triggered []resources.ClusterObjectKey
+	)
+	key := resources.NewClusterKey("default", entryGroupKind, "ns", "e1")
+
+	triggeredKeys := func() []resources.ClusterObjectKey {
+		lock.Lock()
+		defer lock.Unlock()
+		return append([]resources.ClusterObjectKey{}, triggered...)
+	}
+	setAddresses := func(host string, addrs HostAddresses) {
+		lock.Lock()
+		defer lock.Unlock()
+		addresses[host] = addrs
+	}
+
+	ginkgo.BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		lock.Lock()
+		addresses = map[string]HostAddresses{}
+		triggered = nil
+		lock.Unlock()
+		config := ResolverConfig{Nameservers: []string{"127.0.0.1:53"}, Workers: 2, MaxTargets: 5, MinRefresh: 10 * time.Millisecond}
+		resolver = newCNAMEResolver(ctx, logger.New(), config, func(key resources.ClusterObjectKey) {
+			lock.Lock()
+			defer lock.Unlock()
+			triggered = append(triggered, key)
+		})
+		resolver.lookup = func(hostname string) (HostAddresses, time.Duration) {
+			lock.Lock()
+			defer lock.Unlock()
+			if a, ok := addresses[hostname]; ok {
+				return a, 20 * time.Millisecond
+			}
+			return HostAddresses{Err: fmt.Errorf("no such host")}, 0
+		}
+		resolver.Start()
+	})
+
+	ginkgo.AfterEach(func() {
+		cancel()
+	})
+
+	ginkgo.It("resolves hosts in the background and triggers the entry", func() {
+		setAddresses("a.example.com", HostAddresses{IPv4: []string{"1.1.1.1"}})
+		setAddresses("b.example.com", HostAddresses{IPv6: []string{"::1"}})
+
+		_, pending := resolver.Resolve(key, time.Minute, []string{"a.example.com", "b.example.com"})
+		Expect(pending).To(BeTrue())
+		Eventually(triggeredKeys).Should(ContainElement(key))
+
+		result, pending := resolver.Resolve(key, time.Minute, []string{"a.example.com", "b.example.com"})
+		Expect(pending).To(BeFalse())
+		Expect(result["a.example.com"].IPv4).To(Equal([]string{"1.1.1.1"}))
+		Expect(result["b.example.com"].IPv6).To(Equal([]string{"::1"}))
+	})
+
+	ginkgo.It("refreshes hosts and triggers the entry on changes only", func() {
+		setAddresses("a.example.com", HostAddresses{IPv4: []string{"1.1.1.1"}})
+		resolver.Resolve(key, time.Minute, []string{"a.example.com"})
+		Eventually(triggeredKeys).Should(HaveLen(1))
+		Consistently(triggeredKeys, 100*time.Millisecond).Should(HaveLen(1))
+
+		setAddresses("a.example.com", HostAddresses{IPv4: []string{"2.2.2.2"}})
+		Eventually(triggeredKeys).Should(HaveLen(2))
+		result, _ := resolver.Resolve(key, time.Minute, []string{"a.example.com"})
+		Expect(result["a.example.com"].IPv4).To(Equal([]string{"2.2.2.2"}))
+	})
+
+	ginkgo.It("keeps addresses on temporary failures", func() {
+		setAddresses("a.example.com", HostAddresses{IPv4: []string{"1.1.1.1"}})
+		resolver.Resolve(key, time.Minute, []string{"a.example.com"})
+		Eventually(triggeredKeys).Should(HaveLen(1))
+
+		setAddresses("a.example.com", HostAddresses{Err: &temporaryLookupError{fmt.Errorf("timeout")}})
+		Consistently(triggeredKeys, 100*time.Millisecond).Should(HaveLen(1))
+		result, _ := resolver.Resolve(key, time.Minute, []string{"a.example.com"})
+		Expect(result["a.example.com"].IPv4).To(Equal([]string{"1.1.1.1"}))
+	})
+
+	ginkgo.It("releases hosts not used anymore", func() {
+		resolver.Resolve(key, time.Minute, []string{"a.example.com", "b.example.com"})
+		resolver.Resolve(key, time.Minute, []string{"a.example.com"})
+		resolver.lock.Lock()
+		Expect(resolver.hosts).To(HaveLen(1))
+		resolver.lock.Unlock()
+
+		resolver.Release(key)
+		resolver.lock.Lock()
+		Expect(resolver.hosts).To(BeEmpty())
+		resolver.lock.Unlock()
+	})
+
+	ginkgo.It("repeats truncated answers over TCP", func() {
+		pc, err := net.ListenPacket("udp", "127.0.0.1:0")
+		Expect(err).NotTo(HaveOccurred())
+		l, err := net.Listen("tcp", pc.LocalAddr().String())
+		Expect(err).NotTo(HaveOccurred())
+		handler := miekgdns.HandlerFunc(func(w miekgdns.ResponseWriter, req *miekgdns.Msg) {
+			m := &miekgdns.Msg{}
+			m.SetReply(req)
+			if w.LocalAddr().Network() == "udp" {
+				m.Truncated = true
+			} else if req.Question[0].Qtype == miekgdns.TypeA {
+				for i := 1; i <= 3; i++ {
+					rr, _ := miekgdns.NewRR(fmt.Sprintf("%s 60 IN A 10.0.0.%d", req.Question[0].Name, i))
+					m.Answer = append(m.Answer, rr)
+				}
+			}
+			_ = w.WriteMsg(m)
+		})
+		udp := &miekgdns.Server{PacketConn: pc, Handler: handler}
+		tcp := &miekgdns.Server{Listener: l, Handler: handler}
+		go func() { _ = udp.ActivateAndServe() }()
+		go func() { _ = tcp.ActivateAndServe() }()
+		defer func() {
+			_ = udp.Shutdown()
+			_ = tcp.Shutdown()
+		}()
+
+		resolver.config.Nameservers = []string{pc.LocalAddr().String()}
+		resolver.config.Timeout = 2 * time.Second
+		addrs, ttl := resolver.dnsLookup("many.example.com")
+		Expect(addrs.Err).NotTo(HaveOccurred())
+		Expect(addrs.IPv4).To(Equal([]string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}))
+		Expect(ttl).To(Equal(time.Minute))
+	})
+
+	ginkgo.It("provides the cached addresses to the change model", func() {
+		setAddresses("a.example.com", HostAddresses{IPv4: []string{"1.1.1.1"}})
+		setAddresses("b.example.com", HostAddresses{IPv6: []string{"::1"}})
+		model := &ChangeModel{LogContext: logger.New(), context: &zoneReconciliation{resolver: resolver}}
+		provider := &dnsProviderVersion{account: &DNSAccount{handler: &identityHandler{}}}
+		spec := &testTargetSpec{targets: []Target{
+			dnsutils.NewTarget(dns.RS_CNAME, "a.example.com", 300),
+			dnsutils.NewTarget(dns.RS_CNAME, "b.example.com", 300),
+		}}
+
+		// not resolved yet, no synchronous lookup
+		set := model.ApplySpec(dns.NewDNSSet("x.example.com"), nil, provider, spec)
+		Expect(set.Sets).To(BeEmpty())
+
+		resolver.Resolve(key, time.Minute, []string{"a.example.com", "b.example.com"})
+		Eventually(triggeredKeys).Should(ContainElement(key))
+		set = model.ApplySpec(dns.NewDNSSet("x.example.com"), nil, provider, spec)
+		Expect(set.Sets[dns.RS_A].Records).To(Equal(dns.Records{{Value: "1.1.1.1"}}))
+		Expect(set.Sets[dns.RS_AAAA].Records).To(Equal(dns.Records{{Value: "::1"}}))
+	})
+
+	ginkgo.It("keeps the provisioned targets of a ready entry while the first lookup is pending", func() {
+		entry := func(status api.DNSEntryStatus) *EntryVersion {
+			data := &api.DNSEntry{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "e1"}, Status: status}
+			return &EntryVersion{object: &dnsutils.DNSEntryObject{Object: &statusTestObject{data: data}}}
+		}
+		cnames := []Target{
+			dnsutils.NewTarget(dns.RS_CNAME, "a.example.com", 300),
+			dnsutils.NewTarget(dns.RS_CNAME, "b.example.com", 300),
+		}
+
+		// restarted controller: no old entry version, but ready according to the status
+		ready := entry(api.DNSEntryStatus{DNSBaseStatus: api.DNSBaseStatus{State: api.STATE_READY}, Targets: []string{"1.1.1.1", "::1"}})
+		_, _, _, pending := normalizeTargets(logger.New(), resolver, ready.object, false, time.Minute, cnames...)
+		Expect(pending).To(BeTrue())
+		Expect(ready.keptTargets(nil, 300)).To(Equal(Targets{
+			dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 300),
+			dnsutils.NewTarget(dns.RS_AAAA, "::1", 300),
+		}))
+
+		// never provisioned
+		Expect(entry(api.DNSEntryStatus{}).keptTargets(nil, 300)).To(BeEmpty())
+
+		old := &Entry{EntryVersion: &EntryVersion{targets: Targets{dnsutils.NewTarget(dns.RS_A, "2.2.2.2", 300)}}}
+		Expect(ready.keptTargets(old, 300)).To(Equal(old.targets))
+	})
+
+	ginkgo.It("limits the refresh interval", func() {
+		Expect(resolver.refreshInterval(time.Hour, time.Minute)).To(Equal(time.Minute))
+		Expect(resolver.refreshInterval(0, time.Minute)).To(Equal(time.Minute))
+		Expect(resolver.refreshInterval(time.Millisecond, time.Minute)).To(Equal(10 * time.Millisecond))
+	})
+})
@@ -0,0 +1,320 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	coordinationclient "k8s.io/client-go/kubernetes/typed/coordination/v1"
+)
+
+// LabelShardGroup marks the leases of all replicas sharing the hosted zones.
+const LabelShardGroup = "dns.gardener.cloud/shard-group"
+
+const shardVirtualNodes = 128
+
+// ShardingConfig describes the shard membership of a controller replica.
+type ShardingConfig struct {
+	ID            string
+	LeasePrefix   string
+	Namespace     string
+	LeaseDuration time.Duration
+}
+
+func createShardingConfig(c controller.Interface) (*ShardingConfig, error) {
+	enabled, err := c.GetBoolOption(OPT_SHARDING)
+	if err != nil || !enabled {
+		return nil, nil
+	}
+	id, _ := c.GetStringOption(OPT_SHARD_ID)
+	if id == "" {
+		id = defaultShardID()
+	}
+	if shardLeaseName(id) == "" {
+		return nil, fmt.Errorf("invalid %s %q for activated sharding", OPT_SHARD_ID, id)
+	}
+	prefix, _ := c.GetStringOption(OPT_SHARD_LEASE_PREFIX)
+	if prefix == "" {
+		return nil, fmt.Errorf("missing %s for activated sharding", OPT_SHARD_LEASE_PREFIX)
+	}
+	namespace, _ := c.GetStringOption(OPT_SHARD_LEASE_NAMESPACE)
+	if namespace == "" {
+		namespace = c.GetEnvironment().Namespace()
+	}
+	duration, err := c.GetDurationOption(OPT_SHARD_LEASE_DURATION)
+	if err != nil || duration < 3*time.Second {
+		return nil, fmt.Errorf("invalid %s (must be at least 3s)", OPT_SHARD_LEASE_DURATION)
+	}
+	return &ShardingConfig{
+		ID:            id,
+		LeasePrefix:   prefix,
+		Namespace:     namespace,
+		LeaseDuration: duration,
+	}, nil
+}
+
+func (this *ShardingConfig) LeaseName() string {
+	return this.LeasePrefix + "-" + shardLeaseName(this.ID)
+}
+
+var invalidLeaseNameChars = regexp.MustCompile("[^a-z0-9.-]+")
+
+func shardLeaseName(id string) string {
+	return strings.Trim(invalidLeaseNameChars.ReplaceAllString(strings.ToLower(id), "-"), "-.")
+}
+
+func defaultShardID() string {
+	if name := os.Getenv("POD_NAME"); name != "" {
+		return name
+	}
+	hostname, _ := os.Hostname()
+	return hostname
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// consistent hash ring
+
+// shardRing maps keys to shard members by consistent hashing.
+// Every member is placed several times on the ring to spread the keys evenly,
+// so that adding or removing a member only moves the keys of this member.
+type shardRing struct {
+	members utils.StringSet
+	points  []uint32
+	owners  map[uint32]string
+}
+
+func newShardRing(members ...string) *shardRing {
+	ring := &shardRing{
+		members: utils.NewStringSet(members...),
+		owners:  map[uint32]string{},
+	}
+	sorted := ring.members.AsArray()
+	sort.Strings(sorted)
+	for _, m := range sorted {
+		for i := 0; i < shardVirtualNodes; i++ {
+			p := shardHash(fmt.Sprintf("%s#%d", m, i))
+			if _, ok := ring.owners[p]; ok {
+				continue
+			}
+			ring.owners[p] = m
+			ring.points = append(ring.points, p)
+		}
+	}
+	sort.Slice(ring.points, func(i, j int) bool { return ring.points[i] < ring.points[j] })
+	return ring
+}
+
+func (this *shardRing) Members() utils.StringSet {
+	return this.members.Copy()
+}
+
+// Owner returns the member responsible for the given key or
+// an empty string if the ring has no members.
+func (this *shardRing) Owner(key string) string {
+	if len(this.points) == 0 {
+		return ""
+	}
+	h := shardHash(key)
+	i := sort.Search(len(this.points), func(i int) bool { return this.points[i] >= h })
+	if i == len(this.points) {
+		i = 0
+	}
+	return this.owners[this.points[i]]
+}
+
+func shardHash(s string) uint32 {
+	sum := sha256.Sum256([]byte(s))
+	return binary.BigEndian.Uint32(sum[:4])
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// shard membership
+
+// shards keeps the lease of the local replica alive and tracks the
+// live leases of all replicas of the shard group.
+type shards struct {
+	lock       sync.RWMutex
+	config     ShardingConfig
+	leases     coordinationclient.LeaseInterface
+	ring       *shardRing
+	validUntil time.Time
+}
+
+func newShards(client kubernetes.Interface, config ShardingConfig) *shards {
+	return &shards{
+		config: config,
+		leases: client.CoordinationV1().Leases(config.Namespace),
+		ring:   newShardRing(),
+	}
+}
+
+func (this *shards) ID() string {
+	return this.config.ID
+}
+
+// IsLocal checks whether the given key is handled by the local replica.
+// Without sharding all keys are local. If the own lease could not be renewed
+// in time, nothing is local anymore, because other replicas may already
+// have taken over.
+func (this *shards) IsLocal(key string) bool {
+	if this == nil {
+		return true
+	}
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	if time.Now().After(this.validUntil) {
+		return false
+	}
+	return this.ring.Owner(key) == this.config.ID
+}
+
+func (this *shards) Owner(key string) string {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.ring.Owner(key)
+}
+
+// Members returns the live members of the shard group.
+func (this *shards) Members() utils.StringSet {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.ring.Members()
+}
+
+// Run renews the own lease and watches the shard group until the context is done.
+// The change handler is called whenever the set of live members changes.
+func (this *shards) Run(ctx context.Context, log logger.LogContext, changed func(logger.LogContext)) {
+	period := this.config.LeaseDuration / 3
+	for {
+		select {
+		case <-ctx.Done():
+			this.release(log)
+			return
+		case <-time.After(period):
+		}
+		updated, err := this.Sync(ctx, log)
+		if err != nil {
+			log.Warnf("shard sync failed: %s", err)
+			continue
+		}
+		if updated {
+			changed(log)
+		}
+	}
+}
+
+// Sync renews the own lease and updates the hash ring from the live leases.
+// It reports whether the responsibilities of the local replica may have changed.
+func (this *shards) Sync(ctx context.Context, log logger.LogContext) (bool, error) {
+	now := time.Now()
+	if err := this.renew(ctx, now); err != nil {
+		return false, fmt.Errorf("cannot renew shard lease %s/%s: %w", this.config.Namespace, this.config.LeaseName(), err)
+	}
+
+	list, err := this.leases.List(ctx, metav1.ListOptions{LabelSelector: LabelShardGroup + "=" + this.config.LeasePrefix})
+	if err != nil {
+		return false, fmt.Errorf("cannot list shard leases: %w", err)
+	}
+	members := utils.NewStringSet(this.config.ID)
+	for _, l := range list.Items {
+		if l.Spec.HolderIdentity == nil || l.Spec.RenewTime == nil {
+			continue
+		}
+		duration := this.config.LeaseDuration
+		if l.Spec.LeaseDurationSeconds != nil {
+			duration = time.Duration(*l.Spec.LeaseDurationSeconds) * time.Second
+		}
+		if l.Spec.RenewTime.Add(duration).After(now) {
+			members.Add(*l.Spec.HolderIdentity)
+		}
+	}
+
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	expired := now.After(this.validUntil)
+	this.validUntil = now.Add(this.config.LeaseDuration)
+	if this.ring.members.Equals(members) {
+		// after an expired lease all shards have to be taken over again
+		return expired, nil
+	}
+	log.Infof("shard members changed: %s -> %s", this.ring.members, members)
+	this.ring = newShardRing(members.AsArray()...)
+	return true, nil
+}
+
+func (this *shards) renew(ctx context.Context, now time.Time) error {
+	name := this.config.LeaseName()
+	id := this.config.ID
+	seconds := int32(this.config.LeaseDuration / time.Second)
+	renew := metav1.NewMicroTime(now)
+
+	lease, err := this.leases.Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: this.config.Namespace,
+				Labels:    map[string]string{LabelShardGroup: this.config.LeasePrefix},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &id,
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &renew,
+				RenewTime:            &renew,
+			},
+		}
+		_, err = this.leases.Create(ctx, lease, metav1.CreateOptions{})
+		return err
+	}
+	if lease.Labels == nil {
+		lease.Labels = map[string]string{}
+	}
+	lease.Labels[LabelShardGroup] = this.config.LeasePrefix
+	lease.Spec.HolderIdentity = &id
+	lease.Spec.LeaseDurationSeconds = &seconds
+	lease.Spec.RenewTime = &renew
+	_, err = this.leases.Update(ctx, lease, metav1.UpdateOptions{})
+	return err
+}
+
+// release deletes the own lease to hand over the shards without waiting for its expiration.
+func (this *shards) release(log logger.LogContext) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := this.leases.Delete(ctx, this.config.LeaseName(), metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+		log.Warnf("cannot release shard lease: %s", err)
+	}
+}
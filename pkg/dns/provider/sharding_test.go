@@ -0,0 +1,129 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Shard ring", func() {
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("aws-route53:/hostedzone/Z%04d", i)
+	}
+
+	ginkgo.It("has no owner without members", func() {
+		Expect(newShardRing().Owner(keys[0])).To(Equal(""))
+	})
+
+	ginkgo.It("assigns keys independent of member order", func() {
+		r1 := newShardRing("a", "b", "c")
+		r2 := newShardRing("c", "a", "b")
+		counts := map[string]int{}
+		for _, k := range keys {
+			Expect(r1.Owner(k)).To(Equal(r2.Owner(k)))
+			counts[r1.Owner(k)]++
+		}
+		Expect(counts).To(HaveLen(3))
+		for _, c := range counts {
+			Expect(c).To(BeNumerically(">", 150))
+		}
+	})
+
+	ginkgo.It("only moves keys of added or removed members", func() {
+		r3 := newShardRing("a", "b", "c")
+		r4 := newShardRing("a", "b", "c", "d")
+		r2 := newShardRing("a", "c")
+		for _, k := range keys {
+			if o := r4.Owner(k); o != "d" {
+				Expect(o).To(Equal(r3.Owner(k)))
+			}
+			if o := r3.Owner(k); o != "b" {
+				Expect(r2.Owner(k)).To(Equal(o))
+			}
+		}
+	})
+
+	ginkgo.It("sanitizes lease names", func() {
+		cfg := ShardingConfig{ID: "Host_1/42", LeasePrefix: "dns-controller-shard"}
+		Expect(cfg.LeaseName()).To(Equal("dns-controller-shard-host-1-42"))
+	})
+})
+
+var _ = ginkgo.Describe("Shard takeover", func() {
+	log := logger.New()
+	key := func(name string) resources.ClusterObjectKey {
+		return resources.NewClusterKey("default", resources.NewGroupKind(api.GroupName, api.DNSEntryKind), "test", name)
+	}
+
+	ginkgo.It("blocks until all triggered entries are processed", func() {
+		takeover := &shardTakeover{}
+		Expect(takeover.Pending(log)).To(Equal(0))
+
+		takeover.Start(resources.NewClusterObjectKeySet(key("e1"), key("e2")))
+		Expect(takeover.Pending(log)).To(Equal(2))
+		takeover.Processed(key("e1"))
+		takeover.Processed(key("other"))
+		Expect(takeover.Pending(log)).To(Equal(1))
+		takeover.Processed(key("e2"))
+		Expect(takeover.Pending(log)).To(Equal(0))
+	})
+
+	ginkgo.It("stops blocking after the deadline", func() {
+		takeover := &shardTakeover{}
+		takeover.Start(resources.NewClusterObjectKeySet(key("e1")))
+		takeover.deadline = takeover.deadline.Add(-2 * maxShardTakeover)
+		Expect(takeover.Pending(log)).To(Equal(0))
+	})
+})
+
+var _ = ginkgo.Describe("Owner shard reports", func() {
+	types := utils.NewStringSet("aws-route53", "google-clouddns")
+
+	ginkgo.It("sums up the reports of live members", func() {
+		reports := map[string]*OwnerShardReport{
+			"a": {
+				Entries:  ProviderTypeCounts{"aws-route53": 2},
+				Adoption: &api.DNSOwnerAdoptionStatus{Pending: 1, Adopted: 3, Conflicts: []string{"b.example.com"}},
+			},
+			"b": {
+				Entries:  ProviderTypeCounts{"aws-route53": 1, "google-clouddns": 4},
+				Adoption: &api.DNSOwnerAdoptionStatus{Adopted: 2, Conflicts: []string{"a.example.com"}},
+			},
+			"gone": {
+				Entries: ProviderTypeCounts{"aws-route53": 10},
+			},
+		}
+		counts, adoption := mergeOwnerShardReports(reports, utils.NewStringSet("a", "b", "c"), types)
+		Expect(counts).To(Equal(ProviderTypeCounts{"aws-route53": 3, "google-clouddns": 4}))
+		Expect(adoption).To(Equal(&api.DNSOwnerAdoptionStatus{Pending: 1, Adopted: 5, Conflicts: []string{"a.example.com", "b.example.com"}}))
+	})
+
+	ginkgo.It("resets counts and adoption without reports", func() {
+		counts, adoption := mergeOwnerShardReports(map[string]*OwnerShardReport{}, utils.NewStringSet("a"), types)
+		Expect(counts).To(Equal(ProviderTypeCounts{"aws-route53": 0, "google-clouddns": 0}))
+		Expect(adoption).To(BeNil())
+	})
+})
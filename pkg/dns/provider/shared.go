@@ -0,0 +1,289 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"sort"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Shared record sets
+//
+// Entries in shared mode contribute their targets to a record set shared with
+// all other shared entries for the same dns name, possibly maintained by other
+// controllers using different owner ids. The values contributed per owner id
+// are tracked in the META record set by attributes "shared.<owner id>" listing
+// the contribution keys (see dns.ContributionKey) of the values.
+// The contributions of an owner id are always determined completely by the
+// entries currently contributing with this owner id, so that the values of deleted
+// entries are removed, while the values of all other owner ids are preserved.
+////////////////////////////////////////////////////////////////////////////////
+
+// sharedTargetSpec is the target spec for all entries of this controller
+// contributing to a shared record set.
+type sharedTargetSpec struct {
+	TargetSpec
+	contributions map[string]Targets
+}
+
+func (this *sharedTargetSpec) Targets() []Target {
+	var targets Targets
+	for _, list := range this.contributions {
+		for _, t := range list {
+			if !targets.Has(t) {
+				targets = append(targets, t)
+			}
+		}
+	}
+	return targets
+}
+
+// sharedDoneHandler reports the result for a shared record set to all contributing entries.
+type sharedDoneHandler []DoneHandler
+
+var _ DoneHandler = sharedDoneHandler{}
+
+func (this sharedDoneHandler) SetInvalid(err error) {
+	for _, h := range this {
+		h.SetInvalid(err)
+	}
+}
+
+func (this sharedDoneHandler) Failed(err error) {
+	for _, h := range this {
+		h.Failed(err)
+	}
+}
+
+func (this sharedDoneHandler) Throttled() {
+	for _, h := range this {
+		h.Throttled()
+	}
+}
+
+func (this sharedDoneHandler) Succeeded() {
+	for _, h := range this {
+		h.Succeeded()
+	}
+}
+
+// reconcileSharedEntries applies the merged targets of all entries contributing to
+// the shared record set of a dns name. The oldest entry not being deleted determines
+// the kind and update group of the record set.
+func (this *state) reconcileSharedEntries(logger logger.LogContext, req *zoneReconciliation, changes *ChangeModel, group EntryList) ChangeResult {
+	sort.Slice(group, func(i, j int) bool { return group[i].Before(group[j]) })
+	var primary *Entry
+	done := sharedDoneHandler{}
+	contributions := map[string]Targets{}
+	for _, e := range group {
+		done = append(done, NewStatusUpdate(logger, e, req.zone, this.GetContext()))
+		if e.IsDeleting() {
+			continue
+		}
+		if primary == nil {
+			primary = e
+		}
+		spec := e.object.GetTargetSpec(e)
+		id := changes.getOwnerId(spec)
+		contributions[id] = append(contributions[id], spec.Targets()...)
+	}
+	if primary == nil {
+		primary = group[0]
+	}
+	spec := &sharedTargetSpec{TargetSpec: primary.object.GetTargetSpec(primary), contributions: contributions}
+	logger.Infof("reconcile shared record set %s for %d entries (%d owner ids)", primary.DNSName(), len(group), len(contributions))
+	if len(contributions) == 0 {
+		return changes.Delete(primary.DNSName(), primary.ObjectName().Namespace(), primary.CreatedAt(), done, spec)
+	}
+	return changes.Apply(primary.DNSName(), primary.ObjectName().Namespace(), primary.CreatedAt(), done, spec)
+}
+
+// execShared merges the contributions of the entries of this controller into a
+// shared record set. A record set not yet shared can only be taken over, if it
+// is not owned by a foreign owner.
+func (this *ChangeModel) execShared(apply bool, view *ChangeGroup, oldset *dns.DNSSet, name, updateGroup string, createdAt time.Time, done DoneHandler, spec *sharedTargetSpec) ChangeResult {
+	if oldset != nil && !oldset.IsShared() {
+		if this.isForeignFor(oldset, spec) {
+			return this.foreignOwnerConflict(apply, len(spec.contributions) == 0, view.provider, oldset, createdAt, done, spec)
+		}
+		if apply && len(spec.contributions) > 0 {
+			this.Infof("convert entry %q to shared record set", name)
+		}
+	}
+
+	newset := this.newSharedSet(view.provider, name, updateGroup, spec.Kind(), oldset, spec.contributions)
+	mod := false
+	if oldset == nil {
+		if len(spec.contributions) > 0 {
+			if apply {
+				this.Infof("no existing shared entry found for %s", name)
+				for ty := range newset.Sets {
+					view.addCreateRequest(newset, ty, done)
+				}
+			}
+			mod = true
+		}
+	} else if len(newset.GetContributors()) == 0 {
+		if apply {
+			this.Infof("no contributions left for shared entry %q", name)
+			for ty := range oldset.Sets {
+				view.addDeleteRequest(oldset, ty, done)
+			}
+		}
+		mod = true
+	} else {
+		mod = this.addChanges(view, name, oldset, newset, apply, done)
+	}
+	if apply {
+		this.applied[name] = newset
+		if !mod && done != nil {
+			done.Succeeded()
+		}
+	}
+	return ChangeResult{Modified: mod}
+}
+
+// newSharedSet composes a shared dns set from the given contributions of this controller
+// and the contributions of all owner ids not handled by this controller found in the old set.
+// The owner of the old set is kept as long as it is still contributing.
+func (this *ChangeModel) newSharedSet(provider DNSProvider, name, updateGroup, kind string, oldset *dns.DNSSet, contributions map[string]Targets) *dns.DNSSet {
+	set := dns.NewDNSSet(name)
+	set.UpdateGroup = updateGroup
+	set.SetKind(kind)
+	set.SetMetaAttr(dns.ATTR_PREFIX, dns.TxtPrefix)
+	set.SetMetaAttr(dns.ATTR_SHARED, "true")
+
+	owners := utils.StringSet{}
+	for id, targets := range contributions {
+		keys := utils.StringSet{}
+		for _, t := range targets {
+			this.addSharedTarget(set.Sets, keys, provider, t)
+		}
+		set.SetContributor(id, keys)
+		owners.Add(id)
+	}
+	if oldset != nil && oldset.IsShared() {
+		for id, keys := range oldset.GetContributors() {
+			if _, ok := contributions[id]; ok || this.ownership.IsResponsibleFor(id) {
+				continue
+			}
+			for ty, rset := range oldset.Sets {
+				if ty == dns.RS_META {
+					continue
+				}
+				for _, r := range rset.Records {
+					if keys.Contains(dns.ContributionKey(ty, r.Value)) {
+						addSharedRecord(set.Sets, ty, r.Value, rset.TTL)
+					}
+				}
+			}
+			set.SetContributor(id, keys)
+			owners.Add(id)
+		}
+	}
+
+	if len(owners) > 0 {
+		owner := ""
+		if oldset != nil && owners.Contains(oldset.GetOwner()) {
+			owner = oldset.GetOwner()
+		} else {
+			list := owners.AsArray()
+			sort.Strings(list)
+			owner = list[0]
+		}
+		set.SetOwner(owner)
+	}
+	return set
+}
+
+// addSharedTarget adds the records for a target to a shared record set.
+// CNAME targets are always mapped to the address records cached by the CNAME
+// resolver, because they cannot be combined with the values of other contributors.
+func (this *ChangeModel) addSharedTarget(targetsets dns.RecordSets, keys utils.StringSet, provider DNSProvider, t Target) {
+	add := func(ty, value string) {
+		addSharedRecord(targetsets, ty, value, t.GetTTL())
+		keys.Add(dns.ContributionKey(ty, value))
+	}
+	if t.GetRecordType() != dns.RS_CNAME {
+		t = provider.MapTarget(t)
+		add(t.GetRecordType(), t.GetHostName())
+		return
+	}
+	addrs, ok := this.lookupHost(t.GetHostName())
+	if !ok {
+		this.Warnf("'%s' not resolved yet", t.GetHostName())
+		return
+	}
+	if addrs.Err != nil {
+		this.Errorf("cannot lookup '%s': %s", t.GetHostName(), addrs.Err)
+		return
+	}
+	for _, addr := range addrs.IPv4 {
+		add(dns.RS_A, addr)
+	}
+	for _, addr := range addrs.IPv6 {
+		add(dns.RS_AAAA, addr)
+	}
+}
+
+// addSharedRecord adds a record value once. The record set uses the minimal TTL
+// of all contributions.
+func addSharedRecord(targetsets dns.RecordSets, ty string, value string, ttl int64) {
+	rs := targetsets[ty]
+	if rs == nil {
+		targetsets[ty] = dns.NewRecordSet(ty, ttl, []*dns.Record{{Value: value}})
+		return
+	}
+	if ttl < rs.TTL {
+		rs.TTL = ttl
+	}
+	for _, r := range rs.Records {
+		if r.Value == value {
+			return
+		}
+	}
+	rs.Records = append(rs.Records, &dns.Record{Value: value})
+}
+
+// cleanupShared removes the contributions of this controller from a shared
+// dns set not applied for any entry anymore.
+func (this *ChangeGroup) cleanupShared(model *ChangeModel, s *dns.DNSSet) bool {
+	contributors := s.GetContributors()
+	own := s.IsOwnedBy(model.ownership)
+	for id := range contributors {
+		own = own || model.ownership.IsResponsibleFor(id)
+	}
+	if !own {
+		return false
+	}
+	model.Infof("found unapplied shared set '%s' -> remove own contributions", s.Name)
+	done := model.wrappedDoneHandler(s.Name, nil)
+	newset := model.newSharedSet(this.provider, s.Name, s.UpdateGroup, s.GetKind(), s, nil)
+	if len(newset.GetContributors()) == 0 {
+		for ty := range s.Sets {
+			this.addDeleteRequest(s, ty, done)
+		}
+		return true
+	}
+	return model.addChanges(this, s.Name, s, newset, true, done)
+}
@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type testOwnership struct {
+	ids utils.StringSet
+}
+
+func (this *testOwnership) IsResponsibleFor(id string) bool {
+	return this.ids.Contains(id)
+}
+
+func (this *testOwnership) GetIds() utils.StringSet {
+	return this.ids
+}
+
+type identityHandler struct {
+	DNSHandler
+}
+
+func (this *identityHandler) MapTarget(t Target) Target {
+	return t
+}
+
+var _ = ginkgo.Describe("Shared record sets", func() {
+	provider := &dnsProviderVersion{account: &DNSAccount{handler: &identityHandler{}}}
+	model := func(ids ...string) *ChangeModel {
+		return &ChangeModel{LogContext: logger.New(), ownership: &testOwnership{ids: utils.NewStringSet(ids...)}}
+	}
+	build := func(m *ChangeModel, oldset *dns.DNSSet, contributions map[string]Targets) *dns.DNSSet {
+		return m.newSharedSet(provider, "a.example.com", "default", api.DNSEntryKind, oldset, contributions)
+	}
+	a := func(values ...string) Targets {
+		targets := Targets{}
+		for _, v := range values {
+			targets = append(targets, dnsutils.NewTarget(dns.RS_A, v, 300))
+		}
+		return targets
+	}
+	values := func(set *dns.DNSSet) []string {
+		var result []string
+		for _, r := range set.Sets[dns.RS_A].Records {
+			result = append(result, r.Value)
+		}
+		return result
+	}
+
+	ginkgo.It("merges and removes contributions per owner id", func() {
+		ctrlA, ctrlB := model("a"), model("b")
+
+		set := build(ctrlA, nil, map[string]Targets{"a": a("1.1.1.1", "2.2.2.2")})
+		Expect(set.IsShared()).To(BeTrue())
+		Expect(set.GetOwner()).To(Equal("a"))
+
+		set = build(ctrlB, set, map[string]Targets{"b": a("2.2.2.2", "3.3.3.3")})
+		Expect(values(set)).To(ConsistOf("1.1.1.1", "2.2.2.2", "3.3.3.3"))
+		Expect(set.GetOwner()).To(Equal("a"))
+		Expect(set.GetContributors()).To(HaveLen(2))
+
+		set = build(ctrlA, set, map[string]Targets{"a": a("4.4.4.4")})
+		Expect(values(set)).To(ConsistOf("2.2.2.2", "3.3.3.3", "4.4.4.4"))
+
+		set = build(ctrlA, set, nil)
+		Expect(values(set)).To(ConsistOf("2.2.2.2", "3.3.3.3"))
+		Expect(set.GetOwner()).To(Equal("b"))
+		Expect(set.GetContributors()).To(HaveKey("b"))
+		Expect(set.GetContributors()).NotTo(HaveKey("a"))
+
+		set = build(ctrlB, set, nil)
+		Expect(set.GetContributors()).To(BeEmpty())
+		Expect(set.Sets[dns.RS_A]).To(BeNil())
+	})
+
+	ginkgo.It("uses the minimal ttl of all contributions", func() {
+		set := build(model("a"), nil, map[string]Targets{
+			"a": a("1.1.1.1"),
+			"b": {dnsutils.NewTarget(dns.RS_A, "1.1.1.1", 60)},
+		})
+		Expect(values(set)).To(ConsistOf("1.1.1.1"))
+		Expect(set.Sets[dns.RS_A].TTL).To(Equal(int64(60)))
+	})
+})
@@ -0,0 +1,116 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/resources/access"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Split-horizon entries
+//
+// Entries with private targets are maintained in the assigned zone and in the
+// best matching zone with the opposite visibility as additional zone. Public
+// zones use the targets and private zones the private targets of the entry.
+////////////////////////////////////////////////////////////////////////////////
+
+// privateTargets returns the private targets of a split-horizon entry.
+func privateTargets(e dnsutils.DNSSpecification) []string {
+	if entry, ok := e.(*dnsutils.DNSEntryObject); ok {
+		return entry.GetPrivateTargets()
+	}
+	return nil
+}
+
+// getSplitHorizonZone determines the hosted zone with the opposite visibility of the
+// zone assigned to a split-horizon entry. Only zones of valid providers matching the
+// provider selection are considered. Zones handled by the local replica are preferred,
+// others are marked with their shard.
+func (this *state) getSplitHorizonZone(e dnsutils.DNSSpecification, assigned *dnsHostedZone, selection *providerSelection) *additionalZone {
+	dnsname := e.GetDNSName()
+	var providers []*dnsProviderVersion
+	for _, p := range this.providerIndex.Candidates(dnsname) {
+		if p.IsValid() && p.Match(dnsname) > 0 && selection.Matches(p) &&
+			access.CheckAccessWithRealms(e, "use", p.Object(), this.realms) == nil {
+			providers = append(providers, p)
+		}
+	}
+	private := !assigned.IsPrivate()
+	zones := this.getZonesForNameWith(dnsname, func(zone *dnsHostedZone) bool {
+		return zone.IsPrivate() == private && zone.Id() != assigned.Id()
+	})
+	sort.Slice(providers, func(i, j int) bool { return providers[i].ObjectName().String() < providers[j].ObjectName().String() })
+	sort.Slice(zones, func(i, j int) bool { return zones[i].Id() < zones[j].Id() })
+	var foreign *additionalZone
+	for _, zone := range zones {
+		for _, p := range providers {
+			if p.IncludesZone(zone.Id()) {
+				z := &additionalZone{zoneid: zone.Id(), ptype: zone.ProviderType(), provider: p, private: private, shard: this.foreignShardOfZone(zone.Id())}
+				if z.IsLocal() {
+					return z
+				}
+				if foreign == nil {
+					foreign = z
+				}
+				break
+			}
+		}
+	}
+	return foreign
+}
+
+// splitHorizonTargets determines the targets for the assigned zone of an entry and
+// the targets for zones with the opposite visibility. The split targets are nil
+// for entries without private targets.
+func splitHorizonTargets(entry *EntryVersion, p *EntryPremise, spec dnsutils.DNSSpecification, targets Targets) (Targets, Targets, error) {
+	names := privateTargets(entry.object)
+	if len(names) == 0 {
+		return targets, nil, nil
+	}
+	if len(spec.GetText()) > 0 {
+		return nil, nil, fmt.Errorf("private targets cannot be combined with text")
+	}
+	private := Targets{}
+	for i, t := range names {
+		if strings.TrimSpace(t) == "" {
+			return nil, nil, fmt.Errorf("private target %d must not be empty", i+1)
+		}
+		new, err := NewHostTargetFromEntryVersion(t, entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !private.Has(new) {
+			private = append(private, new)
+		}
+	}
+	visibility := "private"
+	if p.private {
+		targets, private = private, targets
+		visibility = "public"
+	}
+	if len(private) > 1 && private[0].GetRecordType() == dns.RS_CNAME {
+		return nil, nil, fmt.Errorf("multiple CNAME targets not supported for %s zone of split-horizon entry", visibility)
+	}
+	return targets, private, nil
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+type testEntryObject struct {
+	resources.Object
+	entry *api.DNSEntry
+}
+
+func (this *testEntryObject) Data() resources.ObjectData {
+	return this.entry
+}
+
+var _ = ginkgo.Describe("Split-horizon entries", func() {
+	version := func(targets []string, privateTargets ...string) *EntryVersion {
+		entry := &api.DNSEntry{Spec: api.DNSEntrySpec{DNSName: "a.example.com", Targets: targets, PrivateTargets: privateTargets}}
+		return &EntryVersion{object: &dnsutils.DNSEntryObject{Object: &testEntryObject{entry: entry}}}
+	}
+	hosts := func(targets Targets) []string {
+		var result []string
+		for _, t := range targets {
+			result = append(result, t.GetHostName())
+		}
+		return result
+	}
+	resolve := func(v *EntryVersion, p *EntryPremise) (Targets, Targets, error) {
+		targets := Targets{}
+		for _, t := range v.object.GetTargets() {
+			target, err := NewHostTargetFromEntryVersion(t, v)
+			Expect(err).To(Succeed())
+			targets = append(targets, target)
+		}
+		return splitHorizonTargets(v, p, v.object, targets)
+	}
+
+	ginkgo.It("keeps the targets of entries without private targets", func() {
+		targets, split, err := resolve(version([]string{"1.2.3.4"}), &EntryPremise{})
+		Expect(err).To(Succeed())
+		Expect(hosts(targets)).To(ConsistOf("1.2.3.4"))
+		Expect(split).To(BeNil())
+	})
+
+	ginkgo.It("uses the private targets for private zones", func() {
+		v := version([]string{"lb.example.com"}, "10.0.0.1", "10.0.0.2")
+
+		targets, split, err := resolve(v, &EntryPremise{})
+		Expect(err).To(Succeed())
+		Expect(hosts(targets)).To(ConsistOf("lb.example.com"))
+		Expect(hosts(split)).To(ConsistOf("10.0.0.1", "10.0.0.2"))
+		Expect(split[0].GetRecordType()).To(Equal(dns.RS_A))
+
+		targets, split, err = resolve(v, &EntryPremise{private: true})
+		Expect(err).To(Succeed())
+		Expect(hosts(targets)).To(ConsistOf("10.0.0.1", "10.0.0.2"))
+		Expect(hosts(split)).To(ConsistOf("lb.example.com"))
+
+		v.targets, v.splitTargets, v.private = targets, split, true
+		Expect(hosts(v.targetsFor(&additionalZone{private: false}))).To(ConsistOf("lb.example.com"))
+		Expect(hosts(v.targetsFor(&additionalZone{private: true}))).To(ConsistOf("10.0.0.1", "10.0.0.2"))
+	})
+
+	ginkgo.It("rejects multiple CNAME targets for the additional zone", func() {
+		_, _, err := resolve(version([]string{"1.2.3.4"}, "a.internal", "b.internal"), &EntryPremise{})
+		Expect(err).To(HaveOccurred())
+	})
+})
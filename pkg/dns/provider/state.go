@@ -37,6 +37,7 @@ import (
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
 	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 )
 
@@ -52,15 +53,19 @@ func (z ZonedDNSName) String() string {
 type DNSNames map[ZonedDNSName]*Entry
 
 type zoneReconciliation struct {
-	zone      *dnsHostedZone
-	providers DNSProviders
-	entries   Entries
-	ownership dns.Ownership
-	stale     DNSNames
-	dedicated bool
-	deleting  bool
-	fhandler  FinalizerHandler
-	dnsTicker *Ticker
+	zone       *dnsHostedZone
+	providers  DNSProviders
+	entries    Entries
+	additional Entries
+	conflicts  map[resources.ObjectName]error
+	cleanups   []resources.ObjectName
+	ownership  dns.Ownership
+	stale      DNSNames
+	dedicated  bool
+	deleting   bool
+	fhandler   FinalizerHandler
+	dnsTicker  *Ticker
+	resolver   *cnameResolver
 }
 
 type setup struct {
@@ -115,9 +120,23 @@ type state struct {
 	context   Context
 	ownerresc resources.Interface
 	ownerupd  chan OwnerCounts
+	adoptupd  chan OwnerAdoptions
+
+	conflictresc resources.Interface
+	conflictupd  chan ZoneConflictReports
+	conflicts    *conflictCache
+
+	hostedzoneresc resources.Interface
+	hostedzoneupd  chan HostedZoneReports
+	hostedZones    *hostedZoneCache
 
 	secretresc resources.Interface
 
+	shards        *shards
+	takeover      shardTakeover
+	shardupd      chan OwnerShardReports
+	shardreported OwnerShardReports
+
 	classes *controller.Classes
 	config  Config
 
@@ -139,19 +158,25 @@ type state struct {
 
 	entries         Entries
 	outdated        *synchronizedEntries
+	zoneCleanups    *pendingZoneCleanups
 	blockingEntries map[resources.ObjectName]time.Time
 
 	providerRateLimiter map[resources.ObjectName]*rateLimiterData
 	prlock              sync.RWMutex
 
-	dnsnames   DNSNames
-	references *References
+	dnsnames      *dnsNameIndex
+	providerIndex *providerIndex
+	references    *References
+	resolver      *cnameResolver
+	propagation   *propagationVerifier
 
 	initialized bool
 
-	dnsTicker *Ticker
+	dnsTicker   *Ticker
+	zoneLimiter *accountZoneLimiter
 
 	providerEventListeners []ProviderEventListener
+	zoneStateListeners     []ZoneStateListener
 }
 
 type rateLimiterData struct {
@@ -160,7 +185,7 @@ type rateLimiterData struct {
 	lastAccept  atomic.Value
 }
 
-func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes *controller.Classes, config Config) *state {
+func NewDNSState(ctx Context, ownerresc, conflictresc, hostedzoneresc, secretresc resources.Interface, classes *controller.Classes, config Config) *state {
 	ctx.Infof("responsible for classes:     %s (%s)", classes, classes.Main())
 	ctx.Infof("availabled providers types   %s", config.Factory.TypeCodes())
 	ctx.Infof("enabled providers types:     %s", config.Enabled)
@@ -171,17 +196,35 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 	ctx.Infof("zone cache ttl for zones:    %v", config.CacheTTL)
 	ctx.Infof("zone cache persist dir:      %s", config.CacheDir)
 	ctx.Infof("disable zone state caching:  %t", !config.ZoneStateCaching)
+	ctx.Infof("account zone concurrency:    %d", config.AccountZoneLimit)
+	ctx.Infof("zone concurrency:            %d", config.ZoneLimit)
 	if config.RemoteAccessConfig != nil {
 		ctx.Infof("remote access server port: %d", config.RemoteAccessConfig.Port)
 	}
+	if config.Sharding != nil {
+		ctx.Infof("sharding zones with id:      %s", config.Sharding.ID)
+	}
+	if config.Propagation != nil {
+		ctx.Infof("propagation check timeout:   %s", config.Propagation.Timeout)
+	}
 
 	realms := access.RealmTypes{"use": access.NewRealmType(dns.REALM_ANNOTATION)}
 
-	return &state{
+	resolverConfig := config.Resolver
+	if resolverConfig == nil {
+		resolverConfig = defaultResolverConfig()
+	}
+	ctx.Infof("resolver nameservers:        %v", resolverConfig.Nameservers)
+
+	state := &state{
 		setup:               newSetup(),
 		classes:             classes,
 		context:             ctx,
 		ownerresc:           ownerresc,
+		conflictresc:        conflictresc,
+		conflicts:           newConflictCache(),
+		hostedzoneresc:      hostedzoneresc,
+		hostedZones:         newHostedZoneCache(),
 		secretresc:          secretresc,
 		config:              config,
 		realms:              realms,
@@ -198,11 +241,20 @@ func NewDNSState(ctx Context, ownerresc, secretresc resources.Interface, classes
 		zonePolicies:        map[string]*dnsHostedZonePolicy{},
 		entries:             Entries{},
 		outdated:            newSynchronizedEntries(),
+		zoneCleanups:        newPendingZoneCleanups(),
 		blockingEntries:     map[resources.ObjectName]time.Time{},
-		dnsnames:            map[ZonedDNSName]*Entry{},
+		dnsnames:            newDNSNameIndex(),
+		providerIndex:       newProviderIndex(),
 		references:          NewReferenceCache(),
 		providerRateLimiter: map[resources.ObjectName]*rateLimiterData{},
+		zoneLimiter:         newAccountZoneLimiter(config.AccountZoneLimit, config.ZoneLimit),
+	}
+	state.resolver = newCNAMEResolver(ctx.GetContext(), ctx.NewContext("resolver", "cname"), *resolverConfig, state.triggerKey)
+	if config.Propagation != nil {
+		state.propagation = newPropagationVerifier(ctx.GetContext(), ctx.NewContext("verifier", "propagation"),
+			*config.Propagation, state.resolver, state.TriggerHostedZone)
 	}
+	return state
 }
 
 func (this *state) IsResponsibleFor(logger logger.LogContext, obj resources.Object) bool {
@@ -211,7 +263,9 @@ func (this *state) IsResponsibleFor(logger logger.LogContext, obj resources.Obje
 
 func (this *state) Setup() error {
 	this.dnsTicker = NewTicker(this.context.GetPool(DNS_POOL).Tick)
-	this.ownerupd = startOwnerUpdater(this.context, this.ownerresc)
+	this.ownerupd, this.adoptupd = startOwnerUpdater(this.context, this.ownerresc)
+	this.conflictupd = startConflictReportUpdater(this.context, this.conflictresc)
+	this.hostedzoneupd = startHostedZoneUpdater(this.context, this.hostedzoneresc)
 	processors, err := this.context.GetIntOption(OPT_SETUP)
 	if err != nil || processors <= 0 {
 		processors = 5
@@ -233,6 +287,17 @@ func (this *state) Setup() error {
 		}
 	}
 
+	if this.config.Sharding != nil {
+		if err := this.startSharding(); err != nil {
+			return fmt.Errorf("startSharding failed with: %w", err)
+		}
+	}
+
+	this.resolver.Start()
+	if this.propagation != nil {
+		this.propagation.Start()
+	}
+
 	this.context.Infof("using %d parallel workers for initialization", processors)
 	this.setupFor(&api.DNSProvider{}, "providers", func(e resources.Object) {
 		p := dnsutils.DNSProvider(e)
@@ -253,6 +318,8 @@ func (this *state) Setup() error {
 		this.UpdateEntry(this.context.NewContext("entry", p.ObjectName().String()), p)
 	}, processors)
 
+	this.cleanupHostedZones()
+	this.cleanupConflictReports()
 	this.triggerStatistic()
 	this.initialized = true
 	this.context.Infof("setup done - starting reconciliation")
@@ -261,17 +328,49 @@ func (this *state) Setup() error {
 
 func (this *state) startRemoteAccessServer(secret *corev1.Secret) error {
 	this.context.Infof("starting RemoteAccessServer")
+	if this.config.RemoteAccessAuditEvents {
+		sinks, _ := this.config.RemoteAccessConfig.AuditSink.(audit.Sinks)
+		this.config.RemoteAccessConfig.AuditSink = append(sinks, audit.NewEventSink(this))
+	}
+	if this.config.RemoteAccessConfig.TokenSecretName != nil {
+		this.config.RemoteAccessConfig.SessionRevocationStore = &revokedSessionsStore{
+			secretresc: this.secretresc,
+			name:       this.config.RemoteAccessConfig.TokenSecretName,
+		}
+	}
+	// load all secrets before serving, the server handlers are initialized on registration
+	this.config.RemoteAccessConfig.ServerSecretProvider.UpdateSecret(secret)
+	for _, name := range []resources.ObjectName{
+		this.config.RemoteAccessConfig.PolicySecretName,
+		this.config.RemoteAccessConfig.RevocationListSecretName,
+		this.config.RemoteAccessConfig.TokenSecretName,
+		this.config.RemoteAccessConfig.LimitsSecretName,
+	} {
+		if name == nil {
+			continue
+		}
+		secret := &corev1.Secret{}
+		if _, err := this.secretresc.GetInto(name, secret); err != nil {
+			secret = nil
+			this.context.Warnf("remote access secret %s not available: %s", name, err)
+		}
+		for _, p := range this.optionalRemoteAccessSecretProviders(name) {
+			p.UpdateSecret(secret)
+		}
+	}
 	server, err := embed.StartDNSHandlerServer(this.context, this.config.RemoteAccessConfig)
 	if err != nil {
 		return err
 	}
-	this.config.RemoteAccessConfig.ServerSecretProvider.UpdateSecret(secret)
 
 	listener, ok := server.(ProviderEventListener)
 	if !ok {
 		return fmt.Errorf("cannot cast server to ProviderEventListener")
 	}
 	this.providerEventListeners = append(this.providerEventListeners, listener)
+	if zlistener, ok := server.(ZoneStateListener); ok {
+		this.zoneStateListeners = append(this.zoneStateListeners, zlistener)
+	}
 
 	return nil
 }
@@ -393,6 +492,19 @@ type providerMatch struct {
 }
 
 func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNSProvider, error) {
+	provider, fallback, _, err := this.selectProvider(e)
+	return provider, fallback, err
+}
+
+// selectProvider determines the provider with the longest domain match for an entry
+// among the providers accepted by the provider selection of the entry.
+// If there is no valid provider, a provider serving a matching hosted zone is returned
+// as fallback.
+func (this *state) selectProvider(e dnsutils.DNSSpecification) (DNSProvider, DNSProvider, *providerSelection, error) {
+	selection, err := newProviderSelection(e)
+	if err != nil {
+		return nil, nil, nil, err
+	}
 	handleMatch := func(match *providerMatch, p *dnsProviderVersion, n int, err error) error {
 		if match.match <= n {
 			err2 := access.CheckAccessWithRealms(e, "use", p.Object(), this.realms)
@@ -409,13 +521,15 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 		}
 		return err
 	}
-	var err error
 	validMatch := &providerMatch{}
 	errorMatch := &providerMatch{}
 	validMatchFallback := &providerMatch{}
-	for _, p := range this.providers {
+	for _, p := range this.providerIndex.Candidates(e.GetDNSName()) {
 		n := p.Match(e.GetDNSName())
 		if n > 0 {
+			if !selection.Accepts(p) {
+				continue
+			}
 			if p.IsValid() {
 				err = handleMatch(validMatch, p, n, err)
 			} else {
@@ -423,18 +537,21 @@ func (this *state) lookupProvider(e dnsutils.DNSSpecification) (DNSProvider, DNS
 			}
 		} else {
 			n = p.MatchZone(e.GetDNSName())
-			if n > 0 && p.IsValid() {
+			if n > 0 && p.IsValid() && selection.Accepts(p) {
 				handleMatch(validMatchFallback, p, n, nil)
 			}
 		}
 	}
 	if validMatch.found != nil {
-		return validMatch.found, nil, nil
+		return validMatch.found, nil, selection, nil
 	}
 	if errorMatch.found != nil {
-		return errorMatch.found, nil, nil
+		return errorMatch.found, nil, selection, nil
 	}
-	return nil, validMatchFallback.found, err
+	if err == nil && selection != nil && validMatchFallback.found == nil {
+		err = selection.Error(e.GetDNSName())
+	}
+	return nil, validMatchFallback.found, selection, err
 }
 
 func (this *state) GetProvider(name resources.ObjectName) DNSProvider {
@@ -478,7 +595,7 @@ func (this *state) addEntriesForZone(logger logger.LogContext, entries Entries,
 		}
 	}
 loop:
-	for dns, e := range this.dnsnames {
+	for dns, e := range this.dnsnames.ForZone(zone.Id()) {
 		if e.Kind() == api.DNSLockKind {
 			continue
 		}
@@ -501,7 +618,7 @@ loop:
 			} else if provider == nil || !provider.IncludesZone(zone.Id()) {
 				continue
 			}
-			if dns.ZoneID == zone.Id() && zone.Match(dns.DNSName) > 0 {
+			if zone.Match(dns.DNSName) > 0 {
 				for excl := range nested { // fallback if no forwarded domains are reported
 					if dnsutils.Match(dns.DNSName, excl) {
 						continue loop
@@ -510,6 +627,14 @@ loop:
 				if e.IsActive() {
 					deleting = deleting || e.IsDeleting()
 					entries[e.ObjectName()] = e
+					if e.IsShared() {
+						for _, c := range this.dnsnames.Shared(dns) {
+							if c != e && c.IsValid() && c.IsActive() {
+								deleting = deleting || c.IsDeleting()
+								entries[c.ObjectName()] = c
+							}
+						}
+					}
 				} else {
 					logger.Infof("entry %q(%s) is inactive", e.ObjectName(), e.DNSName())
 				}
@@ -554,10 +679,19 @@ func (this *state) getProviderZoneForName(hostname string, provider DNSProvider)
 
 // getZonesForName can return multiple zones in the case of private zones
 func (this *state) getZonesForName(hostname string) []*dnsHostedZone {
+	return this.getZonesForNameWith(hostname, nil)
+}
+
+// getZonesForNameWith returns the zones with the longest matching domain
+// among the zones accepted by the given filter.
+func (this *state) getZonesForNameWith(hostname string, filter func(zone *dnsHostedZone) bool) []*dnsHostedZone {
 	var found []*dnsHostedZone
 	length := 0
 loop:
 	for _, zone := range this.zones {
+		if filter != nil && !filter(zone) {
+			continue
+		}
 		name := zone.Domain()
 		if dnsutils.Match(hostname, name) {
 			for _, f := range zone.ForwardedDomains() {
@@ -97,10 +97,12 @@ func (this *state) addBlockingEntries(logger logger.LogContext, entries Entries)
 ////////////////////////////////////////////////////////////////////////////////
 
 func (this *state) UpdateEntry(logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
+	defer this.takeover.Processed(object.ClusterKey())
 	return this.HandleUpdateEntry(logger, "reconcile", object)
 }
 
 func (this *state) DeleteEntry(logger logger.LogContext, object dnsutils.DNSSpecification) reconcile.Status {
+	defer this.takeover.Processed(object.ClusterKey())
 	return this.HandleUpdateEntry(logger, "delete", object)
 }
 
@@ -150,6 +152,7 @@ func (this *state) AddEntryVersion(logger logger.LogContext, v *EntryVersion, st
 				if this.HasFinalizer(new.Object()) {
 					logger.Infof("deleting delayed until entry deleted in provider")
 					this.outdated.AddEntry(new)
+					this.addZoneCleanups(logger, new)
 					return new, reconcile.Succeeded(logger)
 				}
 			} else {
@@ -203,11 +206,13 @@ func (this *state) AddEntryVersion(logger logger.LogContext, v *EntryVersion, st
 
 	dnsname := v.DNSName()
 	zonedDNSName := v.ZonedDNSName()
-	cur := this.dnsnames[zonedDNSName]
+	cur := this.dnsnames.Get(zonedDNSName)
 	if dnsname != "" {
 		if cur != nil {
 			if cur.ObjectName() != new.ObjectName() {
-				if cur.Before(new) {
+				if cur.IsShared() && new.IsShared() {
+					logger.Infof("contributing to shared record set of %q with entry %q", dnsname, cur.ObjectName())
+				} else if cur.Before(new) {
 					new.duplicate = true
 					new.modified = false
 					err := &perrs.AlreadyBusyForEntry{DNSName: dnsname, ObjectName: cur.ObjectName()}
@@ -237,28 +242,62 @@ func (this *state) AddEntryVersion(logger logger.LogContext, v *EntryVersion, st
 			}
 		}
 
-		this.dnsnames[zonedDNSName] = new
+		if new.IsShared() {
+			this.dnsnames.AddShared(zonedDNSName, new)
+			if cur == nil || cur.ObjectName() == new.ObjectName() || !cur.IsShared() {
+				this.dnsnames.Set(zonedDNSName, new)
+			}
+		} else {
+			this.dnsnames.RemoveShared(zonedDNSName, new)
+			this.demoteSharedEntries(logger, zonedDNSName)
+			this.dnsnames.Set(zonedDNSName, new)
+		}
+		this.setAdditionalZones(logger, new, new.additional.ZoneIds())
 	}
 
 	return new, status
 }
 
+// demoteSharedEntries marks all entries contributing to the shared record set
+// of a dns name as duplicates, because the name is claimed by an exclusive entry.
+func (this *state) demoteSharedEntries(logger logger.LogContext, name ZonedDNSName) {
+	for _, e := range this.dnsnames.Shared(name) {
+		e.duplicate = true
+		e.modified = false
+		this.dnsnames.RemoveShared(name, e)
+		logger.Warnf("DNS name %q claimed by exclusive entry, reschedule shared entry %q for error update", name.DNSName, e.ObjectName())
+		this.triggerKey(e.ClusterKey())
+	}
+}
+
 func (this *state) EntryPremise(e dnsutils.DNSSpecification) (*EntryPremise, error) {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
 
-	provider, fallback, err := this.lookupProvider(e)
+	provider, fallback, selection, err := this.selectProvider(e)
 	p := &EntryPremise{
 		ptypes:   this.config.Enabled,
 		provider: provider,
 		fallback: fallback,
 	}
+	if provider != nil {
+		p.selection = selection.Describe()
+	} else if err != nil && selection != nil {
+		p.selection = err.Error()
+	}
 	zone := this.getProviderZoneForName(e.GetDNSName(), provider)
 
 	if zone != nil {
 		p.ptype = zone.ProviderType()
 		p.zoneid = zone.Id()
 		p.zonedomain = zone.Domain()
+		p.private = zone.IsPrivate()
+		if len(privateTargets(e)) > 0 {
+			if z := this.getSplitHorizonZone(e, zone, selection); z != nil {
+				p.additional = append(p.additional, z)
+			}
+		}
+		p.additional = append(p.additional, this.getMirrorZones(e, provider, zone, p.additional, selection)...)
 	} else if provider != nil && !provider.IsValid() && e.BaseStatus().Zone != nil {
 		p.ptype = provider.TypeCode()
 		p.zoneid = *e.BaseStatus().Zone
@@ -289,6 +328,13 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 		}
 	}
 
+	if !this.isLocalPremise(p, object.ObjectName()) {
+		if old != nil {
+			this.releaseEntry(logger, old)
+		}
+		return reconcile.Succeeded(logger)
+	}
+
 	defer this.triggerStatistic()
 	defer this.references.NotifyHolder(this.context, object.ClusterKey())
 
@@ -318,6 +364,12 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 		if new.IsModified() && new.ZoneId() != "" {
 			this.SmartInfof(logger, "trigger zone %q", new.ZoneId())
 			this.TriggerHostedZone(new.ZoneId())
+			for _, zone := range new.additional {
+				if zone.IsLocal() {
+					this.SmartInfof(logger, "trigger additional zone %q", zone.zoneid)
+					this.TriggerHostedZone(zone.zoneid)
+				}
+			}
 		} else {
 			logger.Debugf("skipping trigger zone %q because entry not modified", new.ZoneId())
 		}
@@ -334,6 +386,7 @@ func (this *state) HandleUpdateEntry(logger logger.LogContext, op string, object
 }
 
 func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterObjectKey) reconcile.Status {
+	defer this.takeover.Processed(key)
 	this.lock.Lock()
 	defer func() {
 		this.lock.Unlock()
@@ -363,7 +416,25 @@ func (this *state) EntryDeleted(logger logger.LogContext, key resources.ClusterO
 func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 	this.smartInfof(logger, "cleanup old entry (duplicate=%t)", e.duplicate)
 	this.entries.Delete(e)
-	if this.dnsnames[e.ZonedDNSName()] == e {
+	this.resolver.Release(e.ClusterKey())
+	if this.propagation != nil {
+		this.propagation.Release(e.ClusterKey())
+	}
+	this.dnsnames.RemoveShared(e.ZonedDNSName(), e)
+	this.setAdditionalZones(logger, e, nil)
+	this.zoneCleanups.Remove(e.ObjectName())
+	if this.dnsnames.Get(e.ZonedDNSName()) == e {
+		var next *Entry
+		for _, a := range this.dnsnames.Shared(e.ZonedDNSName()) {
+			if a.Before(next) {
+				next = a
+			}
+		}
+		if next != nil {
+			logger.Infof("activate shared entry %s for %s", next.ObjectName(), next.ZonedDNSName())
+			this.dnsnames.Set(next.ZonedDNSName(), next)
+			return
+		}
 		var found *Entry
 		for _, a := range this.entries {
 			logger.Debugf("  checking %s(%s): dup:%t", a.ObjectName(), a.ZonedDNSName(), a.duplicate)
@@ -380,7 +451,7 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 		if found == nil {
 			logger.Infof("no duplicate found to reactivate")
 		} else {
-			old := this.dnsnames[found.ZonedDNSName()]
+			old := this.dnsnames.Get(found.ZonedDNSName())
 			msg := ""
 			if old != nil {
 				msg = fmt.Sprintf("reactivate duplicate for %s: %s replacing %s", found.ZonedDNSName(), found.ObjectName(), e.ObjectName())
@@ -389,8 +460,15 @@ func (this *state) cleanupEntry(logger logger.LogContext, e *Entry) {
 			}
 			logger.Info(msg)
 			found.Trigger(nil)
+			if found.IsShared() {
+				for _, a := range this.entries {
+					if a != found && a.duplicate && a.IsShared() && a.ZonedDNSName() == e.ZonedDNSName() {
+						a.Trigger(nil)
+					}
+				}
+			}
 		}
-		delete(this.dnsnames, e.ZonedDNSName())
+		this.dnsnames.Delete(e.ZonedDNSName())
 	}
 }
 
@@ -420,13 +498,16 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 	owned := true
 	ok := true
 	ownedMsg := ""
+	ownership := metav1.ConditionTrue
 	if len(rs) != 0 {
+		lockObj := entry.object.(*dnsutils.DNSLockObject)
 		lockID := rs.GetAttr(dns.ATTR_LOCKID)
 		timestamp := rs.GetAttr(dns.ATTR_TIMESTAMP)
-		owned, ok, ownedMsg = isLockOwned(entry.object.(*dnsutils.DNSLockObject), lockID, timestamp)
+		owned, ok, ownedMsg = isLockOwned(lockObj, lockID, timestamp)
+		ownership = lockOwnership(lockObj, lockID, owned, ok)
 	}
 
-	if owned && hasLockRecordsetChanged(rs, newRS) {
+	if owned && hasRecordsetChanged(rs, newRS) {
 		err = handler.CreateOrUpdateRecordSet(logger, zone, rs, newRS)
 		if err != nil {
 			return reconcile.Delay(logger, err)
@@ -454,6 +535,9 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 
 		mod.AssureStringValue(&status.State, state)
 		mod.AssureStringPtrPtr(&status.Message, &msg)
+		// records of owned locks have been updated above
+		inSync := dnsutils.ConditionStatus(owned || !hasRecordsetChanged(rs, newRS))
+		assureLockConditions(&mod, &status.DNSBaseStatus, state, msg, ownership, inSync, entry.object.GetGeneration())
 
 		mod.AssureStringPtrPtr(&status.Zone, &premise.zoneid)
 		provider := premise.provider.ObjectName().String()
@@ -469,7 +553,48 @@ func (this *state) checkAndUpdateLock(logger logger.LogContext, entry *Entry, pr
 	return reconcile.Succeeded(logger)
 }
 
-func hasLockRecordsetChanged(old, new DedicatedRecordSet) bool {
+// assureLockConditions maintains the status conditions of a DNSLock according to its state, ownership
+// and the comparison of the lock record with the spec.
+func assureLockConditions(mod *utils.ModificationState, status *api.DNSBaseStatus, state, msg string,
+	ownership, inSync metav1.ConditionStatus, gen int64) {
+	conds := &status.Conditions
+	ready := state == api.STATE_READY
+	mod.Modify(dnsutils.AssureCondition(conds, api.ConditionReady, dnsutils.ConditionStatus(ready), state, msg, gen))
+	switch ownership {
+	case metav1.ConditionTrue:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionTrue, "Confirmed",
+			"lock owned by this object", gen))
+	case metav1.ConditionFalse:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionFalse, "ForeignLock", msg, gen))
+	default:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionOwnershipConfirmed, metav1.ConditionUnknown, state, msg, gen))
+	}
+	switch inSync {
+	case metav1.ConditionTrue:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionRecordsInSync, metav1.ConditionTrue, "InSync",
+			"dns records match lock", gen))
+	case metav1.ConditionFalse:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionRecordsInSync, metav1.ConditionFalse, "RecordsMismatch",
+			"dns records do not match lock", gen))
+	default:
+		mod.Modify(dnsutils.AssureCondition(conds, api.ConditionRecordsInSync, metav1.ConditionUnknown, state, msg, gen))
+	}
+}
+
+// lockOwnership returns the status of the OwnershipConfirmed condition for the result of isLockOwned.
+// The ownership is unknown if the lock record with the own lock id has no valid timestamp.
+func lockOwnership(obj *dnsutils.DNSLockObject, lockDNS string, owned, ok bool) metav1.ConditionStatus {
+	switch {
+	case owned:
+		return metav1.ConditionTrue
+	case ok || utils.StringValue(obj.Spec().LockId) != lockDNS:
+		return metav1.ConditionFalse
+	default:
+		return metav1.ConditionUnknown
+	}
+}
+
+func hasRecordsetChanged(old, new DedicatedRecordSet) bool {
 	if len(new) != len(old) {
 		return true
 	}
@@ -561,11 +686,14 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 	lockDNS := ""
 	attrs := map[string]string{}
 	unnamed := 0
+	inSync := metav1.ConditionUnknown
 
 	if err == nil {
 		log.Infof("found records %v", records)
+		values := utils.NewStringSet()
 		for _, r := range records {
 			r = strings.Trim(r, "\"")
+			values.Add(r)
 			fields := strings.Split(r, "=")
 			if len(fields) != 2 {
 				fields = []string{fmt.Sprintf("_%d", unnamed), r}
@@ -585,6 +713,8 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 				attrs[fields[0]] = fields[1]
 			}
 		}
+		text := e.object.(*dnsutils.DNSLockObject).GetText()
+		inSync = dnsutils.ConditionStatus(values.Equals(utils.NewStringSetByArray(text)))
 	} else {
 		log.Warnf("dns lookup failed for %q: %s", dnsName, err)
 		now := time.Now()
@@ -621,6 +751,11 @@ func (this *state) updateLockState(log logger.LogContext, dnsName string, e *Ent
 		}
 		mod.AssureStringValue(&status.State, state)
 		mod.AssureStringPtrPtr(&status.Message, &msg)
+		ownership := lockOwnership(e.object.(*dnsutils.DNSLockObject), lockDNS, owned, ok)
+		if !firstfailed.IsZero() {
+			ownership = metav1.ConditionUnknown
+		}
+		assureLockConditions(&mod, &status.DNSBaseStatus, state, msg, ownership, inSync, e.object.GetGeneration())
 		var pLockID *string
 		if lockDNS != "" {
 			pLockID = &lockDNS
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+var _ = ginkgo.Describe("DNSLock conditions", func() {
+	var (
+		lock  *api.DNSLock
+		entry *Entry
+	)
+
+	ginkgo.BeforeEach(func() {
+		lockID := "id1"
+		lock = &api.DNSLock{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "lock", Generation: 1},
+			Spec: api.DNSLockSpec{
+				DNSName:    "lock.example.com",
+				LockId:     &lockID,
+				TTL:        60,
+				Timestamp:  metav1.NewTime(time.Unix(1000, 0)),
+				Attributes: map[string]string{"owner": "a"},
+			},
+		}
+		var events []string
+		obj := dnsutils.DNSLock(&statusTestObject{data: lock, events: &events})
+		entry = &Entry{lock: dnsutils.NewTryLock(), EntryVersion: &EntryVersion{object: obj, dnsname: lock.Spec.DNSName}}
+	})
+
+	records := func(lockID, ts string, attrs ...string) []string {
+		return append([]string{fmt.Sprintf("%s=%s", dns.ATTR_LOCKID, lockID), fmt.Sprintf("%s=%s", dns.ATTR_TIMESTAMP, ts)}, attrs...)
+	}
+
+	updateLockState := func(records []string, err error) {
+		(&state{}).updateLockState(logger.New(), lock.Spec.DNSName, entry, records, err)
+	}
+
+	condition := func(ctype string) metav1.Condition {
+		c := meta.FindStatusCondition(lock.Status.Conditions, ctype)
+		Expect(c).NotTo(BeNil())
+		return *c
+	}
+
+	expectConditions := func(ready, owned, inSync metav1.ConditionStatus) {
+		Expect(condition(api.ConditionReady).Status).To(Equal(ready))
+		Expect(condition(api.ConditionOwnershipConfirmed).Status).To(Equal(owned))
+		Expect(condition(api.ConditionRecordsInSync).Status).To(Equal(inSync))
+	}
+
+	ginkgo.It("confirms an owned lock with matching records", func() {
+		updateLockState(records("id1", "1000", "owner=a"), nil)
+		expectConditions(metav1.ConditionTrue, metav1.ConditionTrue, metav1.ConditionTrue)
+	})
+
+	ginkgo.It("reports records not matching an owned lock", func() {
+		updateLockState(records("id1", "1000", "owner=b"), nil)
+		expectConditions(metav1.ConditionTrue, metav1.ConditionTrue, metav1.ConditionFalse)
+		Expect(condition(api.ConditionRecordsInSync).Reason).To(Equal("RecordsMismatch"))
+	})
+
+	ginkgo.It("reports a lock held by another lock id as not owned", func() {
+		updateLockState(records("id2", "1000", "owner=a"), nil)
+		expectConditions(metav1.ConditionFalse, metav1.ConditionFalse, metav1.ConditionFalse)
+		Expect(condition(api.ConditionOwnershipConfirmed).Reason).To(Equal("ForeignLock"))
+	})
+
+	ginkgo.It("reports a lock with newer timestamp in DNS as not owned", func() {
+		updateLockState(records("id1", "2000", "owner=a"), nil)
+		expectConditions(metav1.ConditionTrue, metav1.ConditionFalse, metav1.ConditionFalse)
+	})
+
+	ginkgo.It("reports an unknown ownership for invalid lock records", func() {
+		updateLockState(records("id1", "invalid", "owner=a"), nil)
+		expectConditions(metav1.ConditionFalse, metav1.ConditionUnknown, metav1.ConditionFalse)
+		Expect(condition(api.ConditionOwnershipConfirmed).Reason).To(Equal(api.STATE_INVALID))
+	})
+
+	ginkgo.It("reports unknown ownership and records if the lookup fails", func() {
+		updateLockState(nil, fmt.Errorf("lookup failed"))
+		expectConditions(metav1.ConditionFalse, metav1.ConditionUnknown, metav1.ConditionUnknown)
+		Expect(condition(api.ConditionRecordsInSync).Reason).To(Equal(api.STATE_STALE))
+
+		updateLockState(records("id1", "1000", "owner=a"), nil)
+		expectConditions(metav1.ConditionTrue, metav1.ConditionTrue, metav1.ConditionTrue)
+	})
+})
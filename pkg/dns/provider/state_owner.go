@@ -19,6 +19,7 @@ package provider
 
 import (
 	"fmt"
+	"reflect"
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
@@ -82,6 +83,7 @@ func (this *state) UpdateOwner(logger logger.LogContext, owner *dnsutils.DNSOwne
 	}
 	this.lock.Lock()
 	changed, active := this.ownerCache.UpdateOwner(owner)
+	adopted := this.ownerCache.UpdateAdoptions(OwnerName(owner.GetName()), owner.IsActive(), owner.GetAdoptOwnerIds())
 	this.lock.Unlock()
 	logger.Infof("update: owner ids %s", delta(owner, changed, active))
 	logger.Debugf("       active owner ids %s", active)
@@ -89,6 +91,11 @@ func (this *state) UpdateOwner(logger logger.LogContext, owner *dnsutils.DNSOwne
 		this.TriggerEntriesByOwner(logger, changed)
 		this.TriggerHostedZonesByChangedOwners(logger, changed)
 	}
+	if len(adopted) > 0 {
+		logger.Infof("update: changed adopted owner ids %s", adopted)
+		this.TriggerEntriesByOwner(logger, utils.NewStringSet(owner.GetOwnerId()))
+		this.TriggerHostedZonesByChangedOwners(logger, adopted)
+	}
 	if statusActive := owner.Status().Active; statusActive == nil || *statusActive != owner.IsActive() {
 		isActive := owner.IsActive()
 		owner.Status().Active = &isActive
@@ -97,12 +104,16 @@ func (this *state) UpdateOwner(logger logger.LogContext, owner *dnsutils.DNSOwne
 			return reconcile.DelayOnError(logger, fmt.Errorf("cannot update status of %s: %w", owner.ObjectName(), err))
 		}
 	}
+	if this.IsSharded() && !setup {
+		this.aggregateOwnerStatus(logger, owner)
+	}
 	return reconcile.Succeeded(logger)
 }
 
 func (this *state) OwnerDeleted(logger logger.LogContext, key resources.ObjectKey) reconcile.Status {
 	this.lock.Lock()
 	changed, active := this.ownerCache.DeleteOwner(key)
+	adopted := this.ownerCache.UpdateAdoptions(OwnerName(key.Name()), false, nil)
 	this.lock.Unlock()
 	logger.Infof("delete: changed owner ids %s", changed)
 	logger.Debugf("       active owner ids %s", active)
@@ -110,6 +121,10 @@ func (this *state) OwnerDeleted(logger logger.LogContext, key resources.ObjectKe
 		this.TriggerEntriesByOwner(logger, changed)
 		this.TriggerHostedZonesByChangedOwners(logger, changed)
 	}
+	if len(adopted) > 0 {
+		logger.Infof("delete: changed adopted owner ids %s", adopted)
+		this.TriggerHostedZonesByChangedOwners(logger, adopted)
+	}
 	return reconcile.Succeeded(logger)
 }
 
@@ -122,19 +137,29 @@ func (this *state) UpdateOwnerCounts(log logger.LogContext) {
 	this.UpdateStatistic(statistic)
 	types := this.GetHandlerFactory().TypeCodes()
 	metrics.UpdateOwnerStatistic(statistic, types)
+	if this.IsSharded() {
+		// counts and adoptions of a single shard are incomplete and aggregated by the owner reconciliation
+		this.reportOwnerShard(log, this.ownerCache.GetShardReports(statistic.Owners, types))
+		return
+	}
 	changes := this.ownerCache.UpdateCountsWith(statistic.Owners, types)
 	if len(changes) > 0 {
 		log.Infof("found %d changes for owner usages", len(changes))
 		this.ownerupd <- changes
 	}
+	if adoptions := this.ownerCache.UpdateAdoptionStatus(); len(adoptions) > 0 {
+		log.Infof("found %d changes for owner adoptions", len(adoptions))
+		this.adoptupd <- adoptions
+	}
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 
-func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCounts {
+func startOwnerUpdater(ctx Context, ownerresc resources.Interface) (chan OwnerCounts, chan OwnerAdoptions) {
 	log := ctx.AddIndent("updater: ")
 
 	requests := make(chan OwnerCounts, 2)
+	adoptions := make(chan OwnerAdoptions, 2)
 	go func() {
 		log.Infof("starting owner count updater")
 		for {
@@ -178,8 +203,27 @@ func startOwnerUpdater(ctx Context, ownerresc resources.Interface) chan OwnerCou
 						log.Errorf("update failed: %s", err)
 					}
 				}
+			case changes := <-adoptions:
+				log.Infof("starting owner adoption update for %d changes", len(changes))
+				for n, status := range changes {
+					log.Infof("  updating owner adoption status for %s", n)
+					_, _, err := ownerresc.ModifyStatusByName(resources.NewObjectName(string(n)), func(data resources.ObjectData) (bool, error) {
+						owner, ok := data.(*v1alpha1.DNSOwner)
+						if !ok {
+							return false, fmt.Errorf("invalid owner object type %T", data)
+						}
+						if reflect.DeepEqual(owner.Status.Adoption, status) {
+							return false, nil
+						}
+						owner.Status.Adoption = status
+						return true, nil
+					})
+					if err != nil {
+						log.Errorf("update failed: %s", err)
+					}
+				}
 			}
 		}
 	}()
-	return requests
+	return requests, adoptions
 }
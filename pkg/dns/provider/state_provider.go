@@ -79,6 +79,7 @@ func (this *state) _UpdateLocalProvider(logger logger.LogContext, obj *dnsutils.
 	regmod, regerr := this.registerSecret(logger, new.secret, new)
 
 	this.providers[new.ObjectName()] = new
+	this.providerIndex.Set(new)
 	mod := this.updateZones(logger, last, new)
 	if !status.IsSucceeded() {
 		this.informProviderRemoved(logger, new.ObjectName())
@@ -230,6 +231,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 	if cur != nil {
 		this.deleting[pname] = cur
 		delete(this.providers, pname)
+		this.providerIndex.Delete(pname)
 	} else {
 		cur = this.deleting[pname]
 	}
@@ -253,6 +255,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 						deleting:  false,
 						fhandler:  this.context,
 						ownership: this.ownerCache,
+						resolver:  this.resolver,
 					})
 					if !done {
 						return reconcile.Delay(logger, fmt.Errorf("zone reconcilation busy -> delay deletion"))
@@ -277,7 +280,7 @@ func (this *state) removeLocalProvider(logger logger.LogContext, obj *dnsutils.D
 		}
 		logger.Infof("zone cleanup done -> trigger entries")
 		for _, e := range this.entries {
-			if e.providername == pname {
+			if e.providername == pname || e.additional.HasProvider(pname) {
 				this.TriggerEntry(logger, e)
 			}
 		}
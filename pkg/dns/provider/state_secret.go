@@ -23,6 +23,7 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
@@ -37,6 +38,9 @@ func (this *state) UpdateSecret(logger logger.LogContext, obj resources.Object)
 		obj.ObjectName().Namespace() == this.config.RemoteAccessConfig.SecretName.Namespace() {
 		this.config.RemoteAccessConfig.ServerSecretProvider.UpdateSecret(obj.Data().(*corev1.Secret))
 	}
+	for _, p := range this.optionalRemoteAccessSecretProviders(obj.ObjectName()) {
+		p.UpdateSecret(obj.Data().(*corev1.Secret))
+	}
 
 	providers := this.GetSecretUsage(obj.ObjectName())
 	if providers == nil || len(providers) == 0 {
@@ -52,6 +56,37 @@ func (this *state) UpdateSecret(logger logger.LogContext, obj resources.Object)
 	return reconcile.Succeeded(logger)
 }
 
+func (this *state) SecretDeleted(logger logger.LogContext, key resources.ObjectKey) reconcile.Status {
+	for _, p := range this.optionalRemoteAccessSecretProviders(key.ObjectName()) {
+		logger.Warnf("remote access secret %s deleted", key.ObjectName())
+		p.UpdateSecret(nil)
+	}
+	return reconcile.Succeeded(logger)
+}
+
+// optionalRemoteAccessSecretProviders returns the providers for the optional secrets
+// of the remote access server (authorization policy, revocation list, token key and client limits) with the given name.
+func (this *state) optionalRemoteAccessSecretProviders(name resources.ObjectName) []embed.ServerSecretProvider {
+	cfg := this.config.RemoteAccessConfig
+	if cfg == nil {
+		return nil
+	}
+	var result []embed.ServerSecretProvider
+	if cfg.PolicySecretName != nil && cfg.PolicySecretName.String() == name.String() {
+		result = append(result, cfg.PolicySecretProvider)
+	}
+	if cfg.RevocationListSecretName != nil && cfg.RevocationListSecretName.String() == name.String() {
+		result = append(result, cfg.RevocationListSecretProvider)
+	}
+	if cfg.TokenSecretName != nil && cfg.TokenSecretName.String() == name.String() {
+		result = append(result, cfg.TokenSecretProvider)
+	}
+	if cfg.LimitsSecretName != nil && cfg.LimitsSecretName.String() == name.String() {
+		result = append(result, cfg.LimitsSecretProvider)
+	}
+	return result
+}
+
 func (this *state) GetSecretUsage(name resources.ObjectName) []resources.Object {
 	this.lock.RLock()
 	defer this.lock.RUnlock()
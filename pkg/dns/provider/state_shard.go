@@ -0,0 +1,366 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for sharding of hosted zones across controller replicas
+////////////////////////////////////////////////////////////////////////////////
+
+func (this *state) startSharding() error {
+	cfg := this.config.Sharding
+	restcfg := this.context.GetCluster(TARGET_CLUSTER).Config()
+	client, err := kubernetes.NewForConfig(&restcfg)
+	if err != nil {
+		return err
+	}
+	log := this.context.AddIndent("shards: ")
+	this.shards = newShards(client, *cfg)
+	this.shardupd = startOwnerShardUpdater(this.context, this.ownerresc, this.shards)
+	if _, err := this.shards.Sync(this.context.GetContext(), log); err != nil {
+		return err
+	}
+	log.Infof("joined shard group %q as %q (lease %s/%s)", cfg.LeasePrefix, cfg.ID, cfg.Namespace, cfg.LeaseName())
+	go this.shards.Run(this.context.GetContext(), log, this.ShardsChanged)
+	return nil
+}
+
+// IsSharded reports whether the hosted zones are distributed across several replicas.
+func (this *state) IsSharded() bool {
+	return this.shards != nil
+}
+
+func (this *state) IsLocalZone(zoneid string) bool {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.isLocalZone(zoneid)
+}
+
+func (this *state) isLocalZone(zoneid string) bool {
+	if this.shards == nil {
+		return true
+	}
+	return this.shards.IsLocal(this.shardKeyForZone(zoneid))
+}
+
+// foreignShardOfZone returns the replica responsible for a zone not handled
+// by the local replica, or an empty string for local zones.
+func (this *state) foreignShardOfZone(zoneid string) string {
+	if this.isLocalZone(zoneid) {
+		return ""
+	}
+	return this.shards.Owner(this.shardKeyForZone(zoneid))
+}
+
+func (this *state) shardKeyForZone(zoneid string) string {
+	if zone := this.zones[zoneid]; zone != nil && zone.Key() != "" {
+		return zone.Key()
+	}
+	return zoneid
+}
+
+// isLocalPremise checks whether an entry is handled by the local replica.
+// Entries are handled by the replica responsible for their zone. Entries without
+// zone are distributed by their object name to report their status only once.
+func (this *state) isLocalPremise(p *EntryPremise, name resources.ObjectName) bool {
+	if this.shards == nil {
+		return true
+	}
+	if p.zoneid != "" {
+		return this.IsLocalZone(p.zoneid)
+	}
+	return this.shards.IsLocal(name.String())
+}
+
+// releaseEntry drops an entry handled by another replica from the local state.
+func (this *state) releaseEntry(logger logger.LogContext, e *Entry) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if this.entries[e.ObjectName()] != e {
+		return
+	}
+	logger.Infof("entry %s (%s) is handled by shard %q -> release", e.ObjectName(), e.ZonedDNSName(), this.shards.Owner(this.shardKeyForZone(e.ZoneId())))
+	delete(this.blockingEntries, e.ObjectName())
+	this.cleanupEntry(logger, e)
+}
+
+// ShardsChanged rebalances the local state after the members of the shard group
+// have changed. Entries of zones moved to other replicas are released and all
+// entries and local zones are triggered to take over newly assigned zones.
+// The reconciliation of the local zones is blocked until the triggered entries
+// have been processed, because entries of other zones may have been released
+// meanwhile (for example while the own lease was expired). Otherwise, the
+// records of the not yet known entries would be deleted as orphans.
+func (this *state) ShardsChanged(logger logger.LogContext) {
+	this.lock.Lock()
+	released := 0
+	for _, e := range this.entries {
+		if e.ZoneId() != "" && !this.isLocalZone(e.ZoneId()) {
+			delete(this.blockingEntries, e.ObjectName())
+			this.cleanupEntry(logger, e)
+			released++
+		}
+	}
+	zones := []string{}
+	for zoneid := range this.zones {
+		if this.isLocalZone(zoneid) {
+			zones = append(zones, zoneid)
+		}
+	}
+	this.lock.Unlock()
+
+	logger.Infof("rebalancing shards: released %d entries, %d local zones", released, len(zones))
+	pending := resources.ClusterObjectKeySet{}
+	for _, obj := range []runtime.Object{&api.DNSEntry{}, &api.DNSLock{}} {
+		keys, err := this.responsibleKeysOf(obj)
+		if err != nil {
+			logger.Warnf("%s", err)
+		}
+		pending.AddSet(keys)
+	}
+	this.takeover.Start(pending)
+	for key := range pending {
+		_ = this.context.EnqueueKey(key)
+	}
+	for _, zoneid := range zones {
+		this.TriggerHostedZone(zoneid)
+	}
+	// the owner status may have to be aggregated and hosted zone requests
+	// may have to be handled by another shard now
+	for _, obj := range []runtime.Object{&api.DNSOwner{}, &api.DNSHostedZoneRequest{}} {
+		keys, err := this.responsibleKeysOf(obj)
+		if err != nil {
+			logger.Warnf("%s", err)
+		}
+		for key := range keys {
+			_ = this.context.EnqueueKey(key)
+		}
+	}
+}
+
+func (this *state) responsibleKeysOf(obj runtime.Object) (resources.ClusterObjectKeySet, error) {
+	keys := resources.ClusterObjectKeySet{}
+	res, err := this.context.GetByExample(obj)
+	if err != nil {
+		return keys, err
+	}
+	list, err := res.ListCached(labels.Everything())
+	if err != nil {
+		return keys, fmt.Errorf("cannot list %s: %w", res.GroupKind(), err)
+	}
+	for _, o := range list {
+		if this.IsResponsibleFor(this.context, o) {
+			keys.Add(o.ClusterKey())
+		}
+	}
+	return keys, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+
+const maxShardTakeover = 10 * time.Minute
+
+// shardTakeover keeps track of the entries triggered by a shard rebalancing,
+// which have not been processed yet.
+type shardTakeover struct {
+	lock     sync.Mutex
+	pending  resources.ClusterObjectKeySet
+	deadline time.Time
+}
+
+func (this *shardTakeover) Start(keys resources.ClusterObjectKeySet) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	this.pending = keys
+	this.deadline = time.Now().Add(maxShardTakeover)
+}
+
+func (this *shardTakeover) Processed(key resources.ClusterObjectKey) {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	delete(this.pending, key)
+}
+
+// Pending returns the number of entries still to be processed for the
+// last shard rebalancing.
+func (this *shardTakeover) Pending(logger logger.LogContext) int {
+	this.lock.Lock()
+	defer this.lock.Unlock()
+	if len(this.pending) > 0 && time.Now().After(this.deadline) {
+		// should never happen, avoid blocking forever
+		logger.Warnf("dropping %d pending entries of shard rebalancing because pending longer than %fm", len(this.pending), maxShardTakeover.Minutes())
+		this.pending = nil
+	}
+	return len(this.pending)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// owner status aggregation for sharding
+////////////////////////////////////////////////////////////////////////////////
+
+// AnnotationShardReports contains the parts of the owner status reported by the
+// single shards as JSON map of shard ids to OwnerShardReport objects.
+const AnnotationShardReports = "dns.gardener.cloud/shard-reports"
+
+// shardKeyOwnerStatus selects the shard aggregating the owner status.
+const shardKeyOwnerStatus = "#owner-status"
+
+// reportOwnerShard sends the changed parts of the owner status seen by the local shard.
+func (this *state) reportOwnerShard(log logger.LogContext, reports OwnerShardReports) {
+	this.lock.Lock()
+	changes := OwnerShardReports{}
+	for n, r := range reports {
+		if old := this.shardreported[n]; old == nil || !reflect.DeepEqual(old, r) {
+			changes[n] = r
+		}
+	}
+	this.shardreported = reports
+	this.lock.Unlock()
+	if len(changes) > 0 {
+		log.Infof("found %d changes for owner shard reports", len(changes))
+		this.shardupd <- changes
+	}
+}
+
+// aggregateOwnerStatus lets a single replica of the shard group
+// aggregate the owner status from the reports of all shards.
+func (this *state) aggregateOwnerStatus(logger logger.LogContext, owner *dnsutils.DNSOwnerObject) {
+	if !this.shards.IsLocal(shardKeyOwnerStatus) {
+		return
+	}
+	reports, err := getOwnerShardReports(owner.Data())
+	if err != nil {
+		logger.Warnf("%s", err)
+	}
+	counts, adoption := mergeOwnerShardReports(reports, this.shards.Members(), this.GetHandlerFactory().TypeCodes())
+	name := OwnerName(owner.GetName())
+	for t, c := range counts {
+		if owner.Status().Entries.ByType[t] != c {
+			logger.Infof("aggregated owner counts %v from shards %s", counts, this.shards.Members())
+			this.ownerupd <- OwnerCounts{name: counts}
+			break
+		}
+	}
+	if !reflect.DeepEqual(owner.Status().Adoption, adoption) {
+		logger.Infof("aggregated owner adoption status from shards %s", this.shards.Members())
+		this.adoptupd <- OwnerAdoptions{name: adoption}
+	}
+}
+
+func getOwnerShardReports(data resources.ObjectData) (map[string]*OwnerShardReport, error) {
+	reports := map[string]*OwnerShardReport{}
+	value := data.GetAnnotations()[AnnotationShardReports]
+	if value == "" {
+		return reports, nil
+	}
+	if err := json.Unmarshal([]byte(value), &reports); err != nil {
+		return map[string]*OwnerShardReport{}, fmt.Errorf("invalid annotation %s: %w", AnnotationShardReports, err)
+	}
+	return reports, nil
+}
+
+// mergeOwnerShardReports sums up the reports of the given shard members.
+// The counts contain all given provider types to reset vanished ones.
+func mergeOwnerShardReports(reports map[string]*OwnerShardReport, members, types utils.StringSet) (ProviderTypeCounts, *api.DNSOwnerAdoptionStatus) {
+	counts := ProviderTypeCounts{}
+	for t := range types {
+		counts[t] = 0
+	}
+	var adoption *api.DNSOwnerAdoptionStatus
+	for id, r := range reports {
+		if r == nil || !members.Contains(id) {
+			continue
+		}
+		for t, c := range r.Entries {
+			counts[t] += c
+		}
+		if r.Adoption != nil {
+			if adoption == nil {
+				adoption = &api.DNSOwnerAdoptionStatus{}
+			}
+			adoption.Pending += r.Adoption.Pending
+			adoption.Adopted += r.Adoption.Adopted
+			adoption.Conflicts = append(adoption.Conflicts, r.Adoption.Conflicts...)
+		}
+	}
+	if adoption != nil {
+		sort.Strings(adoption.Conflicts)
+	}
+	return counts, adoption
+}
+
+func startOwnerShardUpdater(ctx Context, ownerresc resources.Interface, shards *shards) chan OwnerShardReports {
+	log := ctx.AddIndent("shard updater: ")
+
+	requests := make(chan OwnerShardReports, 2)
+	go func() {
+		log.Infof("starting owner shard report updater")
+		for {
+			select {
+			case <-ctx.GetContext().Done():
+				log.Infof("stopping owner shard report updater")
+				return
+			case changes := <-requests:
+				for n, report := range changes {
+					log.Infof("  updating shard report for owner %s", n)
+					_, _, err := ownerresc.ModifyByName(resources.NewObjectName(string(n)), func(data resources.ObjectData) (bool, error) {
+						reports, err := getOwnerShardReports(data)
+						if err != nil {
+							log.Warnf("%s", err)
+						}
+						// drop reports of shards which have left the group
+						members := shards.Members()
+						for id := range reports {
+							if !members.Contains(id) {
+								delete(reports, id)
+							}
+						}
+						reports[shards.ID()] = report
+						value, err := json.Marshal(reports)
+						if err != nil {
+							return false, err
+						}
+						return resources.SetAnnotation(data, AnnotationShardReports, string(value)), nil
+					})
+					if err != nil && !errors.IsNotFound(err) {
+						log.Errorf("update failed: %s", err)
+					}
+				}
+			}
+		}
+	}()
+	return requests
+}
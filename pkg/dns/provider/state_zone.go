@@ -75,8 +75,11 @@ func (this *state) GetZoneReconcilation(logger logger.LogContext, zoneid string)
 		return next.Sub(now), hasProviders, req
 	}
 	req.entries, req.stale, req.deleting = this.addEntriesForZone(logger, nil, nil, zone)
+	req.additional, req.conflicts = this.addAdditionalEntriesForZone(logger, req.stale, zone)
+	req.cleanups = this.zoneCleanups.ForZone(zoneid)
 	req.providers = this.getProvidersForZone(zoneid)
 	req.dnsTicker = this.dnsTicker
+	req.resolver = this.resolver
 	return 0, hasProviders, req
 }
 
@@ -101,6 +104,16 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid string) reconc
 	logger.Infof("Initiate reconcilation of zone %s", zoneid)
 	defer logger.Infof("zone %s done", zoneid)
 
+	if !this.IsLocalZone(zoneid) {
+		logger.Infof("zone %s is handled by another shard -> skip", zoneid)
+		return reconcile.Succeeded(logger)
+	}
+
+	if pending := this.takeover.Pending(logger); pending > 0 {
+		logger.Infof("reconciliation of zone %s is blocked due to %d pending entry reconciliations after shard rebalancing", zoneid, pending)
+		return reconcile.Succeeded(logger).RescheduleAfter(5 * time.Second)
+	}
+
 	blockingCount := this.reconcileZoneBlockingEntries(logger)
 	if blockingCount > 0 {
 		logger.Infof("reconciliation of zone %s is blocked due to %d pending entry reconciliations", zoneid, blockingCount)
@@ -119,6 +132,12 @@ func (this *state) ReconcileZone(logger logger.LogContext, zoneid string) reconc
 		logger.Infof("too early (required delay between two reconcilations: %s) -> skip and reschedule", this.config.Delay)
 		return reconcile.Succeeded(logger).RescheduleAfter(delay)
 	}
+	accounts := req.providers.AccountHashes()
+	if !this.zoneLimiter.TryAcquire(accounts) {
+		logger.Infof("reconciling zone %q (%s) delayed, too many concurrent zone reconciliations", zoneid, req.zone.Domain())
+		return reconcile.Succeeded(logger).RescheduleAfter(5 * time.Second)
+	}
+	defer this.zoneLimiter.Release(accounts)
 	logger.Infof("precondition fulfilled for zone %s", zoneid)
 	if done, err := this.StartZoneReconcilation(logger, req); done {
 		if err != nil {
@@ -148,7 +167,7 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 	if req.zone.TestAndSetBusy() {
 		defer req.zone.Release()
 
-		list := make(EntryList, 0, len(req.stale)+len(req.entries))
+		list := make(EntryList, 0, len(req.stale)+len(req.entries)+len(req.additional))
 		for _, e := range req.entries {
 			list = append(list, e)
 		}
@@ -159,6 +178,11 @@ func (this *state) StartZoneReconcilation(logger logger.LogContext, req *zoneRec
 				logger.Errorf("???, duplicate entry in stale and entries")
 			}
 		}
+		for _, e := range req.additional {
+			if req.entries[e.ObjectName()] == nil {
+				list = append(list, e)
+			}
+		}
 		logger.Infof("locking %d entries for zone reconcilation", len(list))
 		if err := list.Lock(); err != nil {
 			logger.Warnf("locking %d entries failed: %s", len(list), err)
@@ -184,21 +208,36 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 	err := changes.Setup()
 	if err != nil {
 		req.zone.Failed()
+		this.hostedZones.Report(req.zone, nil, len(req.entries), err)
 		return err
 	}
 	req.zone.nextTrigger = 0
 	modified := false
 	var conflictErr error
+	sharedEntries := map[string]EntryList{}
 	for _, e := range req.entries {
+		if e.IsShared() {
+			sharedEntries[e.DNSName()] = append(sharedEntries[e.DNSName()], e)
+			continue
+		}
 		// TODO: err handling
 		var changeResult ChangeResult
 		spec := e.object.GetTargetSpec(e)
-		statusUpdate := NewStatusUpdate(logger, e, this.GetContext())
+		statusUpdate := NewStatusUpdate(logger, e, req.zone, this.GetContext())
 		if e.IsDeleting() {
 			changeResult = changes.Delete(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
 		} else {
-			if !e.NotRateLimited() {
+			inSync := e.isInSync()
+			if !e.NotRateLimited() || inSync {
 				changeResult = changes.Check(e.DNSName(), e.ObjectName().Namespace(), e.CreatedAt(), statusUpdate, spec)
+			}
+			if inSync && changeResult.Error == nil {
+				if this.handleDrift(logger, req.zone, e, changeResult.Modified) {
+					changes.PseudoApply(e.DNSName())
+					continue
+				}
+			}
+			if !e.NotRateLimited() {
 				if changeResult.Modified {
 					if accepted, delay := this.tryAcceptProviderRateLimiter(logger, e); !accepted {
 						req.zone.nextTrigger = delay
@@ -219,6 +258,21 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 		}
 		modified = modified || changeResult.Modified
 	}
+	for _, group := range sharedEntries {
+		changeResult := this.reconcileSharedEntries(logger, req, changes, group)
+		if changeResult.Error != nil && changeResult.Retry {
+			conflictErr = changeResult.Error
+		}
+		modified = modified || changeResult.Modified
+	}
+	for _, e := range req.additional {
+		changeResult := this.reconcileAdditionalEntry(logger, req, changes, e)
+		if changeResult.Error != nil && changeResult.Retry {
+			conflictErr = changeResult.Error
+		}
+		modified = modified || changeResult.Modified
+	}
+	this.conflicts.Report(req.zone, changes.Conflicts(), this.ownerCache)
 	modified = changes.Cleanup(logger) || modified
 	if modified {
 		err = changes.Update(logger)
@@ -230,24 +284,71 @@ func (this *state) reconcileZone(logger logger.LogContext, req *zoneReconciliati
 		if changes.IsFailed(e.DNSName()) {
 			continue
 		}
+		if this.zoneCleanups.IsPending(e.ObjectName()) {
+			logger.Infof("outdated entry %q still pending in additional zones", e.ObjectName())
+			continue
+		}
 		logger.Infof("cleanup outdated entry %q", e.ObjectName())
 		err := e.RemoveFinalizer()
 		if err == nil || errors.IsNotFound(err) {
 			this.outdated.Delete(e)
 		}
 	}
+	this.ownerCache.ReportAdoption(zoneid, changes.AdoptionProgress())
 	if err == nil {
 		req.zone.Succeeded()
+		for activezone := range this.zoneCleanups.Done(zoneid, req.cleanups) {
+			logger.Infof("additional zones cleaned up -> trigger zone %s", activezone)
+			this.triggerHostedZone(activezone)
+		}
 		err = conflictErr
 	} else {
 		req.zone.Failed()
 	}
+	records := changes.RecordSetCount()
+	this.hostedZones.Report(req.zone, &records, len(req.entries), err)
 	return err
 }
 
+// handleDrift reports differences between the zone state and an entry already in sync.
+// It returns true if the drifted records must be preserved according to the zone policy.
+func (this *state) handleDrift(logger logger.LogContext, zone *dnsHostedZone, e *Entry, drifted bool) bool {
+	reportOnly := zone.ReportDriftOnly()
+	if !drifted {
+		// also resets a drift reported before the zone policy has been changed to correct it
+		if _, err := e.ReportDrift(logger, false, ""); err != nil {
+			logger.Warnf("cannot update drift condition of %s: %s", e.ObjectName(), err)
+		}
+		return false
+	}
+	if !reportOnly {
+		logger.Warnf("records of %s modified outside of the controller -> restoring", e.ObjectName())
+		e.object.Eventf(corev1.EventTypeWarning, "drift", "records of %s modified outside of the controller, restoring", e.DNSName())
+		metrics.ReportDriftedEntry(zone.ProviderType(), zone.Id(), true)
+		return false
+	}
+	msg := fmt.Sprintf("records of %s modified outside of the controller", e.DNSName())
+	mod, err := e.ReportDrift(logger, true, msg)
+	if err != nil {
+		logger.Warnf("cannot update drift condition of %s: %s", e.ObjectName(), err)
+	}
+	if mod {
+		logger.Warnf("records of %s modified outside of the controller -> report only", e.ObjectName())
+		e.object.Eventf(corev1.EventTypeWarning, "drift", "%s (not corrected by zone policy)", msg)
+		metrics.ReportDriftedEntry(zone.ProviderType(), zone.Id(), false)
+	}
+	return true
+}
+
 func (this *state) deleteZone(zoneid string) {
 	metrics.DeleteZone(zoneid)
+	this.ownerCache.ReportAdoption(zoneid, nil)
+	this.conflicts.Delete(zoneid)
+	this.hostedZones.Delete(zoneid)
 	delete(this.zones, zoneid)
+	for activezone := range this.zoneCleanups.Done(zoneid, this.zoneCleanups.ForZone(zoneid)) {
+		this.triggerHostedZone(activezone)
+	}
 	this.triggerAllZonePolicies()
 }
 
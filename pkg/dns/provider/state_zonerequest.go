@@ -0,0 +1,332 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ *  Licensed under the Apache License, Version 2.0 (the "License");
+ *  you may not use this file except in compliance with the License.
+ *  You may obtain a copy of the License at
+ *
+ *       http://www.apache.org/licenses/LICENSE-2.0
+ *
+ *  Unless required by applicable law or agreed to in writing, software
+ *  distributed under the License is distributed on an "AS IS" BASIS,
+ *  WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ *  See the License for the specific language governing permissions and
+ *  limitations under the License.
+ */
+
+package provider
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller/reconcile"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// state handling for DNSHostedZoneRequests
+////////////////////////////////////////////////////////////////////////////////
+
+// isLocalZoneRequest checks whether a hosted zone request is handled by the local replica.
+// Requests are distributed by their object name, so that a hosted zone is created,
+// delegated and deleted by a single replica only.
+func (this *state) isLocalZoneRequest(logger logger.LogContext, req *dnsutils.DNSHostedZoneRequestObject) bool {
+	if this.shards == nil || this.shards.IsLocal(req.ObjectName().String()) {
+		return true
+	}
+	logger.Infof("hosted zone request %s is handled by shard %q", req.ObjectName(), this.shards.Owner(req.ObjectName().String()))
+	return false
+}
+
+func (this *state) UpdateZoneRequest(logger logger.LogContext, req *dnsutils.DNSHostedZoneRequestObject) reconcile.Status {
+	if !this.isLocalZoneRequest(logger, req) {
+		return reconcile.Succeeded(logger)
+	}
+	spec := req.Spec()
+	status := req.Status()
+
+	domain := dns.NormalizeHostname(spec.DomainName)
+	if domain == "" {
+		return this.zoneRequestFailed(logger, req, api.STATE_INVALID, fmt.Errorf("domainName is missing"))
+	}
+	pname := zoneRequestProviderName(req)
+	if status.ZoneID != "" {
+		if status.Provider != pname.String() {
+			return this.zoneRequestFailed(logger, req, api.STATE_INVALID, fmt.Errorf("providerRef cannot be changed after creation of hosted zone %s", status.ZoneID))
+		}
+		if status.DomainName != "" && status.DomainName != domain {
+			return this.zoneRequestFailed(logger, req, api.STATE_INVALID, fmt.Errorf("domainName cannot be changed after creation of hosted zone %s", status.ZoneID))
+		}
+	}
+
+	if err := this.SetFinalizer(req); err != nil {
+		return reconcile.Delay(logger, fmt.Errorf("cannot set finalizer: %s", err))
+	}
+
+	p := this.GetProvider(pname)
+	if p == nil || !p.IsValid() {
+		return this.zoneRequestFailed(logger, req, api.STATE_PENDING, fmt.Errorf("provider %s not found or not valid", pname))
+	}
+	zm := p.GetZoneManager()
+	if zm == nil {
+		return this.zoneRequestFailed(logger, req, api.STATE_INVALID, fmt.Errorf("provider type %s does not support the creation of hosted zones", p.TypeCode()))
+	}
+
+	if status.ZoneID == "" {
+		zoneid, err := zm.CreateZone(logger, domain, string(req.GetUID()))
+		if err != nil {
+			return this.zoneRequestFailed(logger, req, api.STATE_ERROR, fmt.Errorf("creation of hosted zone failed: %s", err))
+		}
+		// remember the zone immediately to be able to delete it later on
+		_, err = req.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+			status := &data.(*api.DNSHostedZoneRequest).Status
+			status.ZoneID = zoneid
+			status.DomainName = domain
+			status.Provider = pname.String()
+			status.ProviderType = p.TypeCode()
+			return true, nil
+		})
+		if err != nil {
+			return reconcile.Delay(logger, err)
+		}
+		status = req.Status()
+		// reconcile provider to discover the new zone
+		this.context.Enqueue(p.Object())
+	}
+
+	nameservers, err := zm.GetNameServers(status.ZoneID)
+	if err != nil {
+		return this.zoneRequestFailed(logger, req, api.STATE_ERROR, fmt.Errorf("cannot get name servers of hosted zone %s: %s", status.ZoneID, err))
+	}
+
+	parent, pp := this.lookupParentZone(domain, status.ZoneID)
+	if status.ParentZoneID != "" && (parent == nil || pp == nil || parent.Id() != status.ParentZoneID) {
+		err := this.deleteDelegation(logger, domain, status.ParentZoneID, status.ParentProvider)
+		if err != nil {
+			return this.zoneRequestFailed(logger, req, api.STATE_ERROR, err)
+		}
+	}
+
+	var parentZoneID, parentProvider, msg string
+	switch {
+	case parent != nil && pp != nil:
+		err := this.assureDelegation(logger, domain, nameservers, spec.DelegationTTL, parent, pp)
+		if err != nil {
+			return this.zoneRequestFailed(logger, req, api.STATE_ERROR, err)
+		}
+		parentZoneID = parent.Id()
+		parentProvider = pp.ObjectName().String()
+		msg = fmt.Sprintf("hosted zone delegated by parent zone %s", parentZoneID)
+	case parent != nil:
+		msg = fmt.Sprintf("hosted zone created, but parent zone %s is not served by a provider supporting NS records: delegation must be configured manually", parent.Id())
+	default:
+		msg = "hosted zone created, but no parent zone found: delegation must be configured manually"
+	}
+
+	_, err = req.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSHostedZoneRequest).Status
+		mod := &utils.ModificationState{}
+		mod.AssureStringValue(&status.State, api.STATE_READY)
+		mod.AssureStringPtrPtr(&status.Message, &msg)
+		if !reflect.DeepEqual(status.NameServers, nameservers) {
+			status.NameServers = nameservers
+			mod.Modify(true)
+		}
+		mod.AssureStringValue(&status.ParentZoneID, parentZoneID)
+		mod.AssureStringValue(&status.ParentProvider, parentProvider)
+		mod.AssureInt64Value(&status.ObservedGeneration, req.GetGeneration())
+		return mod.IsModified(), nil
+	})
+	return reconcile.DelayOnError(logger, err)
+}
+
+func (this *state) DeleteZoneRequest(logger logger.LogContext, req *dnsutils.DNSHostedZoneRequestObject) reconcile.Status {
+	if !this.isLocalZoneRequest(logger, req) {
+		return reconcile.Succeeded(logger)
+	}
+	status := req.Status()
+	if status.ZoneID != "" {
+		if n := this.CountDNSNamesForZone(status.ZoneID); n > 0 {
+			return this.zoneRequestFailed(logger, req, api.STATE_DELETING, fmt.Errorf("hosted zone %s still used by %d DNS names", status.ZoneID, n))
+		}
+		if status.ParentZoneID != "" {
+			domain := dns.NormalizeHostname(req.Spec().DomainName)
+			err := this.deleteDelegation(logger, domain, status.ParentZoneID, status.ParentProvider)
+			if err != nil {
+				return this.zoneRequestFailed(logger, req, api.STATE_ERROR, err)
+			}
+		}
+		p := this.getZoneRequestProvider(status.Provider)
+		if p == nil || !p.IsValid() || p.GetZoneManager() == nil {
+			return this.zoneRequestFailed(logger, req, api.STATE_ERROR, fmt.Errorf("provider %s not available to delete hosted zone %s", status.Provider, status.ZoneID))
+		}
+		err := p.GetZoneManager().DeleteZone(logger, status.ZoneID)
+		if err != nil {
+			return this.zoneRequestFailed(logger, req, api.STATE_ERROR, fmt.Errorf("deletion of hosted zone %s failed: %s", status.ZoneID, err))
+		}
+		this.context.Enqueue(p.Object())
+	}
+	return reconcile.DelayOnError(logger, this.RemoveFinalizer(req))
+}
+
+func (this *state) zoneRequestFailed(logger logger.LogContext, req *dnsutils.DNSHostedZoneRequestObject, state string, err error) reconcile.Status {
+	msg := err.Error()
+	_, uerr := req.ModifyStatus(func(data resources.ObjectData) (bool, error) {
+		status := &data.(*api.DNSHostedZoneRequest).Status
+		mod := &utils.ModificationState{}
+		mod.AssureStringValue(&status.State, state)
+		mod.AssureStringPtrPtr(&status.Message, &msg)
+		mod.AssureInt64Value(&status.ObservedGeneration, req.GetGeneration())
+		return mod.IsModified(), nil
+	})
+	if uerr != nil {
+		logger.Warnf("cannot update status: %s", uerr)
+	}
+	if state == api.STATE_INVALID {
+		return reconcile.Failed(logger, err)
+	}
+	return reconcile.Delay(logger, err)
+}
+
+func zoneRequestProviderName(req *dnsutils.DNSHostedZoneRequestObject) resources.ObjectName {
+	ref := req.Spec().ProviderRef
+	namespace := ref.Namespace
+	if namespace == "" {
+		namespace = req.GetNamespace()
+	}
+	return resources.NewObjectName(namespace, ref.Name)
+}
+
+func (this *state) getZoneRequestProvider(name string) DNSProvider {
+	pname, err := resources.ParseObjectName(name)
+	if err != nil {
+		return nil
+	}
+	return this.GetProvider(pname)
+}
+
+// assureDelegation creates or updates the NS records for the domain of a
+// hosted zone in its parent zone.
+func (this *state) assureDelegation(logger logger.LogContext, domain string, nameservers []string, delegationTTL *int64,
+	parent *dnsHostedZone, provider DNSProvider) error {
+	ttl := provider.DefaultTTL()
+	if delegationTTL != nil {
+		ttl = *delegationTTL
+	}
+	records := dns.Records{}
+	for _, ns := range nameservers {
+		records = append(records, &dns.Record{Value: ns})
+	}
+	newRS := FromDedicatedRecordSet(domain, dns.NewRecordSet(dns.RS_NS, ttl, records))
+
+	access := provider.GetDedicatedDNSAccess()
+	rs, err := access.GetRecordSet(parent, domain, dns.RS_NS)
+	if err != nil {
+		return fmt.Errorf("cannot get delegation in parent zone %s: %s", parent.Id(), err)
+	}
+	if hasRecordsetChanged(rs, newRS) {
+		err = access.CreateOrUpdateRecordSet(logger, parent, rs, newRS)
+		if err != nil {
+			return fmt.Errorf("cannot update delegation in parent zone %s: %s", parent.Id(), err)
+		}
+		logger.Infof("delegation for %s created or updated in parent zone %s", domain, parent.Id())
+	}
+	return nil
+}
+
+// deleteDelegation removes the NS records for the domain of a hosted zone
+// from a former parent zone.
+func (this *state) deleteDelegation(logger logger.LogContext, domain, zoneid, pname string) error {
+	provider := this.getZoneRequestProvider(pname)
+	zone := this.GetZone(zoneid)
+	if provider == nil || !provider.IsValid() || !SupportsDedicatedRecordType(provider.GetDedicatedDNSAccess(), dns.RS_NS) || zone == nil {
+		logger.Warnf("provider %s for parent zone %s not available: cannot delete delegation for %s", pname, zoneid, domain)
+		return nil
+	}
+	access := provider.GetDedicatedDNSAccess()
+	rs, err := access.GetRecordSet(zone, domain, dns.RS_NS)
+	if err != nil {
+		return fmt.Errorf("cannot get delegation in parent zone %s: %s", zoneid, err)
+	}
+	if len(rs) == 0 {
+		return nil
+	}
+	err = access.DeleteRecordSet(logger, zone, rs)
+	if err != nil {
+		return fmt.Errorf("cannot delete delegation in parent zone %s: %s", zoneid, err)
+	}
+	logger.Infof("delegation for %s deleted in parent zone %s", domain, zoneid)
+	return nil
+}
+
+// lookupParentZone finds the public zone with the longest domain containing the
+// given domain. Only this zone is used for the delegation, because resolvers never
+// query zones above it. The returned provider is nil if the zone is not served by a
+// provider able to maintain NS records. Among several zones for the parent domain,
+// zones with such a provider are preferred.
+func (this *state) lookupParentZone(domain, zoneid string) (*dnsHostedZone, DNSProvider) {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+
+	var found *dnsHostedZone
+	var provider DNSProvider
+	for _, zone := range this.zones {
+		name := zone.Domain()
+		if zone.Id() == zoneid || zone.IsPrivate() || name == domain || !dnsutils.Match(domain, name) {
+			continue
+		}
+		p := this.getDelegationProvider(zone.Id())
+		if found != nil {
+			if len(name) < len(found.Domain()) {
+				continue
+			}
+			if len(name) == len(found.Domain()) {
+				if p == nil && provider != nil {
+					continue
+				}
+				if (p == nil) == (provider == nil) && zone.Id() > found.Id() {
+					continue
+				}
+			}
+		}
+		found = zone
+		provider = p
+	}
+	return found, provider
+}
+
+func (this *state) getDelegationProvider(zoneid string) DNSProvider {
+	providers := this.getProvidersForZone(zoneid)
+	names := make([]resources.ObjectName, 0, len(providers))
+	for n := range providers {
+		names = append(names, n)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i].String() < names[j].String() })
+	for _, n := range names {
+		p := providers[n]
+		if p.IsValid() && SupportsDedicatedRecordType(p.GetDedicatedDNSAccess(), dns.RS_NS) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (this *state) GetZone(zoneid string) *dnsHostedZone {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return this.zones[zoneid]
+}
+
+func (this *state) CountDNSNamesForZone(zoneid string) int {
+	this.lock.RLock()
+	defer this.lock.RUnlock()
+	return len(this.dnsnames.ForZone(zoneid))
+}
@@ -17,9 +17,15 @@
 package provider
 
 import (
+	"fmt"
+
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	perrs "github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 )
 
 type FinalizerHandler interface {
@@ -30,14 +36,15 @@ type FinalizerHandler interface {
 type StatusUpdate struct {
 	*Entry
 	logger   logger.LogContext
+	zone     *dnsHostedZone
 	delete   bool
 	done     bool
 	fhandler FinalizerHandler
 }
 
-func NewStatusUpdate(logger logger.LogContext, e *Entry, f FinalizerHandler) DoneHandler {
+func NewStatusUpdate(logger logger.LogContext, e *Entry, zone *dnsHostedZone, f FinalizerHandler) DoneHandler {
 	//logger.Infof("request update for %s (delete=%t)", e.DNSName(), e.IsDeleting())
-	return &StatusUpdate{Entry: e, logger: logger, delete: e.IsDeleting(), fhandler: f}
+	return &StatusUpdate{Entry: e, logger: logger, zone: zone, delete: e.IsDeleting(), fhandler: f}
 }
 
 func (this *StatusUpdate) SetInvalid(err error) {
@@ -45,6 +52,9 @@ func (this *StatusUpdate) SetInvalid(err error) {
 		this.done = true
 		this.modified = false
 		this.fhandler.RemoveFinalizer(this.Entry.object)
+		if _, ok := err.(*perrs.AlreadyBusyForOwner); ok {
+			this.ownershipConflict = "ForeignOwner"
+		}
 		_, err := this.UpdateStatus(this.logger, api.STATE_INVALID, err.Error())
 		if err != nil {
 			this.logger.Errorf("cannot update: %s", err)
@@ -77,13 +87,40 @@ func (this *StatusUpdate) Succeeded() {
 		} else {
 			this.Entry.activezone = this.ZoneId()
 			this.fhandler.SetFinalizer(this.Entry.Object())
-			_, err := this.UpdateStatus(this.logger, api.STATE_READY, "dns entry active")
+			msg := "dns entry active"
+			switch state, pmsg := this.verifyPropagation(); state {
+			case propagationPending:
+				_, err := this.UpdateState(this.logger, api.STATE_PENDING, pmsg)
+				if err != nil {
+					this.logger.Errorf("cannot update: %s", err)
+				}
+				return
+			case propagationTimedOut:
+				this.logger.Warnf("%s: %s", this.ObjectName(), pmsg)
+				this.object.Eventf(corev1.EventTypeWarning, "propagation", "%s", pmsg)
+				msg = fmt.Sprintf("%s (%s)", msg, pmsg)
+			}
+			_, err := this.UpdateStatus(this.logger, api.STATE_READY, msg)
 			if err != nil {
 				this.logger.Errorf("cannot update: %s", err)
 			}
 		}
 	}
 }
+
+// verifyPropagation checks whether changed records of the entry are already served by
+// the authoritative nameservers of the zone. Entries whose records have been confirmed
+// before and entries in private zones are not verified.
+func (this *StatusUpdate) verifyPropagation() (propagationState, string) {
+	verifier := this.Entry.state.propagation
+	if verifier == nil || this.zone == nil || this.zone.IsPrivate() {
+		return propagationDone, ""
+	}
+	if _, ok := this.object.(*dnsutils.DNSEntryObject); !ok || this.isInSync() {
+		return propagationDone, ""
+	}
+	return verifier.Verify(this.ClusterKey(), this.zone, this.DNSName(), this.Targets(), this.IsShared())
+}
 func (this *StatusUpdate) Throttled() {
 	_, err := this.UpdateState(this.logger, api.STATE_PENDING, MSG_THROTTLING)
 	if err != nil {
@@ -133,6 +133,13 @@ func (this *dnsHostedZone) Policy() *dnsHostedZonePolicy {
 	return this.policy
 }
 
+// ReportDriftOnly returns true if records modified outside of the controller
+// should only be reported instead of being corrected.
+func (this *dnsHostedZone) ReportDriftOnly() bool {
+	pol := this.Policy()
+	return pol != nil && utils.StringValue(pol.spec.Policy.DriftPolicy) == dnsv1alpha1.DriftPolicyReport
+}
+
 func (this *dnsHostedZone) SetPolicy(pol *dnsHostedZonePolicy) {
 	this.lock.Lock()
 	defer this.lock.Unlock()
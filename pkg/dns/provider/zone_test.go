@@ -0,0 +1,44 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+)
+
+var _ = ginkgo.Describe("Hosted zone drift policy", func() {
+	zone := newDNSHostedZone(time.Second, NewDNSHostedZone("test", "zone1", "example.com", "", nil, false))
+
+	ginkgo.It("corrects drift without policy", func() {
+		Expect(zone.ReportDriftOnly()).To(BeFalse())
+	})
+
+	ginkgo.It("follows the drift policy of the zone policy", func() {
+		policy := api.DriftPolicyReport
+		zone.SetPolicy(newDNSHostedZonePolicy("p", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{DriftPolicy: &policy}}))
+		Expect(zone.ReportDriftOnly()).To(BeTrue())
+
+		policy = api.DriftPolicyCorrect
+		zone.SetPolicy(newDNSHostedZonePolicy("p", &api.DNSHostedZonePolicySpec{Policy: api.ZonePolicy{DriftPolicy: &policy}}))
+		Expect(zone.ReportDriftOnly()).To(BeFalse())
+	})
+})
@@ -29,6 +29,7 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
 	"k8s.io/apimachinery/pkg/runtime"
 
@@ -47,6 +48,20 @@ type ZoneCacheConfig struct {
 	zonesTTL              time.Duration
 	stateTTLGetter        StateTTLGetter
 	disableZoneStateCache bool
+	listener              ZoneCacheListener
+}
+
+// ZoneCacheListener is informed about complete reads of zone states and about
+// cached zone states being dropped.
+type ZoneCacheListener interface {
+	ZoneStateRead(zoneid string, t time.Time)
+	ZoneStateDropped(zoneid string)
+}
+
+// ZoneStateListener is informed about changes of cached zone states.
+// It is optionally implemented by a ZoneCacheListener.
+type ZoneStateListener interface {
+	ZoneStateChanged(zoneid string)
 }
 
 func NewTestZoneCacheConfig(zonesTTL, stateTTL time.Duration) *ZoneCacheConfig {
@@ -59,20 +74,40 @@ func NewTestZoneCacheConfig(zonesTTL, stateTTL time.Duration) *ZoneCacheConfig {
 func (c *ZoneCacheConfig) CopyWithDisabledZoneStateCache() *ZoneCacheConfig {
 	return &ZoneCacheConfig{context: c.context, logger: c.logger,
 		zoneID: c.zoneID, providerType: c.providerType,
-		persistDir: c.persistDir, zonesTTL: c.zonesTTL, stateTTLGetter: c.stateTTLGetter, disableZoneStateCache: true}
+		persistDir: c.persistDir, zonesTTL: c.zonesTTL, stateTTLGetter: c.stateTTLGetter, disableZoneStateCache: true,
+		listener: c.listener}
 }
 
 type ZoneCacheZoneUpdater func(cache ZoneCache) (DNSHostedZones, error)
 
 type ZoneCacheStateUpdater func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error)
 
+// ZoneCacheNamesStateUpdater reads the DNS sets for dedicated DNS names of a zone.
+// It is used to update the cached zone state incrementally instead of reading
+// the complete zone. DNS names without records must be omitted in the result.
+type ZoneCacheNamesStateUpdater func(zone DNSHostedZone, names utils.StringSet) (dns.DNSSets, error)
+
+// ZoneStateDelta describes a partial update of a zone state.
+type ZoneStateDelta struct {
+	// Names contains all DNS names covered by the delta
+	Names utils.StringSet
+	// DNSSets contains the actual DNS sets for the covered names,
+	// covered names without DNS set are deleted
+	DNSSets dns.DNSSets
+}
+
 type ZoneCache interface {
 	GetZones() (DNSHostedZones, error)
 	GetZoneState(zone DNSHostedZone) (DNSZoneState, error)
 	ApplyRequests(logctx logger.LogContext, err error, zone DNSHostedZone, reqs []*ChangeRequest)
+	// ApplyDelta updates a cached zone state with changes reported by a handler.
+	ApplyDelta(zone DNSHostedZone, delta *ZoneStateDelta)
 	GetHandlerData() HandlerData
 	Release()
 	ReportZoneStateConflict(zone DNSHostedZone, err error) bool
+	// InvalidateZones enforces reading the hosted zones again on next use,
+	// for example after a hosted zone has been created or deleted.
+	InvalidateZones()
 }
 
 type HandlerData interface {
@@ -83,7 +118,15 @@ type HandlerData interface {
 
 func NewZoneCache(config ZoneCacheConfig, metrics Metrics, handlerData HandlerData,
 	zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater) (ZoneCache, error) {
-	common := abstractZonesCache{config: config, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater}
+	return NewIncrementalZoneCache(config, metrics, handlerData, zonesUpdater, stateUpdater, nil)
+}
+
+// NewIncrementalZoneCache creates a zone cache additionally using partial reads by DNS names.
+// Instead of discarding a cached zone state on conflicts or failed requests, only the affected
+// DNS names are read again. A full read of the zone is only done after the state TTL has expired.
+func NewIncrementalZoneCache(config ZoneCacheConfig, metrics Metrics, handlerData HandlerData,
+	zonesUpdater ZoneCacheZoneUpdater, stateUpdater ZoneCacheStateUpdater, namesUpdater ZoneCacheNamesStateUpdater) (ZoneCache, error) {
+	common := abstractZonesCache{config: config, zonesUpdater: zonesUpdater, stateUpdater: stateUpdater, namesUpdater: namesUpdater}
 	if config.disableZoneStateCache {
 		cache := &onlyZonesCache{abstractZonesCache: common, handlerData: handlerData}
 		return cache, nil
@@ -172,6 +215,7 @@ type abstractZonesCache struct {
 	zonesNext    time.Time
 	zonesUpdater ZoneCacheZoneUpdater
 	stateUpdater ZoneCacheStateUpdater
+	namesUpdater ZoneCacheNamesStateUpdater
 }
 
 type onlyZonesCache struct {
@@ -188,13 +232,20 @@ func (c *onlyZonesCache) GetZones() (DNSHostedZones, error) {
 }
 
 func (c *onlyZonesCache) GetZoneState(zone DNSHostedZone) (DNSZoneState, error) {
+	start := time.Now()
 	state, err := c.stateUpdater(zone, c)
+	if err == nil && c.config.listener != nil {
+		c.config.listener.ZoneStateRead(zone.Id(), start)
+	}
 	return state, err
 }
 
 func (c *onlyZonesCache) ApplyRequests(logctx logger.LogContext, err error, zone DNSHostedZone, reqs []*ChangeRequest) {
 }
 
+func (c *onlyZonesCache) ApplyDelta(zone DNSHostedZone, delta *ZoneStateDelta) {
+}
+
 func (c *onlyZonesCache) GetHandlerData() HandlerData {
 	return c.handlerData
 }
@@ -206,6 +257,9 @@ func (c *onlyZonesCache) ReportZoneStateConflict(zone DNSHostedZone, err error)
 func (c *onlyZonesCache) Release() {
 }
 
+func (c *onlyZonesCache) InvalidateZones() {
+}
+
 type defaultZoneCache struct {
 	abstractZonesCache
 	lock     sync.Mutex
@@ -226,7 +280,10 @@ func newDefaultZoneCache(common abstractZonesCache, metrics Metrics, handlerData
 		stateTTLGetter: common.config.stateTTLGetter,
 		persistDir:     common.config.persistDir,
 		next:           map[string]updateTimestamp{},
+		refreshed:      map[string]map[string]time.Time{},
 		handlerData:    handlerData,
+		namesUpdater:   common.namesUpdater,
+		listener:       common.config.listener,
 	}
 	persist := common.config.persistDir != ""
 	cache := &defaultZoneCache{abstractZonesCache: common, logger: common.config.logger, metrics: metrics, state: state, persist: persist}
@@ -268,6 +325,12 @@ func (c *defaultZoneCache) GetZones() (DNSHostedZones, error) {
 	return c.zones, c.zonesErr
 }
 
+func (c *defaultZoneCache) InvalidateZones() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.zonesNext = time.Time{}
+}
+
 func (c *defaultZoneCache) nextBackoff() time.Duration {
 	next := c.backoffOnError*5/4 + 2*time.Second
 	maxBackoff := c.config.zonesTTL / 4
@@ -288,10 +351,19 @@ func (c *defaultZoneCache) GetZoneState(zone DNSHostedZone) (DNSZoneState, error
 		c.metrics.AddZoneRequests(zone.Id(), M_CACHED_GETZONESTATE, 1)
 	} else {
 		c.persistZone(zone)
+		if err == nil {
+			c.notifyChanged(zone)
+		}
 	}
 	return state, err
 }
 
+func (c *defaultZoneCache) notifyChanged(zone DNSHostedZone) {
+	if l, ok := c.config.listener.(ZoneStateListener); ok {
+		l.ZoneStateChanged(zone.Id())
+	}
+}
+
 func (c *defaultZoneCache) ReportZoneStateConflict(zone DNSHostedZone, err error) bool {
 	return c.state.ReportZoneStateConflict(zone, err)
 }
@@ -311,8 +383,15 @@ func (c *defaultZoneCache) ApplyRequests(logctx logger.LogContext, err error, zo
 	if err == nil {
 		c.state.ExecuteRequests(zone, reqs)
 		c.persistZone(zone)
+		c.notifyChanged(zone)
 	} else {
 		if !errors.IsThrottlingError(err) {
+			if c.state.RefreshNames(zone, requestNames(reqs)) {
+				logctx.Infof("zone cache updated for changed names because of error during ExecuteRequests")
+				c.persistZone(zone)
+				c.notifyChanged(zone)
+				return
+			}
 			logctx.Infof("zone cache discarded because of error during ExecuteRequests")
 			c.deleteZoneState(zone)
 			metrics.AddZoneCacheDiscarding(zone.ProviderType(), zone.Id())
@@ -322,6 +401,26 @@ func (c *defaultZoneCache) ApplyRequests(logctx logger.LogContext, err error, zo
 	}
 }
 
+func (c *defaultZoneCache) ApplyDelta(zone DNSHostedZone, delta *ZoneStateDelta) {
+	if c.state.ApplyDelta(zone, delta) {
+		c.persistZone(zone)
+		c.notifyChanged(zone)
+	}
+}
+
+func requestNames(reqs []*ChangeRequest) utils.StringSet {
+	names := utils.StringSet{}
+	for _, r := range reqs {
+		if r.Addition != nil {
+			names.Add(r.Addition.Name)
+		}
+		if r.Deletion != nil {
+			names.Add(r.Deletion.Name)
+		}
+	}
+	return names
+}
+
 func (c *defaultZoneCache) GetHandlerData() HandlerData {
 	return c.state.GetHandlerData()
 }
@@ -495,7 +594,10 @@ type zoneState struct {
 	stateTTLGetter StateTTLGetter
 	inMemory       *InMemory
 	next           map[string]updateTimestamp
+	refreshed      map[string]map[string]time.Time
 	handlerData    HandlerData
+	namesUpdater   ZoneCacheNamesStateUpdater
+	listener       ZoneCacheListener
 }
 
 func (s *zoneState) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (DNSZoneState, bool, error) {
@@ -509,7 +611,11 @@ func (s *zoneState) GetZoneState(zone DNSHostedZone, cache *defaultZoneCache) (D
 		state, err := cache.stateUpdater(zone, cache)
 		if err == nil {
 			s.next[zone.Id()] = updateTimestamp{start, time.Now()}
+			delete(s.refreshed, zone.Id())
 			s.inMemory.SetZone(zone, state)
+			if s.listener != nil {
+				s.listener.ZoneStateRead(zone.Id(), start)
+			}
 		} else {
 			s.deleteZoneState(zone)
 		}
@@ -528,10 +634,13 @@ func (s *zoneState) ReportZoneStateConflict(zone DNSHostedZone, err error) bool
 	if found {
 		ownerConflict, ok := err.(*errors.AlreadyBusyForOwner)
 		if ok {
-			if ownerConflict.EntryCreatedAt.After(next.updateStart) {
+			if ownerConflict.EntryCreatedAt.After(s.lastRefresh(zone, next, ownerConflict.DNSName)) {
 				// If a DNSEntry ownership is moved to another DNS controller manager (e.g. shoot recreation on another seed)
-				// the zone cache may have stale owner information. In this case the cache is invalidated
-				// if the entry is newer than the last cache refresh.
+				// the zone cache may have stale owner information. In this case the DNS name is read again
+				// or the cache is invalidated if the entry is newer than the last cache refresh.
+				if s.refreshNames(zone, utils.NewStringSet(ownerConflict.DNSName)) {
+					return true
+				}
 				s.deleteZoneState(zone)
 				return true
 			}
@@ -553,9 +662,66 @@ func (s *zoneState) ExecuteRequests(zone DNSHostedZone, reqs []*ChangeRequest) {
 		}
 	}
 
+	if err != nil && !s.refreshNames(zone, requestNames(reqs)) {
+		s.deleteZoneState(zone)
+	}
+}
+
+func (s *zoneState) lastRefresh(zone DNSHostedZone, next updateTimestamp, name string) time.Time {
+	if t, ok := s.refreshed[zone.Id()][name]; ok && t.After(next.updateStart) {
+		return t
+	}
+	return next.updateStart
+}
+
+// RefreshNames reads the given DNS names of a cached zone state again.
+// It returns false if partial reads are not supported or have failed.
+func (s *zoneState) RefreshNames(zone DNSHostedZone, names utils.StringSet) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return s.refreshNames(zone, names)
+}
+
+func (s *zoneState) refreshNames(zone DNSHostedZone, names utils.StringSet) bool {
+	if s.namesUpdater == nil || len(names) == 0 {
+		return false
+	}
+	if _, ok := s.next[zone.Id()]; !ok {
+		return false
+	}
+	start := time.Now()
+	sets, err := s.namesUpdater(zone, names)
 	if err != nil {
+		return false
+	}
+	if err := s.inMemory.UpdateDNSSets(zone.Id(), names, sets); err != nil {
+		return false
+	}
+	refreshed := s.refreshed[zone.Id()]
+	if refreshed == nil {
+		refreshed = map[string]time.Time{}
+		s.refreshed[zone.Id()] = refreshed
+	}
+	for name := range names {
+		refreshed[name] = start
+	}
+	return true
+}
+
+// ApplyDelta updates a cached zone state with a delta reported by a handler.
+func (s *zoneState) ApplyDelta(zone DNSHostedZone, delta *ZoneStateDelta) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if _, ok := s.next[zone.Id()]; !ok || delta == nil {
+		return false
+	}
+	if err := s.inMemory.UpdateDNSSets(zone.Id(), delta.Names, delta.DNSSets); err != nil {
 		s.deleteZoneState(zone)
+		return false
 	}
+	return true
 }
 
 func (s *zoneState) GetHandlerData() HandlerData {
@@ -571,7 +737,11 @@ func (s *zoneState) DeleteZoneState(zone DNSHostedZone) {
 
 func (s *zoneState) deleteZoneState(zone DNSHostedZone) {
 	delete(s.next, zone.Id())
+	delete(s.refreshed, zone.Id())
 	s.inMemory.DeleteZone(zone)
+	if s.listener != nil {
+		s.listener.ZoneStateDropped(zone.Id())
+	}
 	if s.handlerData != nil {
 		s.handlerData.DeleteZone(zone.Id())
 	}
@@ -692,6 +862,9 @@ func (s *zoneState) RestoreZone(persistentState *PersistentZoneState) DNSHostedZ
 	s.inMemory.SetZone(zone, zoneState)
 	ttl := s.stateTTLGetter(zone.Id())
 	s.next[zone.Id()] = updateTimestamp{persistentState.Valid, persistentState.Valid.Add(ttl)}
+	if s.listener != nil {
+		s.listener.ZoneStateRead(zone.Id(), persistentState.Valid)
+	}
 	if persistentState.HandlerData != nil && s.GetHandlerData() != nil {
 		_ = s.GetHandlerData().Unmarshal(zone.Id(), persistentState.HandlerData)
 	}
@@ -0,0 +1,114 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package provider
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/utils"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider/errors"
+)
+
+var _ = ginkgo.Describe("Incremental zone cache", func() {
+	zone := NewDNSHostedZone("test", "zone1", "example.com", "", nil, false)
+
+	var backend *InMemory
+	var fullReads, partialReads int
+	var cache ZoneCache
+
+	newSet := func(name, owner, value string) *dns.DNSSet {
+		set := dns.NewDNSSet(name)
+		set.SetOwner(owner)
+		set.SetRecordSet(dns.RS_A, 300, value)
+		return set
+	}
+	set := func(s *dns.DNSSet) {
+		Expect(backend.UpdateDNSSets(zone.Id(), utils.NewStringSet(s.Name), dns.DNSSets{s.Name: s})).To(Succeed())
+	}
+	get := func(name string) *dns.DNSSet {
+		state, err := cache.GetZoneState(zone)
+		Expect(err).To(BeNil())
+		return state.GetDNSSets()[name]
+	}
+
+	ginkgo.BeforeEach(func() {
+		backend = NewInMemory()
+		backend.AddZone(zone)
+		fullReads = 0
+		partialReads = 0
+		set(newSet("a.example.com", "o1", "1.1.1.1"))
+
+		zonesUpdater := func(cache ZoneCache) (DNSHostedZones, error) {
+			return DNSHostedZones{zone}, nil
+		}
+		stateUpdater := func(zone DNSHostedZone, cache ZoneCache) (DNSZoneState, error) {
+			fullReads++
+			return backend.CloneZoneState(zone)
+		}
+		namesUpdater := func(zone DNSHostedZone, names utils.StringSet) (dns.DNSSets, error) {
+			partialReads++
+			return backend.GetDNSSets(zone.Id(), names)
+		}
+		var err error
+		cache, err = NewIncrementalZoneCache(*NewTestZoneCacheConfig(time.Hour, time.Hour), &NullMetrics{}, nil, zonesUpdater, stateUpdater, namesUpdater)
+		Expect(err).To(BeNil())
+	})
+
+	ginkgo.It("reads only conflicting names again", func() {
+		Expect(get("a.example.com").GetOwner()).To(Equal("o1"))
+		set(newSet("a.example.com", "o2", "1.1.1.1"))
+
+		conflict := &errors.AlreadyBusyForOwner{DNSName: "a.example.com", EntryCreatedAt: time.Now().Add(time.Minute), Owner: "o1"}
+		Expect(cache.ReportZoneStateConflict(zone, conflict)).To(BeTrue())
+		Expect(get("a.example.com").GetOwner()).To(Equal("o2"))
+		Expect(fullReads).To(Equal(1))
+		Expect(partialReads).To(Equal(1))
+
+		conflict = &errors.AlreadyBusyForOwner{DNSName: "a.example.com", EntryCreatedAt: time.Now().Add(-time.Second), Owner: "o2"}
+		Expect(cache.ReportZoneStateConflict(zone, conflict)).To(BeFalse())
+		Expect(partialReads).To(Equal(1))
+	})
+
+	ginkgo.It("updates failed names instead of discarding the cache", func() {
+		Expect(get("a.example.com")).NotTo(BeNil())
+		b := newSet("b.example.com", "o1", "2.2.2.2")
+		set(b)
+
+		reqs := []*ChangeRequest{NewChangeRequest(R_CREATE, dns.RS_A, nil, b, nil)}
+		cache.ApplyRequests(logger.New(), fmt.Errorf("failed"), zone, reqs)
+		Expect(get("b.example.com")).NotTo(BeNil())
+		Expect(get("a.example.com")).NotTo(BeNil())
+		Expect(fullReads).To(Equal(1))
+		Expect(partialReads).To(Equal(1))
+	})
+
+	ginkgo.It("applies delta updates", func() {
+		Expect(get("a.example.com")).NotTo(BeNil())
+		cache.ApplyDelta(zone, &ZoneStateDelta{Names: utils.NewStringSet("a.example.com", "c.example.com"),
+			DNSSets: dns.DNSSets{"c.example.com": newSet("c.example.com", "o1", "3.3.3.3")}})
+		Expect(get("a.example.com")).To(BeNil())
+		Expect(get("c.example.com")).NotTo(BeNil())
+		Expect(fullReads).To(Equal(1))
+	})
+})
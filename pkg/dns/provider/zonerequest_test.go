@@ -0,0 +1,108 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package provider
+
+import (
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type dedicatedTestHandler struct {
+	DNSHandler
+	ns bool
+}
+
+func (this *dedicatedTestHandler) GetRecordSet(zone DNSHostedZone, dnsName, recordType string) (DedicatedRecordSet, error) {
+	return nil, nil
+}
+
+func (this *dedicatedTestHandler) CreateOrUpdateRecordSet(logger logger.LogContext, zone DNSHostedZone, old, new DedicatedRecordSet) error {
+	return nil
+}
+
+func (this *dedicatedTestHandler) SupportsRecordType(rtype string) bool {
+	return this.ns
+}
+
+func (this *dedicatedTestHandler) DeleteRecordSet(logger logger.LogContext, zone DNSHostedZone, rs DedicatedRecordSet) error {
+	return nil
+}
+
+var _ = ginkgo.Describe("Hosted zone requests", func() {
+	var s *state
+
+	addZone := func(id, domain string, private bool, pname resources.ObjectName) {
+		s.zones[id] = newDNSHostedZone(time.Second, NewDNSHostedZone("test", id, domain, "", nil, private))
+		s.addProviderForZone(id, pname)
+	}
+	addProvider := func(name string, handler DNSHandler) resources.ObjectName {
+		pname := resources.NewObjectName("default", name)
+		s.providers[pname] = &dnsProviderVersion{valid: true, account: &DNSAccount{handler: handler}}
+		return pname
+	}
+
+	ginkgo.BeforeEach(func() {
+		s = &state{
+			providers:     map[resources.ObjectName]*dnsProviderVersion{},
+			zones:         map[string]*dnsHostedZone{},
+			zoneproviders: map[string]resources.ObjectNameSet{},
+		}
+		dedicated := addProvider("dedicated", &dedicatedTestHandler{ns: true})
+		plain := addProvider("plain", nil)
+		addZone("parent", "example.com", false, dedicated)
+		addZone("private", "sub.example.com", true, dedicated)
+		addZone("sub", "sub.example.com", false, plain)
+		addZone("created", "a.sub.example.com", false, dedicated)
+	})
+
+	ginkgo.It("uses the closest public parent zone", func() {
+		zone, p := s.lookupParentZone("a.sub.example.com", "created")
+		Expect(zone).NotTo(BeNil())
+		Expect(zone.Id()).To(Equal("sub"))
+		Expect(p).To(BeNil())
+
+		addZone("sub", "sub.example.com", false, resources.NewObjectName("default", "dedicated"))
+		zone, p = s.lookupParentZone("a.sub.example.com", "created")
+		Expect(zone.Id()).To(Equal("sub"))
+		Expect(p).To(BeIdenticalTo(s.providers[resources.NewObjectName("default", "dedicated")]))
+	})
+
+	ginkgo.It("delegates only with providers supporting NS records", func() {
+		txtOnly := addProvider("txt-only", &dedicatedTestHandler{})
+		addZone("sub", "sub.example.com", false, txtOnly)
+		zone, p := s.lookupParentZone("a.sub.example.com", "created")
+		Expect(zone.Id()).To(Equal("sub"))
+		Expect(p).To(BeNil())
+	})
+
+	ginkgo.It("prefers a parent zone with a provider supporting NS records", func() {
+		addZone("sub2", "sub.example.com", false, resources.NewObjectName("default", "dedicated"))
+		zone, p := s.lookupParentZone("a.sub.example.com", "created")
+		Expect(zone.Id()).To(Equal("sub2"))
+		Expect(p).NotTo(BeNil())
+	})
+
+	ginkgo.It("does not use a zone of the requested domain as parent", func() {
+		zone, p := s.lookupParentZone("example.com", "")
+		Expect(zone).To(BeNil())
+		Expect(p).To(BeNil())
+	})
+})
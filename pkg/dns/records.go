@@ -139,6 +139,22 @@ func (this *RecordSet) GetAttr(name string) string {
 	return ""
 }
 
+// GetAttrsWithPrefix returns all attributes of the record set whose names start with the given prefix.
+func (this *RecordSet) GetAttrsWithPrefix(prefix string) map[string]string {
+	result := map[string]string{}
+	if this == nil || (this.Type != RS_TXT && this.Type != RS_META) {
+		return result
+	}
+	for _, r := range this.Records {
+		if strings.HasPrefix(r.Value, "\""+prefix) && strings.HasSuffix(r.Value, "\"") {
+			if i := strings.Index(r.Value, "="); i > 0 {
+				result[r.Value[1:i]] = r.Value[i+1 : len(r.Value)-1]
+			}
+		}
+	}
+	return result
+}
+
 func (this *RecordSet) SetAttr(name string, value string) {
 	prefix := newAttrKeyPrefix(name)
 	for _, r := range this.Records {
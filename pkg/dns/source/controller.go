@@ -42,6 +42,9 @@ const TARGET_CLUSTER = "target"
 const DNS_ANNOTATION = dns.ANNOTATION_GROUP + "/dnsnames"
 const TTL_ANNOTATION = dns.ANNOTATION_GROUP + "/ttl"
 const PERIOD_ANNOTATION = dns.ANNOTATION_GROUP + "/cname-lookup-interval"
+const PRIVATE_TARGETS_ANNOTATION = dns.ANNOTATION_GROUP + "/private-targets"
+const PROVIDER_ANNOTATION = dns.ANNOTATION_GROUP + "/provider"
+const PROVIDER_SELECTOR_ANNOTATION = dns.ANNOTATION_GROUP + "/provider-selector"
 const CLASS_ANNOTATION = dns.CLASS_ANNOTATION
 
 const OPT_CLASS = "dns-class"
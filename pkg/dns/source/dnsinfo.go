@@ -24,6 +24,9 @@ import (
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 )
 
 func (this *sourceReconciler) exclude(dns string) bool {
@@ -93,6 +96,31 @@ func (this *sourceReconciler) getDNSInfo(logger logger.LogContext, obj resources
 			}
 		}
 	}
+	if info.PrivateTargets == nil && annos[PRIVATE_TARGETS_ANNOTATION] != "" {
+		info.PrivateTargets = utils.StringSet{}
+		info.PrivateTargets.AddAllSplittedSelected(annos[PRIVATE_TARGETS_ANNOTATION], utils.StandardNonEmptyStringElement)
+	}
+	if info.ProviderRef == nil {
+		if a := strings.TrimSpace(annos[PROVIDER_ANNOTATION]); a != "" {
+			ref := &api.ProviderReference{Name: a}
+			if i := strings.Index(a, "/"); i >= 0 {
+				ref.Namespace, ref.Name = a[:i], a[i+1:]
+			}
+			if ref.Name == "" {
+				return info, true, fmt.Errorf("invalid provider reference %q", a)
+			}
+			info.ProviderRef = ref
+		}
+	}
+	if info.ProviderSelector == nil {
+		if a := strings.TrimSpace(annos[PROVIDER_SELECTOR_ANNOTATION]); a != "" {
+			sel, err := metav1.ParseToLabelSelector(a)
+			if err != nil {
+				return info, true, fmt.Errorf("invalid provider selector: %s", err)
+			}
+			info.ProviderSelector = sel
+		}
+	}
 	return info, true, nil
 }
 
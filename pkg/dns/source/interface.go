@@ -36,6 +36,10 @@ type DNSInfo struct {
 	Text      utils.StringSet
 	OrigRef   *v1alpha1.EntryReference
 	TargetRef *v1alpha1.EntryReference
+
+	PrivateTargets   utils.StringSet
+	ProviderRef      *v1alpha1.ProviderReference
+	ProviderSelector *metav1.LabelSelector
 }
 
 type DNSFeedback interface {
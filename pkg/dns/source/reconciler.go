@@ -35,6 +35,7 @@ import (
 	dnsutils "github.com/gardener/external-dns-management/pkg/dns/utils"
 
 	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 )
 
@@ -442,6 +443,9 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 		if info.Text != nil {
 			entry.Spec.Text = info.Text.AsArray()
 		}
+		if info.PrivateTargets != nil {
+			entry.Spec.PrivateTargets = info.PrivateTargets.AsArray()
+		}
 	}
 
 	if this.namespace == "" {
@@ -450,6 +454,8 @@ func (this *sourceReconciler) createEntryFor(logger logger.LogContext, obj resou
 		entry.Namespace = this.namespace
 	}
 	entry.Spec.TTL = info.TTL
+	entry.Spec.ProviderRef = info.ProviderRef
+	entry.Spec.ProviderSelector = info.ProviderSelector
 
 	e, _ := this.SlaveResoures()[0].Wrap(entry)
 
@@ -512,8 +518,17 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 		mod.AssureStringPtrPtr(&spec.OwnerId, p)
 		mod.AssureInt64PtrPtr(&spec.TTL, info.TTL)
 		mod.AssureInt64PtrPtr(&spec.CNameLookupInterval, info.Interval)
+		if !equality.Semantic.DeepEqual(spec.ProviderRef, info.ProviderRef) {
+			spec.ProviderRef = info.ProviderRef
+			mod.Modify(true)
+		}
+		if !equality.Semantic.DeepEqual(spec.ProviderSelector, info.ProviderSelector) {
+			spec.ProviderSelector = info.ProviderSelector
+			mod.Modify(true)
+		}
 		targets := info.Targets
 		text := info.Text
+		privateTargets := info.PrivateTargets
 
 		this.mapRef(obj, info)
 		if info.TargetRef != nil {
@@ -522,6 +537,7 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 				spec.Reference = info.TargetRef
 				targets = nil
 				text = nil
+				privateTargets = nil
 				mod.Modify(true)
 			}
 		} else {
@@ -532,6 +548,7 @@ func (this *sourceReconciler) updateEntryFor(logger logger.LogContext, obj resou
 		}
 		mod.AssureStringSet(&spec.Targets, targets)
 		mod.AssureStringSet(&spec.Text, text)
+		mod.AssureStringSet(&spec.PrivateTargets, privateTargets)
 		if mod.IsModified() {
 			logger.Infof("update entry %s", slave.ObjectName())
 		}
@@ -0,0 +1,45 @@
+/*
+ * Copyright 2019 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package utils
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Conditions", func() {
+	It("adds and updates conditions", func() {
+		var conds []metav1.Condition
+		Expect(AssureCondition(&conds, "Ready", metav1.ConditionFalse, "Pending", "waiting", 1)).To(BeTrue())
+		Expect(conds).To(HaveLen(1))
+		Expect(AssureCondition(&conds, "Ready", metav1.ConditionFalse, "Pending", "waiting", 1)).To(BeFalse())
+
+		old := conds[0].LastTransitionTime
+		Expect(AssureCondition(&conds, "Ready", metav1.ConditionFalse, "Error", "failed", 2)).To(BeTrue())
+		cond := meta.FindStatusCondition(conds, "Ready")
+		Expect(cond.Reason).To(Equal("Error"))
+		Expect(cond.ObservedGeneration).To(Equal(int64(2)))
+		Expect(cond.LastTransitionTime).To(Equal(old))
+
+		Expect(AssureCondition(&conds, "Ready", ConditionStatus(true), "Ready", "", 2)).To(BeTrue())
+		Expect(meta.IsStatusConditionTrue(conds, "Ready")).To(BeTrue())
+		Expect(conds).To(HaveLen(1))
+	})
+})
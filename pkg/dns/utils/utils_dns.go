@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 )
@@ -39,6 +40,8 @@ type DNSSpecification interface {
 	GetText() []string
 	GetCNameLookupInterval() *int64
 	GetReference() *api.EntryReference
+	GetProviderRef() *api.ProviderReference
+	GetProviderSelector() *metav1.LabelSelector
 	BaseStatus() *api.DNSBaseStatus
 
 	GetTargetSpec(TargetProvider) TargetSpec
@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 )
@@ -81,6 +82,19 @@ func (this *DNSEntryObject) GetCNameLookupInterval() *int64 {
 func (this *DNSEntryObject) GetReference() *api.EntryReference {
 	return this.DNSEntry().Spec.Reference
 }
+func (this *DNSEntryObject) GetPrivateTargets() []string {
+	return this.DNSEntry().Spec.PrivateTargets
+}
+func (this *DNSEntryObject) GetProviderRef() *api.ProviderReference {
+	return this.DNSEntry().Spec.ProviderRef
+}
+func (this *DNSEntryObject) GetProviderSelector() *metav1.LabelSelector {
+	return this.DNSEntry().Spec.ProviderSelector
+}
+
+func (this *DNSEntryObject) IsShared() bool {
+	return this.DNSEntry().Spec.Shared != nil && *this.DNSEntry().Spec.Shared
+}
 
 func (this *DNSEntryObject) RefreshTime() time.Time {
 	return time.Time{}
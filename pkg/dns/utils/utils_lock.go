@@ -24,6 +24,8 @@ import (
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
 	"github.com/gardener/controller-manager-library/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
 	"github.com/gardener/external-dns-management/pkg/dns"
 )
@@ -109,6 +111,14 @@ func (this *DNSLockObject) GetReference() *api.EntryReference {
 	return nil
 }
 
+func (this *DNSLockObject) GetProviderRef() *api.ProviderReference {
+	return nil
+}
+
+func (this *DNSLockObject) GetProviderSelector() *metav1.LabelSelector {
+	return nil
+}
+
 func (this *DNSLockObject) RefreshTime() time.Time {
 	return this.Spec().Timestamp.Time
 }
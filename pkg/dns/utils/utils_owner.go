@@ -75,6 +75,10 @@ func (this *DNSOwnerObject) ValidUntil() *metav1.Time {
 	return this.DNSOwner().Spec.ValidUntil
 }
 
+func (this *DNSOwnerObject) GetAdoptOwnerIds() []string {
+	return this.DNSOwner().Spec.AdoptOwnerIds
+}
+
 func (this *DNSOwnerObject) GetCounts() map[string]int {
 	return this.DNSOwner().Status.Entries.ByType
 }
@@ -19,6 +19,7 @@ package utils
 import (
 	"strings"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	"github.com/gardener/controller-manager-library/pkg/resources"
@@ -104,6 +105,19 @@ func (this *DNSProviderObject) SetState(state, message string, commonMessagePref
 	return mod.IsModified()
 }
 
+// AssureConditions maintains the Ready and Throttled conditions of the provider status.
+func (this *DNSProviderObject) AssureConditions(state, message string, throttled bool) bool {
+	status := &this.DNSProvider().Status
+	gen := this.DNSProvider().Generation
+	mod := AssureCondition(&status.Conditions, api.ConditionReady, ConditionStatus(state == api.STATE_READY), state, message, gen)
+	if throttled {
+		mod = AssureCondition(&status.Conditions, api.ConditionThrottled, metav1.ConditionTrue, "ProviderThrottled", message, gen) || mod
+	} else {
+		mod = AssureCondition(&status.Conditions, api.ConditionThrottled, metav1.ConditionFalse, "NotThrottled", "", gen) || mod
+	}
+	return mod
+}
+
 func (this *DNSProviderObject) SetSelection(included, excluded utils.StringSet, target *api.DNSSelectionStatus) bool {
 	modified := false
 	old_inc := utils.NewStringSetByArray(target.Included)
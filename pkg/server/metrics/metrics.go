@@ -38,11 +38,16 @@ func init() {
 	prometheus.MustRegister(Accounts)
 	prometheus.MustRegister(Entries)
 	prometheus.MustRegister(StaleEntries)
+	prometheus.MustRegister(ZoneConflicts)
+	prometheus.MustRegister(DriftedEntries)
+	prometheus.MustRegister(PropagationChecks)
+	prometheus.MustRegister(PropagationSeconds)
 	prometheus.MustRegister(Owners)
 	prometheus.MustRegister(RemoteAccessLogins)
 	prometheus.MustRegister(RemoteAccessRequests)
 	prometheus.MustRegister(RemoteAccessSeconds)
 	prometheus.MustRegister(RemoteAccessCertificates)
+	prometheus.MustRegister(RemoteAccessRevokedCertificates)
 
 	server.RegisterHandler("/metrics", promhttp.Handler())
 }
@@ -96,6 +101,39 @@ var (
 		[]string{"providertype", "zone"},
 	)
 
+	ZoneConflicts = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "external_dns_management_zone_conflicts",
+			Help: "Number of DNS names per hosted zone blocked by records of a foreign owner",
+		},
+		[]string{"providertype", "zone", "owner"},
+	)
+
+	DriftedEntries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_dns_entries_drifted",
+			Help: "Total number of dns entries per hosted zone found with records modified outside of the controller",
+		},
+		[]string{"providertype", "zone", "corrected"},
+	)
+
+	PropagationChecks = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_propagation_checks",
+			Help: "Total number of completed propagation checks per hosted zone and result",
+		},
+		[]string{"providertype", "zone", "result"},
+	)
+
+	PropagationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "external_dns_management_propagation_seconds",
+			Help:    "Duration in seconds until changed records are served by all authoritative nameservers",
+			Buckets: []float64{1, 2.5, 5, 10, 25, 50, 100, 250, 500, 1000},
+		},
+		[]string{"providertype", "zone"},
+	)
+
 	Owners = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
 			Name: "external_dns_management_dns_owners",
@@ -117,7 +155,7 @@ var (
 			Name: "external_dns_management_remoteaccess_requests",
 			Help: "Total number of remote access requests",
 		},
-		[]string{"handler", "client", "type", "zoneid"},
+		[]string{"handler", "client", "type", "zoneid", "rejected"},
 	)
 
 	RemoteAccessSeconds = prometheus.NewHistogramVec(
@@ -135,6 +173,13 @@ var (
 			Help: "Number of server-side transport credentials of remote access",
 		},
 	)
+
+	RemoteAccessRevokedCertificates = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Name: "external_dns_management_remoteaccess_revoked_certificates",
+			Help: "Total number of remote access handshakes rejected because of revoked client certificates",
+		},
+	)
 )
 
 var theRequestLabels = &requestLabels{lock: sync.Mutex{}, known: map[ptypeAccount]utils.StringSet{}}
@@ -224,12 +269,26 @@ func ReportZoneEntries(ptype, zone string, amount int, stale int) {
 	zoneProviders.Add(ptype, zone)
 }
 
+func ReportDriftedEntry(ptype, zone string, corrected bool) {
+	DriftedEntries.WithLabelValues(ptype, zone, strconv.FormatBool(corrected)).Inc()
+}
+
+func ReportPropagationCheck(ptype, zone, result string, duration time.Duration) {
+	PropagationChecks.WithLabelValues(ptype, zone, result).Inc()
+	PropagationSeconds.WithLabelValues(ptype, zone).Observe(duration.Seconds())
+}
+
 func ReportRemoteAccessLogins(namespace, client string, success bool) {
 	RemoteAccessLogins.WithLabelValues(namespace, client, strconv.FormatBool(success)).Add(float64(1))
 }
 
 func ReportRemoteAccessRequests(namespace, client, requestType, zoneid string) {
-	RemoteAccessRequests.WithLabelValues(namespace, client, requestType, zoneid).Add(float64(1))
+	RemoteAccessRequests.WithLabelValues(namespace, client, requestType, zoneid, "").Add(float64(1))
+}
+
+// ReportRemoteAccessRejectedRequests counts requests rejected for the given reason (e.g. rate limit).
+func ReportRemoteAccessRejectedRequests(namespace, client, requestType, zoneid, reason string) {
+	RemoteAccessRequests.WithLabelValues(namespace, client, requestType, zoneid, reason).Add(float64(1))
 }
 
 func ReportRemoteAccessSeconds(namespace, client, requestType, zoneid, error string, duration time.Duration) {
@@ -240,6 +299,10 @@ func ReportRemoteAccessCertificates(count int) {
 	RemoteAccessCertificates.Set(float64(count))
 }
 
+func ReportRemoteAccessRevokedCertificate() {
+	RemoteAccessRevokedCertificates.Inc()
+}
+
 func DeleteZone(zone string) {
 	ptype := zoneProviders.Remove(zone)
 	if ptype != "" {
@@ -247,6 +310,40 @@ func DeleteZone(zone string) {
 	}
 }
 
+var zoneConflictOwners = &zoneOwners{owners: map[string]utils.StringSet{}}
+
+type zoneOwners struct {
+	lock   sync.Mutex
+	owners map[string]utils.StringSet
+}
+
+func ReportZoneConflicts(ptype, zone string, counts map[string]int) {
+	zoneConflictOwners.lock.Lock()
+	defer zoneConflictOwners.lock.Unlock()
+
+	for owner := range zoneConflictOwners.owners[zone] {
+		if _, ok := counts[owner]; !ok {
+			ZoneConflicts.DeleteLabelValues(ptype, zone, owner)
+		}
+	}
+	owners := utils.StringSet{}
+	for owner, count := range counts {
+		ZoneConflicts.WithLabelValues(ptype, zone, owner).Set(float64(count))
+		owners.Add(owner)
+	}
+	zoneConflictOwners.owners[zone] = owners
+}
+
+func DeleteZoneConflicts(ptype, zone string) {
+	zoneConflictOwners.lock.Lock()
+	defer zoneConflictOwners.lock.Unlock()
+
+	for owner := range zoneConflictOwners.owners[zone] {
+		ZoneConflicts.DeleteLabelValues(ptype, zone, owner)
+	}
+	delete(zoneConflictOwners.owners, zone)
+}
+
 var currentStatistic = statistic.NewEntryStatistic()
 var lock sync.Mutex
 
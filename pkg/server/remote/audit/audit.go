@@ -0,0 +1,135 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+// Event is the audit record of a single Execute call of a remote client.
+type Event struct {
+	Timestamp  time.Time `json:"timestamp"`
+	Namespace  string    `json:"namespace"`
+	ClientID   string    `json:"clientID"`
+	CommonName string    `json:"commonName,omitempty"`
+	Provider   string    `json:"provider,omitempty"`
+	Zone       string    `json:"zone"`
+	Outcome    string    `json:"outcome"`
+	Changes    []Change  `json:"changes"`
+	Error      string    `json:"error,omitempty"`
+	Log        []string  `json:"log,omitempty"`
+}
+
+// Outcomes of Execute calls.
+const (
+	// OutcomeExecuted is the outcome of executed change requests. The results of the single changes are
+	// reported in the changes.
+	OutcomeExecuted = "executed"
+	// OutcomeAuthFailure is the outcome of calls with invalid token or unknown namespace.
+	OutcomeAuthFailure = "auth-failure"
+	// OutcomePermissionDenied is the outcome of calls of clients without write access to the zone.
+	OutcomePermissionDenied = "permission-denied"
+	// OutcomeRateLimited is the outcome of calls rejected by the rate limit of the client.
+	OutcomeRateLimited = "rate-limited"
+	// OutcomeQuotaExceeded is the outcome of calls rejected by the daily quota of the client.
+	OutcomeQuotaExceeded = "quota-exceeded"
+	// OutcomeBusy is the outcome of calls rejected because the zone is locked by another request.
+	OutcomeBusy = "busy"
+	// OutcomeFailed is the outcome of calls failing before the execution, e.g. for an unknown zone.
+	OutcomeFailed = "failed"
+)
+
+// Change is the audit record of a single change request.
+type Change struct {
+	Action     string   `json:"action"`
+	DNSName    string   `json:"dnsName"`
+	RecordType string   `json:"recordType"`
+	Old        *Records `json:"old,omitempty"`
+	New        *Records `json:"new,omitempty"`
+	Result     string   `json:"result"`
+	Message    string   `json:"message,omitempty"`
+}
+
+// ResultSucceeded is the result of a successfully executed change.
+const ResultSucceeded = "SUCCEEDED"
+
+// ResultRejected is the result of the changes of a rejected Execute call.
+const ResultRejected = "REJECTED"
+
+// Records are the values of a record set.
+type Records struct {
+	TTL    int64    `json:"ttl"`
+	Values []string `json:"values"`
+}
+
+// NewRecords returns the audit representation of a record set or nil.
+func NewRecords(rs *dns.RecordSet) *Records {
+	if rs == nil {
+		return nil
+	}
+	records := &Records{TTL: rs.TTL, Values: []string{}}
+	for _, r := range rs.Records {
+		records.Values = append(records.Values, r.Value)
+	}
+	return records
+}
+
+func (r *Records) String() string {
+	if r == nil {
+		return "-"
+	}
+	return fmt.Sprintf("[%s](%d)", strings.Join(r.Values, ","), r.TTL)
+}
+
+// Summary returns a short human readable description of the event.
+func (e *Event) Summary() string {
+	changes := []string{}
+	for _, c := range e.Changes {
+		changes = append(changes, fmt.Sprintf("%s %s %s %s->%s: %s", c.Action, c.RecordType, c.DNSName, c.Old, c.New, c.Result))
+	}
+	var msg string
+	if e.Outcome == OutcomeExecuted {
+		msg = fmt.Sprintf("remote client %s (%s) changed zone %s: %s", e.ClientID, e.CommonName, e.Zone, strings.Join(changes, "; "))
+	} else {
+		msg = fmt.Sprintf("remote client %s (%s) rejected for zone %s (%s): %s", e.ClientID, e.CommonName, e.Zone, e.Outcome, strings.Join(changes, "; "))
+	}
+	if e.Error != "" {
+		msg += " (error: " + e.Error + ")"
+	}
+	return msg
+}
+
+// Sink receives audit events.
+// Implementations must not block the caller for long.
+type Sink interface {
+	Record(event *Event)
+}
+
+// Sinks dispatches audit events to several sinks.
+type Sinks []Sink
+
+var _ Sink = Sinks{}
+
+func (s Sinks) Record(event *Event) {
+	for _, sink := range s {
+		sink.Record(event)
+	}
+}
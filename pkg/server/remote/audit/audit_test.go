@@ -0,0 +1,107 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+func TestFileSink(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	sink, err := NewFileSink(logger.New(), filename)
+	if err != nil {
+		t.Fatalf("cannot create sink: %s", err)
+	}
+
+	events := []*Event{
+		{
+			Timestamp: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+			Namespace: "default",
+			ClientID:  "client1",
+			Zone:      "Z1",
+			Outcome:   OutcomeExecuted,
+			Changes: []Change{{
+				Action:     "UPDATE",
+				DNSName:    "a.example.com",
+				RecordType: dns.RS_A,
+				Old:        NewRecords(dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "1.1.1.1"}})),
+				New:        NewRecords(dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: "2.2.2.2"}})),
+				Result:     ResultSucceeded,
+			}},
+		},
+		{
+			Timestamp: time.Date(2022, 1, 1, 0, 1, 0, 0, time.UTC),
+			Namespace: "default",
+			ClientID:  "client2",
+			Zone:      "Z1",
+			Outcome:   OutcomeExecuted,
+			Changes:   []Change{{Action: "DELETE", DNSName: "b.example.com", RecordType: dns.RS_TXT, Result: "INVALID"}},
+			Error:     "failed",
+		},
+	}
+	for _, e := range events {
+		sink.Record(e)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("cannot open audit log: %s", err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	i := 0
+	for ; scanner.Scan(); i++ {
+		e := &Event{}
+		if err := json.Unmarshal(scanner.Bytes(), e); err != nil {
+			t.Fatalf("invalid line %d: %s", i, err)
+		}
+		if !reflect.DeepEqual(e, events[i]) {
+			t.Errorf("line %d: expected %#v, got %#v", i, events[i], e)
+		}
+	}
+	if i != len(events) {
+		t.Errorf("expected %d lines, got %d", len(events), i)
+	}
+}
+
+func TestEventSummary(t *testing.T) {
+	event := &Event{
+		ClientID:   "client1",
+		CommonName: "team-a.my.client",
+		Zone:       "Z1",
+		Outcome:    OutcomeExecuted,
+		Changes:    []Change{{Action: "DELETE", DNSName: "a.example.com", RecordType: dns.RS_A, Result: ResultSucceeded}},
+	}
+	if summary := event.Summary(); !strings.HasPrefix(summary, "remote client client1 (team-a.my.client) changed zone Z1: ") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+	event.Outcome = OutcomeRateLimited
+	event.Changes[0].Result = ResultRejected
+	if summary := event.Summary(); !strings.HasPrefix(summary, "remote client client1 (team-a.my.client) rejected for zone Z1 (rate-limited): ") {
+		t.Errorf("unexpected summary %q", summary)
+	}
+}
@@ -0,0 +1,164 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package audit
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// JSON lines file
+
+type fileSink struct {
+	lock   sync.Mutex
+	logctx logger.LogContext
+	file   *os.File
+}
+
+// NewFileSink appends audit events as JSON lines to the given file.
+func NewFileSink(logctx logger.LogContext, filename string) (Sink, error) {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("cannot open audit log file: %w", err)
+	}
+	return &fileSink{logctx: logctx, file: file}, nil
+}
+
+func (s *fileSink) Record(event *Event) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		s.logctx.Errorf("cannot marshal audit event: %s", err)
+		return
+	}
+	data = append(data, '\n')
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if _, err = s.file.Write(data); err == nil {
+		err = s.file.Sync()
+	}
+	if err != nil {
+		s.logctx.Errorf("cannot write audit event: %s", err)
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// webhook
+
+const (
+	webhookQueueSize = 1000
+	webhookRetries   = 3
+)
+
+type webhookSink struct {
+	logctx logger.LogContext
+	url    string
+	client *http.Client
+	queue  chan *Event
+}
+
+// NewWebhookSink posts audit events as JSON to the given URL.
+// Events are sent asynchronously in the order of recording.
+func NewWebhookSink(logctx logger.LogContext, url string) Sink {
+	s := &webhookSink{
+		logctx: logctx,
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		queue:  make(chan *Event, webhookQueueSize),
+	}
+	go s.run()
+	return s
+}
+
+func (s *webhookSink) Record(event *Event) {
+	select {
+	case s.queue <- event:
+	default:
+		s.logctx.Errorf("audit webhook queue full, dropping event: %s", event.Summary())
+	}
+}
+
+func (s *webhookSink) run() {
+	for event := range s.queue {
+		data, err := json.Marshal(event)
+		if err != nil {
+			s.logctx.Errorf("cannot marshal audit event: %s", err)
+			continue
+		}
+		for i := 1; ; i++ {
+			err = s.post(data)
+			if err == nil || i == webhookRetries {
+				break
+			}
+			time.Sleep(time.Duration(i) * time.Second)
+		}
+		if err != nil {
+			s.logctx.Errorf("cannot send audit event to webhook: %s (event: %s)", err, event.Summary())
+		}
+	}
+}
+
+func (s *webhookSink) post(data []byte) error {
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// Kubernetes events
+
+// EventRecorder records Kubernetes events for the DNSProvider serving a zone.
+type EventRecorder interface {
+	RecordProviderEvent(namespace, name, eventtype, reason, message string)
+}
+
+type eventSink struct {
+	recorder EventRecorder
+}
+
+// NewEventSink reports audit events as Kubernetes events on the DNSProvider objects.
+func NewEventSink(recorder EventRecorder) Sink {
+	return &eventSink{recorder: recorder}
+}
+
+func (s *eventSink) Record(event *Event) {
+	eventtype := corev1.EventTypeNormal
+	if event.Error != "" || event.Outcome != OutcomeExecuted {
+		eventtype = corev1.EventTypeWarning
+	}
+	for _, c := range event.Changes {
+		if c.Result != ResultSucceeded {
+			eventtype = corev1.EventTypeWarning
+		}
+	}
+	s.recorder.RecordProviderEvent(event.Namespace, event.Provider, eventtype, "remote-access-audit", event.Summary())
+}
@@ -0,0 +1,142 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+package remote
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+)
+
+type captureSink struct {
+	lock   sync.Mutex
+	events []*audit.Event
+}
+
+func (s *captureSink) Record(event *audit.Event) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.events = append(s.events, event)
+}
+
+func (s *captureSink) last() *audit.Event {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if len(s.events) == 0 {
+		return nil
+	}
+	return s.events[len(s.events)-1]
+}
+
+func TestExecuteAuditOutcomes(t *testing.T) {
+	log := logger.New()
+	s := newServer(log)
+	s.spinning = 10 * time.Millisecond
+	policy, err := ParseAuthorizationPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.policy = policy
+	limits := "namespaces:\n  ns1:\n    dailyChanges: 3\n"
+	if _, err := s.limiters.update(&corev1.Secret{Data: map[string][]byte{ClientLimitsKey: []byte(limits)}}); err != nil {
+		t.Fatal(err)
+	}
+	sink := &captureSink{}
+	s.SetAuditSink(sink)
+
+	handler := &watchTestHandler{
+		zone: provider.NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+		sets: dns.DNSSets{},
+	}
+	nsState := s.getNamespaceState("ns1", true)
+	nsState.updateHandler(log, "p1", handler)
+
+	newToken := func(sessionID, commonName string) string {
+		claims := testClaims(sessionID, time.Now())
+		claims.CommonName = commonName
+		token, err := s.tokens.sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nsState.addSession(claims)
+		return token
+	}
+	token := newToken("s1", "team-a.my.client")
+	changes := func(n int) []*common.ChangeRequest {
+		var requests []*common.ChangeRequest
+		for i := 0; i < n; i++ {
+			requests = append(requests, &common.ChangeRequest{Action: common.ChangeRequest_DELETE,
+				Change: &common.PartialDNSSet{DnsName: "a.team-a.example.com", RecordType: dns.RS_A, RecordSet: &common.RecordSet{}}})
+		}
+		return requests
+	}
+
+	hstate := nsState.handlers["p1"]
+	for _, c := range []struct {
+		name    string
+		token   string
+		changes int
+		busy    bool
+		invalid bool
+		outcome string
+		result  string
+	}{
+		{name: "invalid token", token: "ns1|invalid", changes: 1, outcome: audit.OutcomeAuthFailure, result: audit.ResultRejected},
+		{name: "no zone access", token: newToken("s2", "other.my.client"), changes: 1, outcome: audit.OutcomePermissionDenied, result: audit.ResultRejected},
+		{name: "quota", token: token, changes: 4, outcome: audit.OutcomeQuotaExceeded, result: audit.ResultRejected},
+		{name: "busy", token: token, changes: 1, busy: true, outcome: audit.OutcomeBusy, result: audit.ResultRejected},
+		{name: "invalid action", token: token, changes: 1, invalid: true, outcome: audit.OutcomeFailed, result: audit.ResultRejected},
+		{name: "executed", token: token, changes: 1, outcome: audit.OutcomeExecuted, result: common.ChangeResponse_NOT_PROCESSED.String()},
+	} {
+		if c.busy {
+			hstate.lock.Lock()
+		}
+		requests := changes(c.changes)
+		if c.invalid {
+			requests[0].Action = common.ChangeRequest_ActionType(99)
+		}
+		_, err := s.Execute(context.Background(), &common.ExecuteRequest{Token: c.token, Zoneid: "Z1", ChangeRequest: requests})
+		if c.busy {
+			hstate.lock.Unlock()
+		}
+		if (err == nil) != (c.outcome == audit.OutcomeExecuted) {
+			t.Errorf("%s: unexpected error %v", c.name, err)
+		}
+		event := sink.last()
+		if event == nil {
+			t.Fatalf("%s: missing audit event", c.name)
+		}
+		if event.Outcome != c.outcome || event.Namespace != "ns1" || event.Zone != "Z1" || event.Provider != "p1" {
+			t.Errorf("%s: unexpected audit event %#v", c.name, event)
+		}
+		if len(event.Changes) != c.changes || event.Changes[0].Result != c.result {
+			t.Errorf("%s: unexpected changes %#v", c.name, event.Changes)
+		}
+	}
+	if len(sink.events) != 6 {
+		t.Errorf("expected 6 audit events, got %d", len(sink.events))
+	}
+}
@@ -22,6 +22,10 @@ type RemoteProviderClient interface {
 	GetZones(ctx context.Context, in *GetZonesRequest, opts ...grpc.CallOption) (*Zones, error)
 	GetZoneState(ctx context.Context, in *GetZoneStateRequest, opts ...grpc.CallOption) (*ZoneState, error)
 	Execute(ctx context.Context, in *ExecuteRequest, opts ...grpc.CallOption) (*ExecuteResponse, error)
+	WatchZone(ctx context.Context, in *WatchZoneRequest, opts ...grpc.CallOption) (RemoteProvider_WatchZoneClient, error)
+	Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error)
+	ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*Sessions, error)
+	RevokeSessions(ctx context.Context, in *RevokeSessionsRequest, opts ...grpc.CallOption) (*RevokeSessionsResponse, error)
 }
 
 type remoteProviderClient struct {
@@ -68,6 +72,65 @@ func (c *remoteProviderClient) Execute(ctx context.Context, in *ExecuteRequest,
 	return out, nil
 }
 
+func (c *remoteProviderClient) WatchZone(ctx context.Context, in *WatchZoneRequest, opts ...grpc.CallOption) (RemoteProvider_WatchZoneClient, error) {
+	stream, err := c.cc.NewStream(ctx, &RemoteProvider_ServiceDesc.Streams[0], "/remote.RemoteProvider/WatchZone", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &remoteProviderWatchZoneClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type RemoteProvider_WatchZoneClient interface {
+	Recv() (*ZoneUpdate, error)
+	grpc.ClientStream
+}
+
+type remoteProviderWatchZoneClient struct {
+	grpc.ClientStream
+}
+
+func (x *remoteProviderWatchZoneClient) Recv() (*ZoneUpdate, error) {
+	m := new(ZoneUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *remoteProviderClient) Logout(ctx context.Context, in *LogoutRequest, opts ...grpc.CallOption) (*LogoutResponse, error) {
+	out := new(LogoutResponse)
+	err := c.cc.Invoke(ctx, "/remote.RemoteProvider/Logout", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteProviderClient) ListSessions(ctx context.Context, in *ListSessionsRequest, opts ...grpc.CallOption) (*Sessions, error) {
+	out := new(Sessions)
+	err := c.cc.Invoke(ctx, "/remote.RemoteProvider/ListSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *remoteProviderClient) RevokeSessions(ctx context.Context, in *RevokeSessionsRequest, opts ...grpc.CallOption) (*RevokeSessionsResponse, error) {
+	out := new(RevokeSessionsResponse)
+	err := c.cc.Invoke(ctx, "/remote.RemoteProvider/RevokeSessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RemoteProviderServer is the server API for RemoteProvider service.
 // All implementations must embed UnimplementedRemoteProviderServer
 // for forward compatibility
@@ -76,6 +139,10 @@ type RemoteProviderServer interface {
 	GetZones(context.Context, *GetZonesRequest) (*Zones, error)
 	GetZoneState(context.Context, *GetZoneStateRequest) (*ZoneState, error)
 	Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error)
+	WatchZone(*WatchZoneRequest, RemoteProvider_WatchZoneServer) error
+	Logout(context.Context, *LogoutRequest) (*LogoutResponse, error)
+	ListSessions(context.Context, *ListSessionsRequest) (*Sessions, error)
+	RevokeSessions(context.Context, *RevokeSessionsRequest) (*RevokeSessionsResponse, error)
 	mustEmbedUnimplementedRemoteProviderServer()
 }
 
@@ -95,6 +162,18 @@ func (UnimplementedRemoteProviderServer) GetZoneState(context.Context, *GetZoneS
 func (UnimplementedRemoteProviderServer) Execute(context.Context, *ExecuteRequest) (*ExecuteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Execute not implemented")
 }
+func (UnimplementedRemoteProviderServer) WatchZone(*WatchZoneRequest, RemoteProvider_WatchZoneServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchZone not implemented")
+}
+func (UnimplementedRemoteProviderServer) Logout(context.Context, *LogoutRequest) (*LogoutResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
+}
+func (UnimplementedRemoteProviderServer) ListSessions(context.Context, *ListSessionsRequest) (*Sessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedRemoteProviderServer) RevokeSessions(context.Context, *RevokeSessionsRequest) (*RevokeSessionsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessions not implemented")
+}
 func (UnimplementedRemoteProviderServer) mustEmbedUnimplementedRemoteProviderServer() {}
 
 // UnsafeRemoteProviderServer may be embedded to opt out of forward compatibility for this service.
@@ -180,6 +259,81 @@ func _RemoteProvider_Execute_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RemoteProvider_WatchZone_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchZoneRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RemoteProviderServer).WatchZone(m, &remoteProviderWatchZoneServer{stream})
+}
+
+type RemoteProvider_WatchZoneServer interface {
+	Send(*ZoneUpdate) error
+	grpc.ServerStream
+}
+
+type remoteProviderWatchZoneServer struct {
+	grpc.ServerStream
+}
+
+func (x *remoteProviderWatchZoneServer) Send(m *ZoneUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _RemoteProvider_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogoutRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteProviderServer).Logout(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.RemoteProvider/Logout",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteProviderServer).Logout(ctx, req.(*LogoutRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteProvider_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteProviderServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.RemoteProvider/ListSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteProviderServer).ListSessions(ctx, req.(*ListSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RemoteProvider_RevokeSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RemoteProviderServer).RevokeSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/remote.RemoteProvider/RevokeSessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RemoteProviderServer).RevokeSessions(ctx, req.(*RevokeSessionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RemoteProvider_ServiceDesc is the grpc.ServiceDesc for RemoteProvider service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -203,7 +357,25 @@ var RemoteProvider_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Execute",
 			Handler:    _RemoteProvider_Execute_Handler,
 		},
+		{
+			MethodName: "Logout",
+			Handler:    _RemoteProvider_Logout_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _RemoteProvider_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSessions",
+			Handler:    _RemoteProvider_RevokeSessions_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchZone",
+			Handler:       _RemoteProvider_WatchZone_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/server/remote/common/remote.proto",
 }
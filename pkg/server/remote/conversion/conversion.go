@@ -18,7 +18,9 @@ package conversion
 
 import (
 	"fmt"
+	"sort"
 
+	"github.com/gardener/controller-manager-library/pkg/utils"
 	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
@@ -64,6 +66,80 @@ func MarshalPartialDNSSet(local *dns.DNSSet, recordType string) *common.PartialD
 	}
 }
 
+// MarshalDNSSetsDelta returns the record sets changed between two states of a zone.
+// Deleted record sets are marshalled without record set.
+func MarshalDNSSetsDelta(old, new dns.DNSSets) []*common.PartialDNSSet {
+	names := utils.StringSet{}
+	for name := range old {
+		names.Add(name)
+	}
+	for name := range new {
+		names.Add(name)
+	}
+	sorted := names.AsArray()
+	sort.Strings(sorted)
+
+	var delta []*common.PartialDNSSet
+	for _, name := range sorted {
+		o, n := old[name], new[name]
+		types := utils.StringSet{}
+		if o != nil {
+			for typ := range o.Sets {
+				types.Add(typ)
+			}
+		}
+		if n != nil {
+			for typ := range n.Sets {
+				types.Add(typ)
+			}
+		}
+		sortedTypes := types.AsArray()
+		sort.Strings(sortedTypes)
+		for _, typ := range sortedTypes {
+			var ors, nrs *dns.RecordSet
+			if o != nil {
+				ors = o.Sets[typ]
+			}
+			if n != nil {
+				nrs = n.Sets[typ]
+			}
+			switch {
+			case nrs == nil:
+				delta = append(delta, &common.PartialDNSSet{DnsName: name, UpdateGroup: o.UpdateGroup, RecordType: typ})
+			case ors == nil || !ors.Match(nrs) || o.UpdateGroup != n.UpdateGroup:
+				delta = append(delta, MarshalPartialDNSSet(n, typ))
+			}
+		}
+	}
+	return delta
+}
+
+// ApplyDNSSetsDelta applies record set changes to a zone state and
+// returns the names of the changed DNS sets.
+func ApplyDNSSetsDelta(sets dns.DNSSets, delta []*common.PartialDNSSet) utils.StringSet {
+	names := utils.StringSet{}
+	for _, change := range delta {
+		names.Add(change.DnsName)
+		set := sets[change.DnsName]
+		if change.RecordSet == nil {
+			if set != nil {
+				delete(set.Sets, change.RecordType)
+				if len(set.Sets) == 0 {
+					delete(sets, change.DnsName)
+				}
+			}
+			continue
+		}
+		if set == nil {
+			set = dns.NewDNSSet(change.DnsName)
+			sets[change.DnsName] = set
+		}
+		set.UpdateGroup = change.UpdateGroup
+		set.Sets[change.RecordType] = UnmarshalRecordSet(change.RecordSet)
+	}
+	return names
+}
+
 func UnmarshalDNSSets(remote common.DNSSets) dns.DNSSets {
 	local := dns.DNSSets{}
 	for name, set := range remote {
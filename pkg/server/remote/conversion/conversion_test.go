@@ -90,3 +90,47 @@ func TestMarshalChangeRequest(t *testing.T) {
 		}
 	}
 }
+
+func TestDNSSetsDelta(t *testing.T) {
+	newSets := func(f func(sets dns.DNSSets)) dns.DNSSets {
+		sets := dns.DNSSets{}
+		sets.AddRecordSet("a.b", dns.NewRecordSet(dns.RS_A, 100, []*dns.Record{{Value: "1.1.1.1"}}))
+		sets.AddRecordSet("a.b", dns.NewRecordSet(dns.RS_TXT, 100, []*dns.Record{{Value: "foo"}}))
+		sets.AddRecordSet("c.b", dns.NewRecordSet(dns.RS_CNAME, 100, []*dns.Record{{Value: "a.b"}}))
+		if f != nil {
+			f(sets)
+		}
+		return sets
+	}
+	table := []struct {
+		name    string
+		new     dns.DNSSets
+		changes int
+	}{
+		{"unchanged", newSets(nil), 0},
+		{"added", newSets(func(sets dns.DNSSets) {
+			sets.AddRecordSet("d.b", dns.NewRecordSet(dns.RS_A, 100, []*dns.Record{{Value: "1.1.1.2"}}))
+		}), 1},
+		{"modified", newSets(func(sets dns.DNSSets) {
+			sets["a.b"].Sets[dns.RS_A].TTL = 200
+		}), 1},
+		{"deleted record set", newSets(func(sets dns.DNSSets) {
+			delete(sets["a.b"].Sets, dns.RS_TXT)
+		}), 1},
+		{"deleted dns set", newSets(func(sets dns.DNSSets) {
+			delete(sets, "a.b")
+		}), 2},
+	}
+
+	for _, item := range table {
+		delta := MarshalDNSSetsDelta(newSets(nil), item.new)
+		if len(delta) != item.changes {
+			t.Errorf("delta size mismatch %s: %d != %d", item.name, len(delta), item.changes)
+		}
+		sets := newSets(nil)
+		ApplyDNSSetsDelta(sets, delta)
+		if !reflect.DeepEqual(item.new, sets) {
+			t.Errorf("dnssets mismatch after applying delta %s", item.name)
+		}
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"fmt"
 	"net"
 	"reflect"
 	"sync/atomic"
@@ -39,22 +40,55 @@ type dynamicTransportCredentials struct {
 	oldCertificates      atomic.Value // value type: []tls.Certificate
 	currentTLS           atomic.Value // value type: credentials.TransportCredentials
 	lastResourceVersion  atomic2.String
+	revokedSerialNumbers atomic.Value // value type: map[string]struct{}
 }
 
 var _ credentials.TransportCredentials = &dynamicTransportCredentials{}
 
-func newDynamicTransportCredentials(logctx logger.LogContext, certPool *x509.CertPool, provider ServerSecretProvider) credentials.TransportCredentials {
+func newDynamicTransportCredentials(logctx logger.LogContext, certPool *x509.CertPool, provider ServerSecretProvider,
+	revocationListProvider ServerSecretProvider) credentials.TransportCredentials {
 	dyn := &dynamicTransportCredentials{
 		logctx:               logctx.NewContext("tc", "transport-credentials"),
 		certPool:             certPool,
 		serverSecretProvider: provider,
 	}
 	dyn.oldCertificates.Store([]tls.Certificate{})
+	dyn.revokedSerialNumbers.Store(map[string]struct{}{})
 	dyn.updateTLS(nil)
 	provider.AddUpdateHandler(dyn.updateTLS)
+	if revocationListProvider != nil {
+		revocationListProvider.AddUpdateHandler(dyn.updateRevocationList)
+	}
 	return dyn
 }
 
+func (d *dynamicTransportCredentials) updateRevocationList(secret *corev1.Secret) {
+	list, err := RevocationListFromSecret(secret)
+	if err != nil {
+		// keep the last valid list
+		d.logctx.Errorf("%s", err)
+		return
+	}
+	list.Prune(time.Now())
+	d.revokedSerialNumbers.Store(list.SerialNumbers())
+	d.logctx.Infof("revocation list updated: %d revoked client certificates", len(list.Certificates))
+}
+
+// verifyNotRevoked rejects client certificates contained in the revocation list.
+func (d *dynamicTransportCredentials) verifyNotRevoked(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+	revoked := d.revokedSerialNumbers.Load().(map[string]struct{})
+	for _, chain := range verifiedChains {
+		if len(chain) == 0 {
+			continue
+		}
+		if _, ok := revoked[chain[0].SerialNumber.String()]; ok {
+			metrics.ReportRemoteAccessRevokedCertificate()
+			return fmt.Errorf("client certificate %s (serial number %s) has been revoked", chain[0].Subject.CommonName, chain[0].SerialNumber)
+		}
+	}
+	return nil
+}
+
 func (d *dynamicTransportCredentials) current() credentials.TransportCredentials {
 	return d.currentTLS.Load().(credentials.TransportCredentials)
 }
@@ -84,6 +118,8 @@ func (d *dynamicTransportCredentials) createTLS(secret *corev1.Secret) (credenti
 		Certificates: []tls.Certificate{},
 		ClientAuth:   tls.RequireAndVerifyClientCert,
 		ClientCAs:    d.certPool,
+
+		VerifyPeerCertificate: d.verifyNotRevoked,
 	}
 
 	ok := false
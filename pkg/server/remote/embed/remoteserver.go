@@ -22,9 +22,11 @@ import (
 	"io/ioutil"
 	"log"
 	"net"
+	"time"
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -40,11 +42,60 @@ type ServerSecretProvider interface {
 	AddUpdateHandler(handler ServerSecretUpdateHandler)
 }
 
+// AuthorizationPolicyHandler is called on updated authorization policy secret
+type AuthorizationPolicyHandler interface {
+	UpdateAuthorizationPolicy(secret *corev1.Secret)
+}
+
+// Auditor is implemented by servers supporting audit events
+type Auditor interface {
+	SetAuditSink(sink audit.Sink)
+}
+
+// ClientLimitsHandler is called on updated client limits secret
+type ClientLimitsHandler interface {
+	UpdateClientLimits(secret *corev1.Secret)
+}
+
+// SessionHandler is implemented by servers supporting configurable login tokens
+type SessionHandler interface {
+	SetTokenTTL(ttl time.Duration)
+	UpdateTokenSigningKey(secret *corev1.Secret)
+	SetSessionRevocationStore(store SessionRevocationStore)
+}
+
+// RevokedSessionsKey is the key of the revoked sessions in the token secret.
+const RevokedSessionsKey = "revoked-sessions"
+
+// SessionRevocationStore persists the revoked sessions in the token secret
+type SessionRevocationStore interface {
+	// ModifyRevokedSessions replaces the revoked sessions of the token secret by the result of the modifier.
+	ModifyRevokedSessions(modifier func(data []byte) ([]byte, error)) error
+}
+
 type RemoteAccessServerConfig struct {
 	Port                 int
 	CACertFilename       string
 	SecretName           resources.ObjectName
 	ServerSecretProvider ServerSecretProvider
+	// PolicySecretName is the optional name of the secret containing the authorization policy
+	PolicySecretName     resources.ObjectName
+	PolicySecretProvider ServerSecretProvider
+	// AuditSink is the optional sink for audit events of executed changes
+	AuditSink audit.Sink
+	// RevocationListSecretName is the optional name of the secret containing the revoked client certificates
+	RevocationListSecretName     resources.ObjectName
+	RevocationListSecretProvider ServerSecretProvider
+	// TokenTTL is the optional validity duration of login tokens
+	TokenTTL time.Duration
+	// TokenSecretName is the optional name of the secret containing the key for signing login tokens
+	TokenSecretName     resources.ObjectName
+	TokenSecretProvider ServerSecretProvider
+	// SessionRevocationStore is the optional store for revoked sessions in the token secret
+	SessionRevocationStore SessionRevocationStore
+	// LimitsSecretName is the optional name of the secret containing the rate limits and quotas of the clients
+	LimitsSecretName     resources.ObjectName
+	LimitsSecretProvider ServerSecretProvider
 }
 
 type CreateServerFunc func(logctx logger.LogContext) common.RemoteProviderServer
@@ -67,7 +118,7 @@ func loadTLSCredentials(logctx logger.LogContext, cfg *RemoteAccessServerConfig)
 		return nil, fmt.Errorf("failed to add client CA's certificate")
 	}
 
-	return newDynamicTransportCredentials(logctx, certPool, cfg.ServerSecretProvider), nil
+	return newDynamicTransportCredentials(logctx, certPool, cfg.ServerSecretProvider, cfg.RevocationListSecretProvider), nil
 }
 
 func StartDNSHandlerServer(logctx logger.LogContext, config *RemoteAccessServerConfig) (common.RemoteProviderServer, error) {
@@ -82,6 +133,48 @@ func StartDNSHandlerServer(logctx logger.LogContext, config *RemoteAccessServerC
 	}
 	s := grpc.NewServer(grpc.Creds(creds))
 	server := serverFunc(logctx)
+	if config.PolicySecretName != nil {
+		handler, ok := server.(AuthorizationPolicyHandler)
+		if !ok {
+			_ = lis.Close()
+			return nil, fmt.Errorf("remote access server does not support authorization policies")
+		}
+		// deny all requests until the policy secret has been loaded
+		handler.UpdateAuthorizationPolicy(nil)
+		config.PolicySecretProvider.AddUpdateHandler(handler.UpdateAuthorizationPolicy)
+	}
+	if config.LimitsSecretName != nil {
+		handler, ok := server.(ClientLimitsHandler)
+		if !ok {
+			_ = lis.Close()
+			return nil, fmt.Errorf("remote access server does not support client limits")
+		}
+		config.LimitsSecretProvider.AddUpdateHandler(handler.UpdateClientLimits)
+	}
+	if config.AuditSink != nil {
+		auditor, ok := server.(Auditor)
+		if !ok {
+			_ = lis.Close()
+			return nil, fmt.Errorf("remote access server does not support audit events")
+		}
+		auditor.SetAuditSink(config.AuditSink)
+	}
+	if config.TokenTTL != 0 || config.TokenSecretName != nil {
+		handler, ok := server.(SessionHandler)
+		if !ok {
+			_ = lis.Close()
+			return nil, fmt.Errorf("remote access server does not support token configuration")
+		}
+		if config.TokenTTL != 0 {
+			handler.SetTokenTTL(config.TokenTTL)
+		}
+		if config.TokenSecretName != nil {
+			if config.SessionRevocationStore != nil {
+				handler.SetSessionRevocationStore(config.SessionRevocationStore)
+			}
+			config.TokenSecretProvider.AddUpdateHandler(handler.UpdateTokenSigningKey)
+		}
+	}
 	common.RegisterRemoteProviderServer(s, server)
 	logctx.Infof("DNSHandler server listening at %v", lis.Addr())
 	go func() {
@@ -0,0 +1,93 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package embed
+
+import (
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// RevocationListKey is the key of the revocation list in the revocation list secret.
+const RevocationListKey = "revoked.yaml"
+
+// RevokedCertificate describes a revoked client certificate.
+type RevokedCertificate struct {
+	SerialNumber   string      `json:"serialNumber"`
+	CommonName     string      `json:"commonName,omitempty"`
+	NotAfter       metav1.Time `json:"notAfter"`
+	RevocationTime metav1.Time `json:"revocationTime"`
+}
+
+// RevocationList contains the revoked certificates, which are not expired yet.
+type RevocationList struct {
+	Certificates []RevokedCertificate `json:"certificates"`
+}
+
+// RevocationListFromSecret reads the revocation list from a secret.
+// A missing secret results in an empty list.
+func RevocationListFromSecret(secret *corev1.Secret) (*RevocationList, error) {
+	list := &RevocationList{}
+	if secret == nil || secret.Data == nil || len(secret.Data[RevocationListKey]) == 0 {
+		return list, nil
+	}
+	if err := yaml.Unmarshal(secret.Data[RevocationListKey], list); err != nil {
+		return nil, fmt.Errorf("invalid revocation list in secret %s/%s: %w", secret.Namespace, secret.Name, err)
+	}
+	return list, nil
+}
+
+// Marshal serializes the revocation list.
+func (l *RevocationList) Marshal() ([]byte, error) {
+	return yaml.Marshal(l)
+}
+
+// Add adds a revoked certificate and reports whether the list has been modified.
+func (l *RevocationList) Add(cert RevokedCertificate) bool {
+	for _, c := range l.Certificates {
+		if c.SerialNumber == cert.SerialNumber {
+			return false
+		}
+	}
+	l.Certificates = append(l.Certificates, cert)
+	return true
+}
+
+// Prune removes all expired certificates and reports whether the list has been modified.
+func (l *RevocationList) Prune(now time.Time) bool {
+	var certs []RevokedCertificate
+	for _, c := range l.Certificates {
+		if c.NotAfter.Time.After(now) {
+			certs = append(certs, c)
+		}
+	}
+	mod := len(certs) != len(l.Certificates)
+	l.Certificates = certs
+	return mod
+}
+
+// SerialNumbers returns the set of revoked serial numbers.
+func (l *RevocationList) SerialNumbers() map[string]struct{} {
+	serials := map[string]struct{}{}
+	for _, c := range l.Certificates {
+		serials[c.SerialNumber] = struct{}{}
+	}
+	return serials
+}
@@ -0,0 +1,218 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/util/flowcontrol"
+	"sigs.k8s.io/yaml"
+
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
+)
+
+// ClientLimitsKey is the key of the client limits in the limits secret.
+const ClientLimitsKey = "limits.yaml"
+
+const (
+	rejectedRateLimit = audit.OutcomeRateLimited
+	rejectedQuota     = audit.OutcomeQuotaExceeded
+)
+
+// ClientLimitsConfig defines the limits for the remote clients.
+// The limits apply to each client of a namespace separately. Clients are identified
+// by the common name of their verified client certificate.
+type ClientLimitsConfig struct {
+	// Default are the limits for namespaces without explicit limits.
+	Default *ClientLimits `json:"default,omitempty"`
+	// Namespaces are the limits by namespace.
+	Namespaces map[string]*ClientLimits `json:"namespaces,omitempty"`
+}
+
+// ClientLimits are the limits of a single remote client.
+type ClientLimits struct {
+	// QPS is the maximum rate of GetZoneState and Execute requests per second (0 means unlimited).
+	QPS float32 `json:"qps,omitempty"`
+	// Burst is the maximum number of requests in a burst (defaults to QPS, at least 1).
+	Burst int `json:"burst,omitempty"`
+	// DailyChanges is the maximum number of change requests per day (UTC) (0 means unlimited).
+	DailyChanges int `json:"dailyChanges,omitempty"`
+}
+
+// ParseClientLimitsConfig reads and validates client limits from YAML or JSON.
+func ParseClientLimitsConfig(data []byte) (*ClientLimitsConfig, error) {
+	cfg := &ClientLimitsConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("invalid client limits: %w", err)
+	}
+	if err := cfg.Default.validate(); err != nil {
+		return nil, fmt.Errorf("default: %w", err)
+	}
+	for ns, limits := range cfg.Namespaces {
+		if err := limits.validate(); err != nil {
+			return nil, fmt.Errorf("namespace %s: %w", ns, err)
+		}
+	}
+	return cfg, nil
+}
+
+func (l *ClientLimits) validate() error {
+	if l == nil {
+		return nil
+	}
+	if l.QPS < 0 || l.Burst < 0 || l.DailyChanges < 0 {
+		return fmt.Errorf("limits must not be negative")
+	}
+	if l.Burst == 0 && l.QPS > 0 {
+		l.Burst = int(l.QPS)
+		if l.Burst < 1 {
+			l.Burst = 1
+		}
+	}
+	return nil
+}
+
+func (c *ClientLimitsConfig) limitsFor(namespace string) *ClientLimits {
+	if c == nil {
+		return nil
+	}
+	if limits, ok := c.Namespaces[namespace]; ok {
+		return limits
+	}
+	return c.Default
+}
+
+type clientKey struct {
+	namespace  string
+	commonName string
+}
+
+type clientLimiter struct {
+	limits      ClientLimits
+	rateLimiter flowcontrol.RateLimiter
+	day         string
+	changes     int
+	lastUsed    time.Time
+}
+
+// clientLimiters keeps the state of the rate limits and quotas of all remote clients.
+// The quotas are kept in memory only and start from zero after a restart.
+type clientLimiters struct {
+	lock     sync.Mutex
+	config   *ClientLimitsConfig
+	limiters map[clientKey]*clientLimiter
+}
+
+func newClientLimiters() *clientLimiters {
+	return &clientLimiters{limiters: map[clientKey]*clientLimiter{}}
+}
+
+// update sets the limits from the limits secret. A missing secret removes all limits.
+func (c *clientLimiters) update(secret *corev1.Secret) (*ClientLimitsConfig, error) {
+	var cfg *ClientLimitsConfig
+	if secret != nil && secret.DeletionTimestamp == nil {
+		data, ok := secret.Data[ClientLimitsKey]
+		if !ok {
+			return nil, fmt.Errorf("missing key %s in secret %s/%s", ClientLimitsKey, secret.Namespace, secret.Name)
+		}
+		var err error
+		cfg, err = ParseClientLimitsConfig(data)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.config = cfg
+	return cfg, nil
+}
+
+// check accepts a request of a client consuming a token of the rate limiter (if rateLimited is set)
+// and the given number of changes of the daily quota. It returns the reason if the request is rejected.
+// The client is identified by the common name of its certificate, as the client ID is chosen by the
+// client on login and would allow to reset the limits.
+func (c *clientLimiters) check(namespace, commonName string, rateLimited bool, changes int, now time.Time) (string, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	limits := c.config.limitsFor(namespace)
+	key := clientKey{namespace: namespace, commonName: commonName}
+	if limits == nil {
+		delete(c.limiters, key)
+		return "", nil
+	}
+	limiter := c.limiters[key]
+	if limiter == nil || limiter.limits != *limits {
+		limiter = newClientLimiter(*limits, limiter)
+		c.limiters[key] = limiter
+	}
+
+	limiter.lastUsed = now
+	if rateLimited && limiter.rateLimiter != nil && !limiter.rateLimiter.TryAccept() {
+		return rejectedRateLimit, fmt.Errorf("rate limit of %g requests per second exceeded for client %s", limits.QPS, commonName)
+	}
+	if changes > 0 && limits.DailyChanges > 0 {
+		day := now.UTC().Format("2006-01-02")
+		if limiter.day != day {
+			limiter.day = day
+			limiter.changes = 0
+		}
+		if limiter.changes+changes > limits.DailyChanges {
+			return rejectedQuota, fmt.Errorf("daily quota of %d changes exceeded for client %s (%d changes used)", limits.DailyChanges, commonName, limiter.changes)
+		}
+		limiter.changes += changes
+	}
+	return "", nil
+}
+
+// cleanup removes the state of idle clients, for example of clients with rotated certificates.
+// A client is idle if its quota has been reset and its rate limiter has been refilled since its last request.
+func (c *clientLimiters) cleanup(now time.Time) int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	count := 0
+	for key, limiter := range c.limiters {
+		idle := now.Sub(limiter.lastUsed)
+		if idle < 24*time.Hour {
+			continue
+		}
+		if limiter.limits.QPS > 0 && idle.Seconds() < float64(limiter.limits.Burst)/float64(limiter.limits.QPS) {
+			continue
+		}
+		delete(c.limiters, key)
+		count++
+	}
+	return count
+}
+
+// newClientLimiter creates a limiter keeping the used quota of an old limiter.
+func newClientLimiter(limits ClientLimits, old *clientLimiter) *clientLimiter {
+	limiter := &clientLimiter{limits: limits}
+	if limits.QPS > 0 {
+		limiter.rateLimiter = flowcontrol.NewTokenBucketRateLimiter(limits.QPS, limits.Burst)
+	}
+	if old != nil {
+		limiter.day = old.day
+		limiter.changes = old.changes
+	}
+	return limiter
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const testLimits = `
+default:
+  qps: 0.001
+  burst: 2
+namespaces:
+  team-a:
+    dailyChanges: 10
+  unlimited: {}
+`
+
+func TestClientLimits(t *testing.T) {
+	limiters := newClientLimiters()
+	secret := &corev1.Secret{Data: map[string][]byte{ClientLimitsKey: []byte(testLimits)}}
+	if _, err := limiters.update(secret); err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2022, 3, 1, 23, 0, 0, 0, time.UTC)
+
+	// rate limit by default: burst of 2 requests
+	for i := 0; i < 2; i++ {
+		if reason, err := limiters.check("default", "default.c1", true, 0, now); err != nil {
+			t.Fatalf("request %d: unexpected rejection %s: %s", i, reason, err)
+		}
+	}
+	if reason, _ := limiters.check("default", "default.c1", true, 0, now); reason != rejectedRateLimit {
+		t.Errorf("expected rate limit, got %q", reason)
+	}
+	if _, err := limiters.check("default", "default.c2", true, 0, now); err != nil {
+		t.Errorf("expected separate limit per client: %s", err)
+	}
+	if _, err := limiters.check("default", "default.c1", false, 0, now); err != nil {
+		t.Errorf("expected requests without rate limit to be accepted: %s", err)
+	}
+
+	// daily quota
+	if _, err := limiters.check("team-a", "team-a.c1", true, 8, now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if reason, _ := limiters.check("team-a", "team-a.c1", true, 3, now); reason != rejectedQuota {
+		t.Errorf("expected quota exceeded, got %q", reason)
+	}
+	if _, err := limiters.check("team-a", "team-a.c1", true, 2, now); err != nil {
+		t.Errorf("expected remaining quota to be usable: %s", err)
+	}
+	if _, err := limiters.check("team-a", "team-a.c1", true, 10, now.Add(2*time.Hour)); err != nil {
+		t.Errorf("expected quota reset on next day: %s", err)
+	}
+
+	for i := 0; i < 10; i++ {
+		if _, err := limiters.check("unlimited", "unlimited.c1", true, 1000, now); err != nil {
+			t.Fatalf("expected no limits: %s", err)
+		}
+	}
+
+	// idle clients are removed after the quota reset and refill of the rate limiter
+	if n := limiters.cleanup(now.Add(25 * time.Hour)); n != 3 {
+		t.Errorf("expected 3 idle clients, got %d", n)
+	}
+	if _, ok := limiters.limiters[clientKey{namespace: "team-a", commonName: "team-a.c1"}]; !ok {
+		t.Errorf("expected client used on the next day to be kept")
+	}
+
+	// removing the secret removes all limits
+	if _, err := limiters.update(nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := limiters.check("default", "default.c1", true, 0, now); err != nil {
+		t.Errorf("expected no limits without secret: %s", err)
+	}
+}
+
+func TestInvalidClientLimits(t *testing.T) {
+	for _, data := range []string{
+		"default:\n  qps: -1\n",
+		"namespaces:\n  a:\n    dailyChanges: -5\n",
+		"defaults: {}\n",
+	} {
+		if _, err := ParseClientLimitsConfig([]byte(data)); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
+
+func TestClientLimitsByCommonName(t *testing.T) {
+	s := newServer(logger.New())
+	policy, err := ParseAuthorizationPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.policy = policy
+	limits := "namespaces:\n  ns1:\n    dailyChanges: 2\n"
+	if _, err := s.limiters.update(&corev1.Secret{Data: map[string][]byte{ClientLimitsKey: []byte(limits)}}); err != nil {
+		t.Fatal(err)
+	}
+	nsState := s.getNamespaceState("ns1", true)
+	nsState.updateHandler(s.logctx, "p1", &watchTestHandler{
+		zone: provider.NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+		sets: dns.DNSSets{},
+	})
+
+	newToken := func(sessionID, clientID string) string {
+		claims := testClaims(sessionID, time.Now())
+		claims.ClientID = clientID
+		claims.CommonName = "team-a.my.client"
+		token, err := s.tokens.sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		nsState.addSession(claims)
+		return token
+	}
+
+	if _, _, _, _, err := s.checkAuth(newToken("s1", "client1"), "Execute", "Z1", true, 2); err != nil {
+		t.Fatalf("unexpected rejection: %s", err)
+	}
+	// a new login with another client ID must not reset the quota of the client certificate
+	if _, _, _, _, err := s.checkAuth(newToken("s2", "client2"), "Execute", "Z1", true, 1); err == nil {
+		t.Errorf("expected quota to be exceeded for another client ID of the same certificate")
+	}
+}
@@ -0,0 +1,224 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/yaml"
+)
+
+// AuthorizationPolicyKey is the key of the authorization policy in the policy secret.
+const AuthorizationPolicyKey = "policy.yaml"
+
+type AccessMode string
+
+const (
+	AccessReadOnly  AccessMode = "ReadOnly"
+	AccessReadWrite AccessMode = "ReadWrite"
+	// AccessAdmin grants read-write access and allows to manage the sessions of remote clients.
+	AccessAdmin AccessMode = "Admin"
+)
+
+// AuthorizationPolicy defines which remote clients may access which zones and domains.
+// A client is identified by the common name of its client certificate.
+// Everything not explicitly granted by a rule is denied.
+type AuthorizationPolicy struct {
+	Rules []AuthorizationRule `json:"rules"`
+}
+
+// AuthorizationRule grants access to a set of clients.
+type AuthorizationRule struct {
+	// Clients are glob patterns for the common names of the client certificates (e.g. `*.my.client`).
+	Clients []string `json:"clients"`
+	// Zones restricts the rule to the given hosted zone ids. If empty, all zones are matched.
+	Zones []string `json:"zones,omitempty"`
+	// Domains restricts the rule to the given domain subtrees. If empty, all DNS names are matched.
+	Domains []string `json:"domains,omitempty"`
+	// Access is either `ReadOnly` (default), `ReadWrite` or `Admin`.
+	Access AccessMode `json:"access,omitempty"`
+}
+
+// ParseAuthorizationPolicy reads and validates an authorization policy from YAML or JSON.
+func ParseAuthorizationPolicy(data []byte) (*AuthorizationPolicy, error) {
+	policy := &AuthorizationPolicy{}
+	if err := yaml.UnmarshalStrict(data, policy); err != nil {
+		return nil, fmt.Errorf("invalid authorization policy: %w", err)
+	}
+	for i := range policy.Rules {
+		rule := &policy.Rules[i]
+		if len(rule.Clients) == 0 {
+			return nil, fmt.Errorf("rule %d: missing clients", i)
+		}
+		for _, c := range rule.Clients {
+			if _, err := path.Match(c, ""); err != nil {
+				return nil, fmt.Errorf("rule %d: invalid client pattern %q", i, c)
+			}
+		}
+		for j, d := range rule.Domains {
+			rule.Domains[j] = normalizeDomain(d)
+			if rule.Domains[j] == "" {
+				return nil, fmt.Errorf("rule %d: empty domain", i)
+			}
+		}
+		switch rule.Access {
+		case "":
+			rule.Access = AccessReadOnly
+		case AccessReadOnly, AccessReadWrite, AccessAdmin:
+		default:
+			return nil, fmt.Errorf("rule %d: invalid access %q (expected %s, %s or %s)", i, rule.Access, AccessReadOnly, AccessReadWrite, AccessAdmin)
+		}
+	}
+	return policy, nil
+}
+
+// authorizationPolicyFromSecret returns the policy contained in the given secret.
+// A missing secret results in a policy denying all access.
+func authorizationPolicyFromSecret(secret *corev1.Secret) (*AuthorizationPolicy, error) {
+	if secret == nil || secret.DeletionTimestamp != nil {
+		return &AuthorizationPolicy{}, nil
+	}
+	data, ok := secret.Data[AuthorizationPolicyKey]
+	if !ok {
+		return &AuthorizationPolicy{}, fmt.Errorf("missing key %s in secret %s/%s", AuthorizationPolicyKey, secret.Namespace, secret.Name)
+	}
+	policy, err := ParseAuthorizationPolicy(data)
+	if err != nil {
+		return &AuthorizationPolicy{}, err
+	}
+	return policy, nil
+}
+
+// permissionsFor returns the rules applicable for a client.
+// A nil policy grants unrestricted access.
+func (p *AuthorizationPolicy) permissionsFor(commonName string) *clientPermissions {
+	if p == nil {
+		return nil
+	}
+	perms := &clientPermissions{}
+	for i := range p.Rules {
+		rule := &p.Rules[i]
+		for _, c := range rule.Clients {
+			if ok, _ := path.Match(c, commonName); ok {
+				perms.rules = append(perms.rules, rule)
+				break
+			}
+		}
+	}
+	return perms
+}
+
+// clientPermissions are the rules of a policy matching a client.
+// A nil value grants unrestricted access.
+type clientPermissions struct {
+	rules []*AuthorizationRule
+}
+
+func (c *clientPermissions) isEmpty() bool {
+	return c != nil && len(c.rules) == 0
+}
+
+// canAccessZone checks whether any DNS name of the zone may be accessed.
+func (c *clientPermissions) canAccessZone(zoneid string, write bool) bool {
+	if c == nil {
+		return true
+	}
+	for _, rule := range c.rules {
+		if rule.matchesZone(zoneid) && rule.allows(write) {
+			return true
+		}
+	}
+	return false
+}
+
+// canAccessName checks whether a DNS name of a zone may be accessed.
+func (c *clientPermissions) canAccessName(zoneid, dnsname string, write bool) bool {
+	if c == nil {
+		return true
+	}
+	for _, rule := range c.rules {
+		if rule.matchesZone(zoneid) && rule.matchesName(dnsname) && rule.allows(write) {
+			return true
+		}
+	}
+	return false
+}
+
+// canManageSessions checks whether the client may list and revoke sessions.
+// Session management always requires an explicit rule with admin access.
+func (c *clientPermissions) canManageSessions() bool {
+	if c == nil {
+		return false
+	}
+	for _, rule := range c.rules {
+		if rule.Access == AccessAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+// filterDNSSets returns the DNS sets of a zone readable by the client.
+func (c *clientPermissions) filterDNSSets(zoneid string, sets dns.DNSSets) dns.DNSSets {
+	if c == nil {
+		return sets
+	}
+	result := dns.DNSSets{}
+	for name, set := range sets {
+		if c.canAccessName(zoneid, name, false) {
+			result[name] = set
+		}
+	}
+	return result
+}
+
+func (r *AuthorizationRule) allows(write bool) bool {
+	return !write || r.Access == AccessReadWrite || r.Access == AccessAdmin
+}
+
+func (r *AuthorizationRule) matchesZone(zoneid string) bool {
+	if len(r.Zones) == 0 {
+		return true
+	}
+	for _, z := range r.Zones {
+		if z == zoneid {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *AuthorizationRule) matchesName(dnsname string) bool {
+	if len(r.Domains) == 0 {
+		return true
+	}
+	name := normalizeDomain(dnsname)
+	for _, d := range r.Domains {
+		if name == d || strings.HasSuffix(name, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+func normalizeDomain(name string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.TrimSpace(name), "."))
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+)
+
+const testPolicy = `
+rules:
+- clients: ["*.ops.client"]
+  access: ReadWrite
+- clients: ["admin.ops.client"]
+  access: Admin
+- clients: ["team-a.my.client"]
+  zones: ["Z1"]
+  domains: ["team-a.example.com."]
+  access: ReadWrite
+- clients: ["team-a.my.client"]
+  zones: ["Z1"]
+`
+
+func TestAuthorizationPolicy(t *testing.T) {
+	policy, err := ParseAuthorizationPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatalf("parse failed: %s", err)
+	}
+
+	table := []struct {
+		client  string
+		zoneid  string
+		dnsname string
+		write   bool
+		allowed bool
+	}{
+		{"default.ops.client", "Z2", "foo.example.com", true, true},
+		{"team-a.my.client", "Z1", "foo.team-a.example.com", true, true},
+		{"team-a.my.client", "Z1", "*.team-a.example.com", true, true},
+		{"team-a.my.client", "Z1", "team-a.example.com", true, true},
+		{"team-a.my.client", "Z1", "foo.example.com", true, false},
+		{"team-a.my.client", "Z1", "foo.example.com", false, true},
+		{"team-a.my.client", "Z2", "foo.team-a.example.com", false, false},
+		{"team-b.my.client", "Z1", "foo.team-a.example.com", false, false},
+	}
+	for _, entry := range table {
+		perms := policy.permissionsFor(entry.client)
+		if allowed := perms.canAccessName(entry.zoneid, entry.dnsname, entry.write); allowed != entry.allowed {
+			t.Errorf("%s: %s/%s (write=%t): expected %t, got %t", entry.client, entry.zoneid, entry.dnsname, entry.write, entry.allowed, allowed)
+		}
+	}
+
+	if !policy.permissionsFor("team-b.my.client").isEmpty() {
+		t.Errorf("expected no permissions for unknown client")
+	}
+	if !policy.permissionsFor("admin.ops.client").canManageSessions() || policy.permissionsFor("default.ops.client").canManageSessions() {
+		t.Errorf("expected session management only for admin client")
+	}
+	var unrestricted *AuthorizationPolicy
+	if !unrestricted.permissionsFor("any").canAccessName("Z1", "foo", true) {
+		t.Errorf("expected unrestricted access without policy")
+	}
+	if unrestricted.permissionsFor("any").canManageSessions() {
+		t.Errorf("expected no session management without policy")
+	}
+}
+
+func TestInvalidAuthorizationPolicy(t *testing.T) {
+	for _, data := range []string{
+		"rules:\n- zones: [Z1]\n",
+		"rules:\n- clients: [a]\n  access: Owner\n",
+		"rules:\n- clients: ['[']\n",
+		"rulez: []\n",
+	} {
+		if _, err := ParseAuthorizationPolicy([]byte(data)); err == nil {
+			t.Errorf("expected error for %q", data)
+		}
+	}
+}
+
+func TestPolicyDeniesUntilLoaded(t *testing.T) {
+	s := newServer(logger.New())
+	// policy configured, but secret not yet loaded
+	s.UpdateAuthorizationPolicy(nil)
+	if s.getPermissions("default.ops.client").canAccessZone("Z1", false) {
+		t.Errorf("expected access to be denied until the policy is loaded")
+	}
+}
+
+func TestExecuteChecksEveryChange(t *testing.T) {
+	log := logger.New()
+	s := newServer(log)
+	s.spinning = time.Second
+	policy, err := ParseAuthorizationPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.policy = policy
+
+	handler := &watchTestHandler{
+		zone: provider.NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+		sets: dns.DNSSets{},
+	}
+	handler.addRecord("foo.example.com", "1.1.1.1")
+	nsState := s.getNamespaceState("ns1", true)
+	nsState.updateHandler(log, "p1", handler)
+
+	auth := &clientAuth{clientID: "client1", commonName: "team-a.my.client", perms: s.getPermissions("team-a.my.client")}
+	requests := []*common.ChangeRequest{
+		{Action: common.ChangeRequest_DELETE, Change: &common.PartialDNSSet{DnsName: "foo.example.com", RecordType: dns.RS_A, RecordSet: &common.RecordSet{}}},
+		{Action: common.ChangeRequest_DELETE},
+		{Action: common.ChangeRequest_DELETE, Change: &common.PartialDNSSet{DnsName: "foo.team-a.example.com", RecordType: dns.RS_A}},
+		{Action: common.ChangeRequest_DELETE, Change: &common.PartialDNSSet{DnsName: "foo.team-a.example.com", RecordType: dns.RS_A, RecordSet: &common.RecordSet{}}},
+	}
+	res, err := s.execute(nsState, auth, log, "Z1", requests)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for i, state := range []common.ChangeResponse_State{common.ChangeResponse_INVALID, common.ChangeResponse_INVALID, common.ChangeResponse_INVALID, common.ChangeResponse_NOT_PROCESSED} {
+		if res.ChangeResponse[i].State != state {
+			t.Errorf("change request %d: expected state %s, got %s", i, state, res.ChangeResponse[i].State)
+		}
+	}
+}
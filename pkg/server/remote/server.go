@@ -26,25 +26,37 @@ import (
 
 	"github.com/gardener/controller-manager-library/pkg/logger"
 	"github.com/gardener/controller-manager-library/pkg/resources"
+	"github.com/gardener/external-dns-management/pkg/dns"
 	"github.com/gardener/external-dns-management/pkg/dns/provider"
 	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/remote/audit"
 	"github.com/gardener/external-dns-management/pkg/server/remote/common"
 	"github.com/gardener/external-dns-management/pkg/server/remote/conversion"
+	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
+	corev1 "k8s.io/api/core/v1"
 )
 
 type server struct {
 	lock            sync.Mutex
-	serverID        string
 	spinning        time.Duration
 	logctx          logger.LogContext
 	namespaceStates map[string]*namespaceState
+	policy          *AuthorizationPolicy
+	audit           audit.Sink
+	tokens          *tokenSigner
+	revocationStore embed.SessionRevocationStore
+	limiters        *clientLimiters
 
 	tokenTTL           time.Duration
 	tokenCleanupTicker *time.Ticker
+	// watchCheckPeriod is the period for checking the authorization of idle zone watches
+	watchCheckPeriod time.Duration
+	// watchRetryPeriod is the delay for reading a busy zone again for a zone watch
+	watchRetryPeriod time.Duration
 
 	common.UnimplementedRemoteProviderServer
 }
@@ -54,13 +66,19 @@ func CreateServer(logctx logger.LogContext) common.RemoteProviderServer {
 }
 
 func newServer(logctx logger.LogContext) *server {
-	id, _ := randonString(8)
+	tokens, err := newTokenSigner()
+	if err != nil {
+		panic(fmt.Sprintf("cannot create token signing key: %s", err))
+	}
 	s := &server{
-		serverID:        id,
-		spinning:        15 * time.Second,
-		logctx:          logctx,
-		namespaceStates: map[string]*namespaceState{},
-		tokenTTL:        2 * time.Hour,
+		spinning:         15 * time.Second,
+		logctx:           logctx,
+		namespaceStates:  map[string]*namespaceState{},
+		tokens:           tokens,
+		limiters:         newClientLimiters(),
+		tokenTTL:         2 * time.Hour,
+		watchCheckPeriod: 30 * time.Second,
+		watchRetryPeriod: 5 * time.Second,
 	}
 
 	s.tokenCleanupTicker = time.NewTicker(s.tokenTTL)
@@ -121,21 +139,93 @@ func (s *server) ProviderRemovedEvent(logger logger.LogContext, objectName resou
 	}
 }
 
+// UpdateAuthorizationPolicy sets the authorization policy from the policy secret.
+// Once called, all access not granted by the policy is denied.
+func (s *server) UpdateAuthorizationPolicy(secret *corev1.Secret) {
+	policy, err := authorizationPolicyFromSecret(secret)
+	if err != nil {
+		s.logctx.Errorf("authorization policy: %s -> denying all remote access", err)
+	} else {
+		s.logctx.Infof("authorization policy updated: %d rules", len(policy.Rules))
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.policy = policy
+}
+
+func (s *server) getPermissions(commonName string) *clientPermissions {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.policy.permissionsFor(commonName)
+}
+
 type reportFunc func(err error)
 
-func (s *server) checkAuth(token, requestType, zoneid string) (*namespaceState, logger.LogContext, reportFunc, error) {
+// clientAuth describes an authenticated client request.
+type clientAuth struct {
+	clientID   string
+	commonName string
+	// perms restrict the accessible DNS names
+	perms *clientPermissions
+}
+
+// rejection is the error of a request rejected by checkAuth.
+// It keeps the outcome and the known identity of the client for the audit event.
+type rejection struct {
+	outcome    string
+	namespace  string
+	clientID   string
+	commonName string
+	err        error
+}
+
+func (r *rejection) Error() string {
+	return r.err.Error()
+}
+
+func (r *rejection) Unwrap() error {
+	return r.err
+}
+
+// GRPCStatus returns the status of the wrapped error for the gRPC response.
+func (r *rejection) GRPCStatus() *status.Status {
+	return status.Convert(r.err)
+}
+
+// checkAuth validates the token and the permissions of the client to access the zone.
+// Additionally, the rate limit and the daily quota for the given number of changes of the client are checked.
+// Errors are returned as *rejection.
+func (s *server) checkAuth(token, requestType, zoneid string, write bool, changes int) (*namespaceState, *clientAuth, logger.LogContext, reportFunc, error) {
 	start := time.Now()
 	parts := strings.SplitN(token, "|", 2)
 	namespace := parts[0]
 	nsState := s.getNamespaceState(namespace, false)
 	if nsState == nil {
-		return nil, s.logctx, nil, fmt.Errorf("namespace %s not found or no providers available", namespace)
+		return nil, nil, s.logctx, nil, &rejection{outcome: audit.OutcomeAuthFailure, namespace: namespace,
+			err: fmt.Errorf("namespace %s not found or no providers available", namespace)}
 	}
 
-	clientID, err := nsState.getToken(token)
-	logctx := s.logctx.NewContext("namespace", nsState.name).NewContext("clientID", clientID)
+	tstate, err := s.getSession(nsState, token)
 	if err != nil {
-		return nil, logctx, nil, err
+		return nil, nil, s.logctx.NewContext("namespace", nsState.name), nil,
+			&rejection{outcome: audit.OutcomeAuthFailure, namespace: namespace, err: err}
+	}
+	clientID := tstate.clientID
+	logctx := s.logctx.NewContext("namespace", nsState.name).NewContext("clientID", clientID)
+
+	perms := s.getPermissions(tstate.commonName)
+	if zoneid != "" && !perms.canAccessZone(zoneid, write) {
+		metrics.ReportRemoteAccessRejectedRequests(namespace, clientID, requestType, zoneid, audit.OutcomePermissionDenied)
+		return nil, nil, logctx, nil, &rejection{outcome: audit.OutcomePermissionDenied, namespace: namespace,
+			clientID: clientID, commonName: tstate.commonName, err: status.Error(codes.PermissionDenied,
+				fmt.Sprintf("client %q is not authorized for %s on zone %s", tstate.commonName, requestType, zoneid))}
+	}
+	rateLimited := requestType == "GetZoneState" || requestType == "Execute"
+	if reason, err := s.limiters.check(namespace, tstate.commonName, rateLimited, changes, start); err != nil {
+		metrics.ReportRemoteAccessRejectedRequests(namespace, clientID, requestType, zoneid, reason)
+		return nil, nil, logctx, nil, &rejection{outcome: reason, namespace: namespace,
+			clientID: clientID, commonName: tstate.commonName, err: status.Error(codes.ResourceExhausted, err.Error())}
 	}
 
 	rf := func(err error) {
@@ -148,7 +238,7 @@ func (s *server) checkAuth(token, requestType, zoneid string) (*namespaceState,
 	}
 
 	metrics.ReportRemoteAccessRequests(namespace, clientID, requestType, zoneid)
-	return nsState, logctx, rf, nil
+	return nsState, &clientAuth{clientID: clientID, commonName: tstate.commonName, perms: perms}, logctx, rf, nil
 }
 
 func (s *server) Login(ctx context.Context, request *common.LoginRequest) (*common.LoginResponse, error) {
@@ -158,6 +248,11 @@ func (s *server) Login(ctx context.Context, request *common.LoginRequest) (*comm
 		logctx.Warn("Login auth failed")
 		return nil, err
 	}
+	if s.getPermissions(commonName).isEmpty() {
+		metrics.ReportRemoteAccessLogins(request.Namespace, request.CliendID, false)
+		logctx.Warnf("Login denied: no authorization policy rule for %s", commonName)
+		return nil, status.Error(codes.PermissionDenied, fmt.Sprintf("client %q is not authorized by policy", commonName))
+	}
 	logctx.Info("Login auth successful: %s", commonName)
 
 	nsState := s.getNamespaceState(request.Namespace, false)
@@ -174,10 +269,32 @@ func (s *server) Login(ctx context.Context, request *common.LoginRequest) (*comm
 		return nil, fmt.Errorf("random failed: %w", err)
 	}
 
-	token := nsState.generateAndAddToken(s.tokenTTL, rnd, request.CliendID, s.serverID)
+	now := time.Now()
+	claims := &tokenClaims{
+		Namespace:  request.Namespace,
+		ClientID:   request.CliendID,
+		CommonName: commonName,
+		SessionID:  rnd,
+		IssuedAt:   now.Unix(),
+		ValidUntil: now.Add(s.getTokenTTL()).Unix(),
+	}
+	token, err := s.tokens.sign(claims)
+	if err != nil {
+		return nil, fmt.Errorf("signing token failed: %w", err)
+	}
+	nsState.addSession(claims)
 	return &common.LoginResponse{Token: token}, nil
 }
 
+// getSession verifies the token and returns the session.
+func (s *server) getSession(nsState *namespaceState, token string) (*sessionState, error) {
+	claims, err := s.tokens.verify(token)
+	if err != nil {
+		return nil, fmt.Errorf("%s for namespace %s: %s", common.InvalidToken, nsState.name, err)
+	}
+	return nsState.getSession(claims, time.Now())
+}
+
 func (s *server) checkNamespaceAuthorization(ctx context.Context, namespace string) (string, error) {
 	p, ok := peer.FromContext(ctx)
 	if !ok {
@@ -211,25 +328,76 @@ func (s *server) cleanupTokens() {
 	count := 0
 	now := time.Now()
 	for _, nsState := range s.namespaceStates {
-		count += nsState.cleanupTokens(now)
+		count += nsState.cleanupSessions(now, s.tokenTTL)
+	}
+	s.logctx.Infof("token cleanup of %d outdated sessions", count)
+	if count := s.limiters.cleanup(now); count > 0 {
+		s.logctx.Infof("limits cleanup of %d idle clients", count)
+	}
+}
+
+// SetTokenTTL sets the validity duration of login tokens.
+func (s *server) SetTokenTTL(ttl time.Duration) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.tokenTTL = ttl
+	s.tokenCleanupTicker.Reset(ttl)
+}
+
+func (s *server) getTokenTTL() time.Duration {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.tokenTTL
+}
+
+// UpdateClientLimits sets the rate limits and quotas of the remote clients from the limits secret.
+func (s *server) UpdateClientLimits(secret *corev1.Secret) {
+	cfg, err := s.limiters.update(secret)
+	switch {
+	case err != nil:
+		s.logctx.Errorf("client limits: %s -> keeping current limits", err)
+	case cfg == nil:
+		s.logctx.Infof("client limits removed")
+	default:
+		s.logctx.Infof("client limits updated: %d namespaces (default: %t)", len(cfg.Namespaces), cfg.Default != nil)
+	}
+}
+
+// UpdateTokenSigningKey sets the keys for signing and verifying login tokens from the token secret.
+// With a shared signing key, tokens survive server restarts and are accepted by all replicas.
+// The revoked sessions stored in the secret are applied, too.
+func (s *server) UpdateTokenSigningKey(secret *corev1.Secret) {
+	if err := s.tokens.updateKeys(secret); err != nil {
+		s.logctx.Errorf("token signing key: %s", err)
+		return
+	}
+	s.logctx.Infof("token signing key updated")
+	if err := s.loadRevocations(secret.Data[embed.RevokedSessionsKey]); err != nil {
+		s.logctx.Errorf("token secret: %s", err)
 	}
-	s.logctx.Infof("token cleanup of %d outdated tokens", count)
+}
+
+// SetSessionRevocationStore sets the store for persisting revoked sessions in the token secret.
+func (s *server) SetSessionRevocationStore(store embed.SessionRevocationStore) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.revocationStore = store
 }
 
 func (s *server) GetZones(_ context.Context, request *common.GetZonesRequest) (*common.Zones, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZones", "")
+	nsState, auth, logctx, report, err := s.checkAuth(request.Token, "GetZones", "", false, 0)
 	if err != nil {
 		logctx.Warn(err)
 		return nil, err
 	}
 	logctx.Info("GetZones")
 
-	res, err := s.getZones(nsState, logctx)
+	res, err := s.getZones(nsState, auth.perms, logctx)
 	report(err)
 	return res, err
 }
 
-func (s *server) getZones(nsState *namespaceState, logctx logger.LogContext) (*common.Zones, error) {
+func (s *server) getZones(nsState *namespaceState, perms *clientPermissions, logctx logger.LogContext) (*common.Zones, error) {
 	zones, err := nsState.getAllZones(s.spinning)
 	if err != nil {
 		return nil, err
@@ -237,6 +405,9 @@ func (s *server) getZones(nsState *namespaceState, logctx logger.LogContext) (*c
 
 	result := &common.Zones{}
 	for _, zone := range zones {
+		if !perms.canAccessZone(zone.Id(), false) {
+			continue
+		}
 		z := &common.Zone{
 			Id:              zone.Id(),
 			ProviderType:    zone.ProviderType(),
@@ -253,7 +424,7 @@ func (s *server) getZones(nsState *namespaceState, logctx logger.LogContext) (*c
 }
 
 func (s *server) GetZoneState(_ context.Context, request *common.GetZoneStateRequest) (*common.ZoneState, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "GetZoneState", request.Zoneid)
+	nsState, auth, logctx, report, err := s.checkAuth(request.Token, "GetZoneState", request.Zoneid, false, 0)
 	if err != nil {
 		logctx.Warn(err)
 		return nil, err
@@ -261,12 +432,12 @@ func (s *server) GetZoneState(_ context.Context, request *common.GetZoneStateReq
 	logctx = logctx.NewContext("zoneid", request.Zoneid)
 	logctx.Info("GetZoneState")
 
-	res, err := s.getZoneState(nsState, logctx, request.Zoneid)
+	res, err := s.getZoneState(nsState, auth.perms, logctx, request.Zoneid, request.Names)
 	report(err)
 	return res, err
 }
 
-func (s *server) getZoneState(nsState *namespaceState, logctx logger.LogContext, zoneid string) (*common.ZoneState, error) {
+func (s *server) getZoneState(nsState *namespaceState, perms *clientPermissions, logctx logger.LogContext, zoneid string, names []string) (*common.ZoneState, error) {
 	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
 	if err != nil {
 		return nil, err
@@ -281,40 +452,60 @@ func (s *server) getZoneState(nsState *namespaceState, logctx logger.LogContext,
 	if err != nil {
 		return nil, err
 	}
-	result := &common.ZoneState{DnsSets: conversion.MarshalDNSSets(state.GetDNSSets())}
+	sets := perms.filterDNSSets(zoneid, state.GetDNSSets())
+	if len(names) > 0 {
+		sets = filterDNSSetsByNames(sets, names)
+	}
+	result := &common.ZoneState{DnsSets: conversion.MarshalDNSSets(sets)}
 	logctx.Infof("GetZoneState: %d DNSSets", len(result.GetDnsSets()))
 
 	return result, nil
 }
 
+// filterDNSSetsByNames restricts the DNS sets to the requested DNS names for partial reads.
+func filterDNSSetsByNames(sets dns.DNSSets, names []string) dns.DNSSets {
+	result := dns.DNSSets{}
+	for _, name := range names {
+		if set, ok := sets[name]; ok {
+			result[name] = set
+		}
+	}
+	return result
+}
+
 func (s *server) Execute(_ context.Context, request *common.ExecuteRequest) (*common.ExecuteResponse, error) {
-	nsState, logctx, report, err := s.checkAuth(request.Token, "Execute", request.Zoneid)
+	nsState, auth, logctx, report, err := s.checkAuth(request.Token, "Execute", request.Zoneid, true, len(request.ChangeRequest))
 	if err != nil {
 		logctx.Warn(err)
+		s.recordRejectedAuditEvent(err, request.Zoneid, request.ChangeRequest)
 		return nil, err
 	}
 	logctx = logctx.NewContext("zoneid", request.Zoneid)
 	logctx.Infof("Execute: %d changes", len(request.ChangeRequest))
 
-	res, err := s.execute(nsState, logctx, request.Zoneid, request.ChangeRequest)
+	res, err := s.execute(nsState, auth, logctx, request.Zoneid, request.ChangeRequest)
 	report(err)
 	return res, err
 }
 
-func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zoneid string, changeRequests []*common.ChangeRequest) (*common.ExecuteResponse, error) {
+func (s *server) execute(nsState *namespaceState, auth *clientAuth, logctx logger.LogContext, zoneid string, changeRequests []*common.ChangeRequest) (*common.ExecuteResponse, error) {
 	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
 	if err != nil {
+		s.recordAuditEvent(s.newAuditEvent(nsState, auth, "", zoneid, audit.OutcomeFailed, nil, changeRequests), nil, nil, err)
 		return nil, err
 	}
 
 	if !hstate.lock.TryLockSpinning(s.spinning) {
 		logctx.Info("rejected - busy")
-		return nil, fmt.Errorf("busy")
+		err = fmt.Errorf("busy")
+		s.recordAuditEvent(s.newAuditEvent(nsState, auth, hstate.name, zoneid, audit.OutcomeBusy, nil, changeRequests), nil, nil, err)
+		return nil, err
 	}
 	defer hstate.lock.Unlock()
 
 	state, err := hstate.handler.GetZoneState(zone)
 	if err != nil {
+		s.recordAuditEvent(s.newAuditEvent(nsState, auth, hstate.name, zoneid, audit.OutcomeFailed, nil, changeRequests), nil, nil, err)
 		return nil, err
 	}
 
@@ -324,20 +515,241 @@ func (s *server) execute(nsState *namespaceState, logctx logger.LogContext, zone
 	for _, request := range changeRequests {
 		response := &common.ChangeResponse{}
 		responses = append(responses, response)
+		if request.Change == nil || request.Change.RecordSet == nil {
+			memLogger.Warnf("missing record set for action %s", request.Action)
+			response.State = common.ChangeResponse_INVALID
+			response.ErrorMessage = "missing record set"
+			continue
+		}
+		if !auth.perms.canAccessName(zoneid, request.Change.DnsName, true) {
+			memLogger.Warnf("permission denied for %s %s", request.Change.DnsName, request.Change.RecordType)
+			response.State = common.ChangeResponse_INVALID
+			response.ErrorMessage = fmt.Sprintf("permission denied for DNS name %s", request.Change.DnsName)
+			continue
+		}
 		done := newDoneHandler(response)
 		req, err := conversion.UnmarshalChangeRequest(request, done)
 		if err != nil {
+			event := s.newAuditEvent(nsState, auth, hstate.name, zoneid, audit.OutcomeFailed, state.GetDNSSets(), changeRequests)
+			s.recordAuditEvent(event, nil, memLogger, err)
 			return nil, err
 		}
 		requests = append(requests, req)
 	}
+	event := s.newAuditEvent(nsState, auth, hstate.name, zoneid, audit.OutcomeExecuted, state.GetDNSSets(), changeRequests)
 	err = hstate.handler.ExecuteRequests(memLogger, zone, state, requests)
+	nsState.notifyWatchers(zoneid)
+	s.recordAuditEvent(event, responses, memLogger, err)
 	return &common.ExecuteResponse{
 		ChangeResponse: responses,
 		LogMessage:     memLogger.entries,
 	}, err
 }
 
+func (s *server) WatchZone(request *common.WatchZoneRequest, stream common.RemoteProvider_WatchZoneServer) error {
+	nsState, _, logctx, report, err := s.checkAuth(request.Token, "WatchZone", request.Zoneid, false, 0)
+	if err != nil {
+		logctx.Warn(err)
+		return err
+	}
+	logctx = logctx.NewContext("zoneid", request.Zoneid)
+	logctx.Info("WatchZone")
+
+	err = s.watchZone(stream.Context(), nsState, logctx, request.Token, request.Zoneid, stream.Send)
+	report(err)
+	return err
+}
+
+// watchZone sends the current zone state and afterwards the changed record sets whenever
+// the zone has been modified by a remote client or the cached zone state has changed.
+// The zone state is not polled, the watch is driven by the notifications of the zone caches.
+func (s *server) watchZone(ctx context.Context, nsState *namespaceState, logctx logger.LogContext, token, zoneid string,
+	send func(*common.ZoneUpdate) error) error {
+	notify := nsState.addWatcher(zoneid)
+	defer nsState.removeWatcher(zoneid, notify)
+
+	ticker := time.NewTicker(s.watchCheckPeriod)
+	defer ticker.Stop()
+
+	var last dns.DNSSets
+	var retry <-chan time.Time
+	update := true
+	for {
+		// permissions are evaluated on every update and periodically to respect session revocations and policy changes
+		tstate, err := s.getSession(nsState, token)
+		if err != nil {
+			return err
+		}
+		perms := s.getPermissions(tstate.commonName)
+		if !perms.canAccessZone(zoneid, false) {
+			return status.Error(codes.PermissionDenied, fmt.Sprintf("client %q is not authorized for zone %s", tstate.commonName, zoneid))
+		}
+		if update {
+			sets, err := s.getZoneDNSSets(nsState, zoneid)
+			switch err {
+			case nil:
+				retry = nil
+				sets = perms.filterDNSSets(zoneid, sets)
+				if last == nil {
+					logctx.Infof("WatchZone: snapshot with %d DNSSets", len(sets))
+					err = send(&common.ZoneUpdate{Snapshot: &common.ZoneState{DnsSets: conversion.MarshalDNSSets(sets)}})
+				} else if delta := conversion.MarshalDNSSetsDelta(last, sets); len(delta) > 0 {
+					logctx.Infof("WatchZone: %d changed record sets", len(delta))
+					err = send(&common.ZoneUpdate{Change: delta})
+				}
+				if err != nil {
+					return err
+				}
+				last = sets
+			case errBusy:
+				retry = time.After(s.watchRetryPeriod)
+			default:
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			logctx.Info("WatchZone: closed")
+			return nil
+		case <-notify:
+			update = true
+		case <-retry:
+			update = true
+		case <-ticker.C:
+			update = false
+		}
+	}
+}
+
+// ZoneStateChanged notifies the watchers of a zone about a changed cached zone state.
+func (s *server) ZoneStateChanged(zoneid string) {
+	s.lock.Lock()
+	states := make([]*namespaceState, 0, len(s.namespaceStates))
+	for _, nsState := range s.namespaceStates {
+		states = append(states, nsState)
+	}
+	s.lock.Unlock()
+
+	for _, nsState := range states {
+		nsState.notifyWatchers(zoneid)
+	}
+}
+
+var errBusy = fmt.Errorf("busy")
+
+func (s *server) getZoneDNSSets(nsState *namespaceState, zoneid string) (dns.DNSSets, error) {
+	hstate, zone, err := nsState.lockupZone(s.spinning, zoneid)
+	if err != nil {
+		return nil, err
+	}
+	if !hstate.lock.TryLockSpinning(s.spinning) {
+		return nil, errBusy
+	}
+	defer hstate.lock.Unlock()
+
+	state, err := hstate.handler.GetZoneState(zone)
+	if err != nil {
+		return nil, err
+	}
+	return state.GetDNSSets().Clone(), nil
+}
+
+// SetAuditSink sets the sink for the audit events of Execute calls.
+func (s *server) SetAuditSink(sink audit.Sink) {
+	s.audit = sink
+}
+
+// newAuditEvent prepares the audit event with the record sets before execution.
+// The sets are nil if the zone state is not available.
+func (s *server) newAuditEvent(nsState *namespaceState, auth *clientAuth, providerName, zoneid, outcome string,
+	sets dns.DNSSets, changeRequests []*common.ChangeRequest) *audit.Event {
+	if s.audit == nil {
+		return nil
+	}
+	event := &audit.Event{
+		Timestamp:  time.Now().UTC(),
+		Namespace:  nsState.name,
+		ClientID:   auth.clientID,
+		CommonName: auth.commonName,
+		Provider:   providerName,
+		Zone:       zoneid,
+		Outcome:    outcome,
+		Changes:    newAuditChanges(sets, changeRequests),
+	}
+	return event
+}
+
+// recordRejectedAuditEvent records the audit event of an Execute call rejected by checkAuth.
+func (s *server) recordRejectedAuditEvent(err error, zoneid string, changeRequests []*common.ChangeRequest) {
+	r, ok := err.(*rejection)
+	if s.audit == nil || !ok {
+		return
+	}
+	providerName := ""
+	if nsState := s.getNamespaceState(r.namespace, false); nsState != nil {
+		if hstate, _, err := nsState.lockupZone(s.spinning, zoneid); err == nil {
+			providerName = hstate.name
+		}
+	}
+	event := &audit.Event{
+		Timestamp:  time.Now().UTC(),
+		Namespace:  r.namespace,
+		ClientID:   r.clientID,
+		CommonName: r.commonName,
+		Provider:   providerName,
+		Zone:       zoneid,
+		Outcome:    r.outcome,
+		Changes:    newAuditChanges(nil, changeRequests),
+	}
+	s.recordAuditEvent(event, nil, nil, r.err)
+}
+
+func newAuditChanges(sets dns.DNSSets, changeRequests []*common.ChangeRequest) []audit.Change {
+	changes := []audit.Change{}
+	for _, request := range changeRequests {
+		change := audit.Change{Action: request.Action.String()}
+		if request.Change != nil {
+			change.DNSName = request.Change.DnsName
+			change.RecordType = request.Change.RecordType
+			if set := sets[request.Change.DnsName]; set != nil {
+				change.Old = audit.NewRecords(set.Sets[request.Change.RecordType])
+			}
+			if request.Action != common.ChangeRequest_DELETE && request.Change.RecordSet != nil {
+				change.New = audit.NewRecords(conversion.UnmarshalRecordSet(request.Change.RecordSet))
+			}
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+// recordAuditEvent completes the audit event with the results of the changes and records it.
+// Without responses, the changes have not been executed and are marked as rejected.
+func (s *server) recordAuditEvent(event *audit.Event, responses []*common.ChangeResponse, memLogger *memoryLogger, err error) {
+	if event == nil {
+		return
+	}
+	if responses == nil {
+		for i := range event.Changes {
+			event.Changes[i].Result = audit.ResultRejected
+		}
+	}
+	for i, response := range responses {
+		event.Changes[i].Result = response.State.String()
+		event.Changes[i].Message = response.ErrorMessage
+	}
+	if err != nil {
+		event.Error = err.Error()
+	}
+	if memLogger != nil {
+		for _, entry := range memLogger.entries {
+			event.Log = append(event.Log, entry.Message)
+		}
+	}
+	s.audit.Record(event)
+}
+
 func newDoneHandler(response *common.ChangeResponse) provider.DoneHandler {
 	return &serverDoneHandler{response: response}
 }
@@ -0,0 +1,243 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/server/metrics"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *server) Logout(_ context.Context, request *common.LogoutRequest) (*common.LogoutResponse, error) {
+	claims, err := s.tokens.verify(request.Token)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", common.InvalidToken, err)
+	}
+	logctx := s.logctx.NewContext("namespace", claims.Namespace).NewContext("clientID", claims.ClientID)
+	metrics.ReportRemoteAccessRequests(claims.Namespace, claims.ClientID, "Logout", "")
+	validUntil := time.Unix(claims.ValidUntil, 0)
+	if nsState := s.getNamespaceState(claims.Namespace, false); nsState != nil {
+		nsState.revokeSession(claims.SessionID, validUntil)
+	}
+	revocations := &namespaceRevocations{Sessions: map[string]int64{claims.SessionID: validUntil.Unix()}}
+	if err := s.storeRevocations(claims.Namespace, revocations, time.Now()); err != nil {
+		logctx.Warnf("storing session revocation failed: %s", err)
+	}
+	logctx.Info("Logout")
+	return &common.LogoutResponse{}, nil
+}
+
+func (s *server) ListSessions(_ context.Context, request *common.ListSessionsRequest) (*common.Sessions, error) {
+	nsState, logctx, err := s.checkSessionAdmin(request.Token, request.Namespace, "ListSessions")
+	if err != nil {
+		logctx.Warn(err)
+		return nil, err
+	}
+	result := &common.Sessions{}
+	if nsState != nil {
+		result.Session = nsState.listSessions(request.ClientId)
+	}
+	logctx.Infof("ListSessions: %d sessions", len(result.Session))
+	return result, nil
+}
+
+func (s *server) RevokeSessions(_ context.Context, request *common.RevokeSessionsRequest) (*common.RevokeSessionsResponse, error) {
+	if request.SessionId == "" && request.ClientId == "" && request.CommonName == "" {
+		return nil, status.Error(codes.InvalidArgument, "session id, client id or common name required")
+	}
+	nsState, logctx, err := s.checkSessionAdmin(request.Token, request.Namespace, "RevokeSessions")
+	if err != nil {
+		logctx.Warn(err)
+		return nil, err
+	}
+	if nsState == nil {
+		return &common.RevokeSessionsResponse{}, nil
+	}
+
+	count := 0
+	now := time.Now()
+	revocations := &namespaceRevocations{}
+	if request.SessionId != "" {
+		validUntil := now.Add(s.getTokenTTL())
+		count += nsState.revokeSession(request.SessionId, validUntil)
+		revocations.Sessions = map[string]int64{request.SessionId: validUntil.Unix()}
+	}
+	// the client id is chosen by the client on login, so all sessions of its client certificates are revoked
+	var commonNames []string
+	if request.ClientId != "" {
+		commonNames = nsState.getClientCommonNames(request.ClientId)
+	}
+	if request.CommonName != "" {
+		commonNames = append(commonNames, request.CommonName)
+	}
+	for _, commonName := range commonNames {
+		count += nsState.revokeClientSessions(commonName, now)
+		if revocations.CommonNames == nil {
+			revocations.CommonNames = map[string]int64{}
+		}
+		revocations.CommonNames[commonName] = now.Unix()
+	}
+	if err := s.storeRevocations(nsState.name, revocations, now); err != nil {
+		logctx.Warnf("storing session revocations failed: %s", err)
+	}
+	logctx.Infof("RevokeSessions: revoked %d sessions (session id: %q, client id: %q, common names: %v)", count, request.SessionId, request.ClientId, commonNames)
+	return &common.RevokeSessionsResponse{Count: int32(count)}, nil
+}
+
+// checkSessionAdmin validates the token and the permission of the client to manage the sessions of the namespace.
+// The namespace defaults to the namespace of the token. Other namespaces are only allowed for clients
+// with a wildcard common name. The returned namespace state is nil if the namespace has no sessions.
+func (s *server) checkSessionAdmin(token, namespace, requestType string) (*namespaceState, logger.LogContext, error) {
+	tokenNamespace := strings.SplitN(token, "|", 2)[0]
+	tokenNsState := s.getNamespaceState(tokenNamespace, false)
+	if tokenNsState == nil {
+		return nil, s.logctx, fmt.Errorf("namespace %s not found or no providers available", tokenNamespace)
+	}
+	sstate, err := s.getSession(tokenNsState, token)
+	if err != nil {
+		return nil, s.logctx.NewContext("namespace", tokenNamespace), err
+	}
+	logctx := s.logctx.NewContext("namespace", tokenNamespace).NewContext("clientID", sstate.clientID)
+	metrics.ReportRemoteAccessRequests(tokenNamespace, sstate.clientID, requestType, "")
+
+	if !s.getPermissions(sstate.commonName).canManageSessions() {
+		return nil, logctx, status.Error(codes.PermissionDenied,
+			fmt.Sprintf("client %q is not authorized to manage sessions", sstate.commonName))
+	}
+	if namespace == "" {
+		namespace = tokenNamespace
+	}
+	if namespace != tokenNamespace && strings.SplitN(sstate.commonName, ".", 2)[0] != "*" {
+		return nil, logctx, status.Error(codes.PermissionDenied,
+			fmt.Sprintf("client %q is not authorized to manage sessions of namespace %s", sstate.commonName, namespace))
+	}
+	if namespace == tokenNamespace {
+		return tokenNsState, logctx, nil
+	}
+	return s.getNamespaceState(namespace, false), logctx.NewContext("target", namespace), nil
+}
+
+// revokedSessions are the revoked sessions stored in the token secret by namespace.
+type revokedSessions map[string]*namespaceRevocations
+
+// namespaceRevocations contains the revoked session ids and client certificates of a namespace.
+type namespaceRevocations struct {
+	// Sessions maps the revoked session ids to the end of their validity (unix time)
+	Sessions map[string]int64 `json:"sessions,omitempty"`
+	// CommonNames maps the common names of client certificates to the time (unix time) up to which all their sessions are revoked
+	CommonNames map[string]int64 `json:"commonNames,omitempty"`
+}
+
+func parseRevokedSessions(data []byte) (revokedSessions, error) {
+	revoked := revokedSessions{}
+	if len(data) == 0 {
+		return revoked, nil
+	}
+	if err := json.Unmarshal(data, &revoked); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", embed.RevokedSessionsKey, err)
+	}
+	return revoked, nil
+}
+
+// merge adds the revocations of a namespace and drops all expired revocations.
+func (r revokedSessions) merge(namespace string, add *namespaceRevocations, now time.Time, tokenTTL time.Duration) {
+	nsRevoked := r[namespace]
+	if nsRevoked == nil {
+		nsRevoked = &namespaceRevocations{}
+		r[namespace] = nsRevoked
+	}
+	for id, validUntil := range add.Sessions {
+		if nsRevoked.Sessions == nil {
+			nsRevoked.Sessions = map[string]int64{}
+		}
+		if validUntil > nsRevoked.Sessions[id] {
+			nsRevoked.Sessions[id] = validUntil
+		}
+	}
+	for commonName, before := range add.CommonNames {
+		if nsRevoked.CommonNames == nil {
+			nsRevoked.CommonNames = map[string]int64{}
+		}
+		if before > nsRevoked.CommonNames[commonName] {
+			nsRevoked.CommonNames[commonName] = before
+		}
+	}
+
+	for ns, nsRevoked := range r {
+		for id, validUntil := range nsRevoked.Sessions {
+			if time.Unix(validUntil, 0).Before(now) {
+				delete(nsRevoked.Sessions, id)
+			}
+		}
+		for commonName, before := range nsRevoked.CommonNames {
+			if time.Unix(before, 0).Add(tokenTTL).Before(now) {
+				delete(nsRevoked.CommonNames, commonName)
+			}
+		}
+		if len(nsRevoked.Sessions) == 0 && len(nsRevoked.CommonNames) == 0 {
+			delete(r, ns)
+		}
+	}
+}
+
+// storeRevocations adds revocations to the token secret, so that they survive a restart and
+// are applied by all replicas. Without a token secret, revocations are only kept in memory.
+func (s *server) storeRevocations(namespace string, revocations *namespaceRevocations, now time.Time) error {
+	s.lock.Lock()
+	store := s.revocationStore
+	tokenTTL := s.tokenTTL
+	s.lock.Unlock()
+	if store == nil {
+		return nil
+	}
+	return store.ModifyRevokedSessions(func(data []byte) ([]byte, error) {
+		revoked, err := parseRevokedSessions(data)
+		if err != nil {
+			// overwrite invalid content, it would be ignored anyway
+			revoked = revokedSessions{}
+		}
+		revoked.merge(namespace, revocations, now, tokenTTL)
+		return json.Marshal(revoked)
+	})
+}
+
+// loadRevocations applies the revocations stored in the token secret.
+func (s *server) loadRevocations(data []byte) error {
+	revoked, err := parseRevokedSessions(data)
+	if err != nil {
+		return err
+	}
+	for namespace, nsRevoked := range revoked {
+		nsState := s.getNamespaceState(namespace, true)
+		for id, validUntil := range nsRevoked.Sessions {
+			nsState.revokeSession(id, time.Unix(validUntil, 0))
+		}
+		for commonName, before := range nsRevoked.CommonNames {
+			nsState.revokeClientSessions(commonName, time.Unix(before, 0))
+		}
+	}
+	return nil
+}
@@ -19,6 +19,7 @@ package remote
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -35,8 +36,13 @@ type namespaceState struct {
 	lock     sync.Mutex
 	name     string
 	handlers map[string]*handlerState
-	tokens   map[string]*tokenState
+	sessions map[string]*sessionState
 	zones    map[zoneid]zonehandler
+	watchers map[zoneid]map[chan struct{}]struct{}
+	// revoked contains the revoked session ids until the end of their validity
+	revoked map[string]time.Time
+	// revokedBefore contains the time of the last revocation of all sessions of a client certificate by common name
+	revokedBefore map[string]time.Time
 }
 
 type zonehandler struct {
@@ -51,16 +57,23 @@ type handlerState struct {
 	zones   atomic.Value
 }
 
-type tokenState struct {
+type sessionState struct {
+	id         string
 	clientID   string
+	commonName string
+	created    time.Time
 	validUntil time.Time
+	lastAccess time.Time
 }
 
 func newNamespaceState(namespace string) *namespaceState {
 	return &namespaceState{
-		name:     namespace,
-		handlers: map[string]*handlerState{},
-		tokens:   map[string]*tokenState{},
+		name:          namespace,
+		handlers:      map[string]*handlerState{},
+		sessions:      map[string]*sessionState{},
+		watchers:      map[zoneid]map[chan struct{}]struct{}{},
+		revoked:       map[string]time.Time{},
+		revokedBefore: map[string]time.Time{},
 	}
 }
 
@@ -126,44 +139,157 @@ func (s *namespaceState) _refreshZones() {
 	}
 }
 
-func (s *namespaceState) getToken(token string) (string, error) {
+// getSession returns the session of verified token claims.
+// Sessions unknown to this server instance (e.g. after a restart) are restored from the claims.
+func (s *namespaceState) getSession(claims *tokenClaims, now time.Time) (*sessionState, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	tstate := s.tokens[token]
-	if tstate == nil || time.Now().After(tstate.validUntil) {
-		if tstate != nil {
-			delete(s.tokens, token)
+	validUntil := time.Unix(claims.ValidUntil, 0)
+	if now.After(validUntil) {
+		delete(s.sessions, claims.SessionID)
+		return nil, fmt.Errorf("%s for namespace %s", common.InvalidToken, s.name)
+	}
+	if _, ok := s.revoked[claims.SessionID]; ok {
+		return nil, fmt.Errorf("%s for namespace %s: session revoked", common.InvalidToken, s.name)
+	}
+	created := time.Unix(claims.IssuedAt, 0)
+	if t, ok := s.revokedBefore[claims.CommonName]; ok && !created.After(t) {
+		return nil, fmt.Errorf("%s for namespace %s: session revoked", common.InvalidToken, s.name)
+	}
+	sstate := s.sessions[claims.SessionID]
+	if sstate == nil {
+		sstate = &sessionState{
+			id:         claims.SessionID,
+			clientID:   claims.ClientID,
+			commonName: claims.CommonName,
+			created:    created,
+			validUntil: validUntil,
+		}
+		s.sessions[claims.SessionID] = sstate
+	}
+	sstate.lastAccess = now
+	return sstate, nil
+}
+
+func (s *namespaceState) addSession(claims *tokenClaims) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.sessions[claims.SessionID] = &sessionState{
+		id:         claims.SessionID,
+		clientID:   claims.ClientID,
+		commonName: claims.CommonName,
+		created:    time.Unix(claims.IssuedAt, 0),
+		validUntil: time.Unix(claims.ValidUntil, 0),
+	}
+}
+
+// listSessions returns the active sessions, optionally restricted to a client.
+func (s *namespaceState) listSessions(clientID string) []*common.Session {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	result := []*common.Session{}
+	for _, sstate := range s.sessions {
+		if clientID != "" && sstate.clientID != clientID {
+			continue
 		}
-		return "", fmt.Errorf("%s for namespace %s", common.InvalidToken, s.name)
+		session := &common.Session{
+			Id:         sstate.id,
+			Namespace:  s.name,
+			ClientId:   sstate.clientID,
+			CommonName: sstate.commonName,
+			Created:    sstate.created.Unix(),
+			ValidUntil: sstate.validUntil.Unix(),
+		}
+		if !sstate.lastAccess.IsZero() {
+			session.LastAccess = sstate.lastAccess.Unix()
+		}
+		result = append(result, session)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Created < result[j].Created })
+	return result
+}
+
+// revokeSession revokes a single session until the end of its validity.
+func (s *namespaceState) revokeSession(sessionID string, validUntil time.Time) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	if sstate := s.sessions[sessionID]; sstate != nil {
+		validUntil = sstate.validUntil
+		delete(s.sessions, sessionID)
+		count++
+	}
+	if validUntil.After(s.revoked[sessionID]) {
+		s.revoked[sessionID] = validUntil
 	}
-	return tstate.clientID, nil
+	return count
 }
 
-func (s *namespaceState) generateAndAddToken(tokenTTL time.Duration, rnd, clientID, server string) string {
+// getClientCommonNames returns the common names of the client certificates used by the sessions of a client id.
+func (s *namespaceState) getClientCommonNames(clientID string) []string {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	validUntil := time.Now().Add(tokenTTL).UTC()
-	token := fmt.Sprintf("%s|%s|%s|%s|%s", s.name, clientID, validUntil.Format(time.RFC3339), server, rnd)
-	s.tokens[token] = &tokenState{
-		clientID:   clientID,
-		validUntil: validUntil,
+	names := map[string]struct{}{}
+	for _, sstate := range s.sessions {
+		if sstate.clientID == clientID {
+			names[sstate.commonName] = struct{}{}
+		}
 	}
-	return token
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
 }
 
-func (s *namespaceState) cleanupTokens(now time.Time) int {
+// revokeClientSessions revokes all sessions of a client certificate created up to the given time.
+// The client certificate is identified by its common name, as the client id is chosen by the client on login.
+func (s *namespaceState) revokeClientSessions(commonName string, before time.Time) int {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
 	count := 0
-	for token, data := range s.tokens {
-		if data.validUntil.Before(now) {
-			delete(s.tokens, token)
+	for id, sstate := range s.sessions {
+		if sstate.commonName == commonName && !sstate.created.After(before) {
+			delete(s.sessions, id)
 			count++
 		}
 	}
+	if before.After(s.revokedBefore[commonName]) {
+		s.revokedBefore[commonName] = before
+	}
+	return count
+}
+
+// cleanupSessions removes expired sessions and revocations.
+// Client revocations are kept for the token TTL to cover tokens unknown to this server instance.
+func (s *namespaceState) cleanupSessions(now time.Time, tokenTTL time.Duration) int {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	count := 0
+	for id, sstate := range s.sessions {
+		if sstate.validUntil.Before(now) {
+			delete(s.sessions, id)
+			count++
+		}
+	}
+	for id, validUntil := range s.revoked {
+		if validUntil.Before(now) {
+			delete(s.revoked, id)
+		}
+	}
+	for commonName, t := range s.revokedBefore {
+		if t.Add(tokenTTL).Before(now) {
+			delete(s.revokedBefore, commonName)
+		}
+	}
 	return count
 }
 
@@ -215,3 +341,37 @@ func (h *handlerState) getZones(spinning time.Duration) ([]provider.DNSHostedZon
 	h.zones.Store(zones)
 	return zones, err
 }
+
+func (s *namespaceState) addWatcher(zoneid string) chan struct{} {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	notify := make(chan struct{}, 1)
+	if s.watchers[zoneid] == nil {
+		s.watchers[zoneid] = map[chan struct{}]struct{}{}
+	}
+	s.watchers[zoneid][notify] = struct{}{}
+	return notify
+}
+
+func (s *namespaceState) removeWatcher(zoneid string, notify chan struct{}) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.watchers[zoneid], notify)
+	if len(s.watchers[zoneid]) == 0 {
+		delete(s.watchers, zoneid)
+	}
+}
+
+func (s *namespaceState) notifyWatchers(zoneid string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	for notify := range s.watchers[zoneid] {
+		select {
+		case notify <- struct{}{}:
+		default:
+		}
+	}
+}
@@ -0,0 +1,149 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// TokenSigningKey is the key of the token signing key in the token secret.
+	TokenSigningKey = "signing.key"
+	// PreviousTokenSigningKey is the optional key of the previous token signing key in the token secret.
+	// Tokens signed with it are still accepted to allow a key rotation without forcing all clients to login again.
+	PreviousTokenSigningKey = "previous-signing.key"
+
+	minSigningKeyLength = 32
+)
+
+// tokenClaims are the signed contents of a login token.
+type tokenClaims struct {
+	Namespace  string `json:"ns"`
+	ClientID   string `json:"cid"`
+	CommonName string `json:"cn"`
+	SessionID  string `json:"sid"`
+	IssuedAt   int64  `json:"iat"`
+	ValidUntil int64  `json:"exp"`
+}
+
+// tokenSigner creates and verifies HMAC signed login tokens of the form
+// `<namespace>|<base64 encoded claims>|<base64 encoded signature>`.
+type tokenSigner struct {
+	lock sync.RWMutex
+	// keys contains the signing key followed by keys only accepted for verification
+	keys [][]byte
+}
+
+func newTokenSigner() (*tokenSigner, error) {
+	key := make([]byte, minSigningKeyLength)
+	if _, err := rand.Read(key); err != nil {
+		return nil, err
+	}
+	return &tokenSigner{keys: [][]byte{key}}, nil
+}
+
+// updateKeys sets the keys from the token secret.
+func (t *tokenSigner) updateKeys(secret *corev1.Secret) error {
+	if secret == nil || secret.DeletionTimestamp != nil {
+		return fmt.Errorf("token secret not available, keeping current signing key")
+	}
+	key := secret.Data[TokenSigningKey]
+	if len(key) < minSigningKeyLength {
+		return fmt.Errorf("key %s in secret %s/%s must have at least %d bytes", TokenSigningKey, secret.Namespace, secret.Name, minSigningKeyLength)
+	}
+	keys := [][]byte{key}
+	if previous := secret.Data[PreviousTokenSigningKey]; len(previous) >= minSigningKeyLength {
+		keys = append(keys, previous)
+	}
+
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.keys = keys
+	return nil
+}
+
+func (t *tokenSigner) sign(claims *tokenClaims) (string, error) {
+	data, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	unsigned := claims.Namespace + "|" + base64.RawURLEncoding.EncodeToString(data)
+
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	return unsigned + "|" + base64.RawURLEncoding.EncodeToString(signature(t.keys[0], unsigned)), nil
+}
+
+// verify checks the signature of the token and returns its claims.
+// The expiration is checked by the caller.
+func (t *tokenSigner) verify(token string) (*tokenClaims, error) {
+	idx := strings.LastIndex(token, "|")
+	if idx < 0 {
+		return nil, fmt.Errorf("malformed token")
+	}
+	unsigned := token[:idx]
+	sig, err := base64.RawURLEncoding.DecodeString(token[idx+1:])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token signature")
+	}
+	if !t.validSignature(unsigned, sig) {
+		return nil, fmt.Errorf("invalid token signature")
+	}
+
+	parts := strings.SplitN(unsigned, "|", 2)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("malformed token")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed token claims")
+	}
+	claims := &tokenClaims{}
+	if err := json.Unmarshal(data, claims); err != nil {
+		return nil, fmt.Errorf("malformed token claims")
+	}
+	if claims.Namespace != parts[0] {
+		return nil, fmt.Errorf("token namespace mismatch")
+	}
+	return claims, nil
+}
+
+func (t *tokenSigner) validSignature(unsigned string, sig []byte) bool {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+	for _, key := range t.keys {
+		if hmac.Equal(sig, signature(key, unsigned)) {
+			return true
+		}
+	}
+	return false
+}
+
+func signature(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,211 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+	"github.com/gardener/external-dns-management/pkg/server/remote/embed"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func testClaims(sessionID string, now time.Time) *tokenClaims {
+	return &tokenClaims{
+		Namespace:  "ns1",
+		ClientID:   "client1",
+		CommonName: "ns1.my.client",
+		SessionID:  sessionID,
+		IssuedAt:   now.Unix(),
+		ValidUntil: now.Add(time.Hour).Unix(),
+	}
+}
+
+func TestTokenSigner(t *testing.T) {
+	signer, err := newTokenSigner()
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := testClaims("s1", time.Now())
+	token, err := signer.sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(token, "ns1|") {
+		t.Errorf("token must start with namespace: %s", token)
+	}
+	verified, err := signer.verify(token)
+	if err != nil {
+		t.Fatalf("verify failed: %s", err)
+	}
+	if *verified != *claims {
+		t.Errorf("claims mismatch: %#v != %#v", verified, claims)
+	}
+
+	if _, err := signer.verify("ns2" + strings.TrimPrefix(token, "ns1")); err == nil {
+		t.Errorf("expected error for modified namespace")
+	}
+	other, _ := newTokenSigner()
+	if _, err := other.verify(token); err == nil {
+		t.Errorf("expected error for token signed with other key")
+	}
+
+	// key rotation: tokens signed with the previous key are still accepted
+	key := bytes.Repeat([]byte("k"), minSigningKeyLength)
+	secret := &corev1.Secret{Data: map[string][]byte{TokenSigningKey: key, PreviousTokenSigningKey: signer.keys[0]}}
+	if err := other.updateKeys(secret); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := other.verify(token); err != nil {
+		t.Errorf("expected token signed with previous key to be valid: %s", err)
+	}
+	secret.Data[TokenSigningKey] = []byte("short")
+	if err := other.updateKeys(secret); err == nil {
+		t.Errorf("expected error for short signing key")
+	}
+}
+
+func TestSessionRevocation(t *testing.T) {
+	now := time.Now()
+	nsState := newNamespaceState("ns1")
+	c1 := testClaims("s1", now.Add(-time.Minute))
+	c2 := testClaims("s2", now.Add(-time.Minute))
+	nsState.addSession(c1)
+
+	// unknown sessions are restored from the claims
+	if _, err := nsState.getSession(c2, now); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if n := len(nsState.listSessions("client1")); n != 2 {
+		t.Errorf("expected 2 sessions, got %d", n)
+	}
+
+	if n := nsState.revokeSession("s1", now); n != 1 {
+		t.Errorf("expected 1 revoked session, got %d", n)
+	}
+	if _, err := nsState.getSession(c1, now); err == nil {
+		t.Errorf("expected revoked session to be invalid")
+	}
+
+	if names := nsState.getClientCommonNames("client1"); len(names) != 1 || names[0] != "ns1.my.client" {
+		t.Errorf("unexpected common names of client: %v", names)
+	}
+	if n := nsState.revokeClientSessions("ns1.my.client", now); n != 1 {
+		t.Errorf("expected 1 revoked session, got %d", n)
+	}
+	if _, err := nsState.getSession(c2, now); err == nil {
+		t.Errorf("expected session of revoked client to be invalid")
+	}
+	// the revocation applies to the client certificate, whatever client id has been chosen on login
+	c4 := testClaims("s4", now.Add(-time.Minute))
+	c4.ClientID = "other"
+	if _, err := nsState.getSession(c4, now); err == nil {
+		t.Errorf("expected session of revoked client certificate with other client id to be invalid")
+	}
+	if _, err := nsState.getSession(testClaims("s3", now.Add(time.Second)), now.Add(time.Second)); err != nil {
+		t.Errorf("expected new session after client revocation to be valid: %s", err)
+	}
+
+	if _, err := nsState.getSession(c1, now.Add(2*time.Hour)); err == nil {
+		t.Errorf("expected expired session to be invalid")
+	}
+}
+
+type testRevocationStore struct {
+	data []byte
+}
+
+func (s *testRevocationStore) ModifyRevokedSessions(modifier func(data []byte) ([]byte, error)) error {
+	data, err := modifier(s.data)
+	if err != nil {
+		return err
+	}
+	s.data = data
+	return nil
+}
+
+func TestStoredSessionRevocation(t *testing.T) {
+	now := time.Now()
+	store := &testRevocationStore{}
+	s1 := newServer(logger.New())
+	s1.SetSessionRevocationStore(store)
+
+	expired := &namespaceRevocations{Sessions: map[string]int64{"old": now.Add(-time.Minute).Unix()}}
+	if err := s1.storeRevocations("ns1", expired, now); err != nil {
+		t.Fatal(err)
+	}
+	revocations := &namespaceRevocations{
+		Sessions:    map[string]int64{"s1": now.Add(time.Hour).Unix()},
+		CommonNames: map[string]int64{"ns1.other.client": now.Unix()},
+	}
+	if err := s1.storeRevocations("ns1", revocations, now); err != nil {
+		t.Fatal(err)
+	}
+	revoked, err := parseRevokedSessions(store.data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := revoked["ns1"].Sessions["old"]; ok {
+		t.Errorf("expected expired revocation to be dropped")
+	}
+
+	// revocations are applied after a restart from the token secret
+	s2 := newServer(logger.New())
+	key := bytes.Repeat([]byte("k"), minSigningKeyLength)
+	s2.UpdateTokenSigningKey(&corev1.Secret{Data: map[string][]byte{TokenSigningKey: key, embed.RevokedSessionsKey: store.data}})
+	nsState := s2.getNamespaceState("ns1", true)
+	if _, err := nsState.getSession(testClaims("s1", now.Add(-time.Minute)), now); err == nil {
+		t.Errorf("expected stored revoked session to be invalid")
+	}
+	c2 := testClaims("s2", now.Add(-time.Minute))
+	c2.CommonName = "ns1.other.client"
+	if _, err := nsState.getSession(c2, now); err == nil {
+		t.Errorf("expected session of stored revoked client to be invalid")
+	}
+	if _, err := nsState.getSession(testClaims("s3", now.Add(-time.Minute)), now); err != nil {
+		t.Errorf("expected session not revoked to be valid: %s", err)
+	}
+}
+
+func TestStoredLogout(t *testing.T) {
+	now := time.Now()
+	store := &testRevocationStore{}
+	s1 := newServer(logger.New())
+	s1.SetSessionRevocationStore(store)
+	claims := testClaims("s1", now.Add(-time.Minute))
+	token, err := s1.tokens.sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s1.getNamespaceState("ns1", true).addSession(claims)
+	if _, err := s1.Logout(context.Background(), &common.LogoutRequest{Token: token}); err != nil {
+		t.Fatal(err)
+	}
+
+	// the logout is effective after a restart or on another replica
+	s2 := newServer(logger.New())
+	key := bytes.Repeat([]byte("k"), minSigningKeyLength)
+	s2.UpdateTokenSigningKey(&corev1.Secret{Data: map[string][]byte{TokenSigningKey: key, embed.RevokedSessionsKey: store.data}})
+	if _, err := s2.getNamespaceState("ns1", true).getSession(claims, now); err == nil {
+		t.Errorf("expected session of logged out token to be invalid")
+	}
+}
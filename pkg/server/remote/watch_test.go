@@ -0,0 +1,169 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ * limitations under the License.
+ *
+ */
+
+package remote
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gardener/controller-manager-library/pkg/logger"
+
+	"github.com/gardener/external-dns-management/pkg/dns"
+	"github.com/gardener/external-dns-management/pkg/dns/provider"
+	"github.com/gardener/external-dns-management/pkg/server/remote/common"
+)
+
+type watchTestHandler struct {
+	lock sync.Mutex
+	zone provider.DNSHostedZone
+	sets dns.DNSSets
+}
+
+var _ provider.LightDNSHandler = &watchTestHandler{}
+
+func (h *watchTestHandler) ProviderType() string {
+	return "mock"
+}
+
+func (h *watchTestHandler) GetZones() (provider.DNSHostedZones, error) {
+	return provider.DNSHostedZones{h.zone}, nil
+}
+
+func (h *watchTestHandler) GetZoneState(provider.DNSHostedZone) (provider.DNSZoneState, error) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	return provider.NewDNSZoneState(h.sets.Clone()), nil
+}
+
+func (h *watchTestHandler) ExecuteRequests(logger.LogContext, provider.DNSHostedZone, provider.DNSZoneState, []*provider.ChangeRequest) error {
+	return nil
+}
+
+func (h *watchTestHandler) addRecord(name, value string) {
+	h.lock.Lock()
+	defer h.lock.Unlock()
+	h.sets.AddRecordSetFromProvider(name, dns.NewRecordSet(dns.RS_A, 300, []*dns.Record{{Value: value}}))
+}
+
+func TestWatchZone(t *testing.T) {
+	log := logger.New()
+	s := newServer(log)
+	s.watchCheckPeriod = time.Hour
+	policy, err := ParseAuthorizationPolicy([]byte(testPolicy))
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.policy = policy
+
+	handler := &watchTestHandler{
+		zone: provider.NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+		sets: dns.DNSSets{},
+	}
+	handler.addRecord("a.team-a.example.com", "1.1.1.1")
+	nsState := s.getNamespaceState("ns1", true)
+	nsState.updateHandler(log, "p1", handler)
+
+	claims := testClaims("s1", time.Now())
+	claims.CommonName = "team-a.my.client"
+	token, err := s.tokens.sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nsState.addSession(claims)
+
+	updates := make(chan *common.ZoneUpdate, 10)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- s.watchZone(ctx, nsState, log, token, "Z1", func(update *common.ZoneUpdate) error {
+			updates <- update
+			return nil
+		})
+	}()
+
+	next := func() *common.ZoneUpdate {
+		select {
+		case update := <-updates:
+			return update
+		case <-time.After(5 * time.Second):
+			t.Fatalf("missing zone update")
+			return nil
+		}
+	}
+
+	if update := next(); update.Snapshot == nil || len(update.Snapshot.DnsSets) != 1 {
+		t.Errorf("expected snapshot with 1 DNSSet, got %v", update)
+	}
+
+	// changes are only reported after a notification by the zone cache
+	handler.addRecord("b.team-a.example.com", "2.2.2.2")
+	select {
+	case update := <-updates:
+		t.Errorf("unexpected update without notification: %v", update)
+	case <-time.After(100 * time.Millisecond):
+	}
+	s.ZoneStateChanged("Z2")
+	s.ZoneStateChanged("Z1")
+	if update := next(); len(update.Change) != 1 || update.Change[0].DnsName != "b.team-a.example.com" {
+		t.Errorf("expected change of b.team-a.example.com, got %v", update)
+	}
+
+	// notifications without changes are not reported
+	s.ZoneStateChanged("Z1")
+	select {
+	case update := <-updates:
+		t.Errorf("unexpected update without change: %v", update)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	cancel()
+	if err := <-done; err != nil {
+		t.Errorf("unexpected error: %s", err)
+	}
+}
+
+func TestGetZoneStateForNames(t *testing.T) {
+	log := logger.New()
+	s := newServer(log)
+	handler := &watchTestHandler{
+		zone: provider.NewDNSHostedZone("mock", "Z1", "example.com", "", nil, false),
+		sets: dns.DNSSets{},
+	}
+	handler.addRecord("a.example.com", "1.1.1.1")
+	handler.addRecord("b.example.com", "2.2.2.2")
+	nsState := s.getNamespaceState("ns1", true)
+	nsState.updateHandler(log, "p1", handler)
+
+	state, err := s.getZoneState(nsState, nil, log, "Z1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.DnsSets) != 2 {
+		t.Errorf("expected full zone state with 2 DNSSets, got %v", state.DnsSets)
+	}
+
+	state, err = s.getZoneState(nsState, nil, log, "Z1", []string{"b.example.com", "c.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.DnsSets) != 1 || state.DnsSets["b.example.com"] == nil {
+		t.Errorf("expected partial zone state with b.example.com, got %v", state.DnsSets)
+	}
+}
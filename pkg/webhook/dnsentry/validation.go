@@ -0,0 +1,145 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsentry
+
+import (
+	"fmt"
+	"strings"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func normalize(name string) string {
+	return strings.ToLower(dns.NormalizeHostname(name))
+}
+
+// FindConflicts returns the other entries claiming the same DNS name with the same owner id.
+// Entries contributing to a shared record set only conflict with entries not in shared mode.
+func FindConflicts(entry *api.DNSEntry, others []*api.DNSEntry) []string {
+	var conflicts []string
+	name := normalize(entry.Spec.DNSName)
+	owner := ownerID(entry)
+	for _, other := range others {
+		if other.Namespace == entry.Namespace && other.Name == entry.Name {
+			continue
+		}
+		if other.DeletionTimestamp != nil {
+			continue
+		}
+		if isShared(entry) && isShared(other) {
+			continue
+		}
+		if normalize(other.Spec.DNSName) == name && ownerID(other) == owner {
+			conflicts = append(conflicts, fmt.Sprintf("%s/%s", other.Namespace, other.Name))
+		}
+	}
+	return conflicts
+}
+
+// ClaimChanged returns true if an update changes the claimed DNS name, the owner id or the shared mode of an entry.
+func ClaimChanged(old, entry *api.DNSEntry) bool {
+	return normalize(old.Spec.DNSName) != normalize(entry.Spec.DNSName) ||
+		ownerID(old) != ownerID(entry) || isShared(old) != isShared(entry)
+}
+
+func isShared(entry *api.DNSEntry) bool {
+	return entry.Spec.Shared != nil && *entry.Spec.Shared
+}
+
+func ownerID(entry *api.DNSEntry) string {
+	if entry.Spec.OwnerId == nil {
+		return ""
+	}
+	return *entry.Spec.OwnerId
+}
+
+// SelectableProviders returns the providers matching the provider reference and
+// the provider selector of an entry.
+func SelectableProviders(entry *api.DNSEntry, providers []*api.DNSProvider) []*api.DNSProvider {
+	spec := &entry.Spec
+	if spec.ProviderRef == nil && spec.ProviderSelector == nil {
+		return providers
+	}
+	var selector labels.Selector
+	if spec.ProviderSelector != nil {
+		var err error
+		if selector, err = metav1.LabelSelectorAsSelector(spec.ProviderSelector); err != nil {
+			return nil
+		}
+	}
+	var result []*api.DNSProvider
+	for _, p := range providers {
+		if ref := spec.ProviderRef; ref != nil {
+			namespace := ref.Namespace
+			if namespace == "" {
+				namespace = entry.Namespace
+			}
+			if p.Name != ref.Name || p.Namespace != namespace {
+				continue
+			}
+		}
+		if selector != nil && !selector.Matches(labels.Set(p.Labels)) {
+			continue
+		}
+		result = append(result, p)
+	}
+	return result
+}
+
+// ResponsibleProvider returns the provider with the most specific included domain of the DNS name
+// and warnings about providers excluding the DNS name explicitly.
+// Only ready providers are considered, as the served domains are taken from the provider status.
+func ResponsibleProvider(dnsName string, providers []*api.DNSProvider) (*api.DNSProvider, []string) {
+	var (
+		found    *api.DNSProvider
+		match    string
+		warnings []string
+	)
+	name := normalize(dnsName)
+	for _, p := range providers {
+		if p.Status.State != api.STATE_READY || p.DeletionTimestamp != nil {
+			continue
+		}
+		included := longestMatch(name, p.Status.Domains.Included)
+		if included == "" {
+			continue
+		}
+		if excluded := longestMatch(name, p.Status.Domains.Excluded); len(excluded) > len(included) {
+			warnings = append(warnings, fmt.Sprintf("dns name %s is excluded by domain %s of provider %s/%s", dnsName, excluded, p.Namespace, p.Name))
+			continue
+		}
+		if len(included) > len(match) {
+			found = p
+			match = included
+		}
+	}
+	return found, warnings
+}
+
+func longestMatch(name string, domains []string) string {
+	match := ""
+	for _, d := range domains {
+		d = normalize(d)
+		if (name == d || strings.HasSuffix(name, "."+d)) && len(d) > len(match) {
+			match = d
+		}
+	}
+	return match
+}
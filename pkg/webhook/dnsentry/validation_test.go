@@ -0,0 +1,123 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsentry
+
+import (
+	"testing"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func testEntry(name, dnsName string, ownerID *string, targets ...string) *api.DNSEntry {
+	return &api.DNSEntry{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name},
+		Spec:       api.DNSEntrySpec{DNSName: dnsName, OwnerId: ownerID, Targets: targets},
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	owner := "owner1"
+	entry := testEntry("e1", "A.example.com", nil, "1.2.3.4")
+	others := []*api.DNSEntry{
+		testEntry("e1", "a.example.com", nil, "1.2.3.4"),
+		testEntry("e2", "a.example.com.", nil, "1.2.3.5"),
+		testEntry("e3", "a.example.com", &owner, "1.2.3.6"),
+		testEntry("e4", "b.example.com", nil, "1.2.3.7"),
+	}
+	conflicts := FindConflicts(entry, others)
+	if len(conflicts) != 1 || conflicts[0] != "default/e2" {
+		t.Errorf("unexpected conflicts %v", conflicts)
+	}
+
+	shared := true
+	entry.Spec.Shared = &shared
+	others[1].Spec.Shared = &shared
+	if conflicts := FindConflicts(entry, others); len(conflicts) != 0 {
+		t.Errorf("unexpected conflicts for shared entries %v", conflicts)
+	}
+	others[1].Spec.Shared = nil
+	if conflicts := FindConflicts(entry, others); len(conflicts) != 1 {
+		t.Errorf("expected conflict of shared with exclusive entry, got %v", conflicts)
+	}
+}
+
+func TestClaimChanged(t *testing.T) {
+	owner := "owner1"
+	shared := true
+	old := testEntry("e1", "a.example.com", nil, "1.2.3.4")
+	targets := testEntry("e1", "A.example.com.", nil, "1.2.3.5")
+	name := testEntry("e1", "b.example.com", nil, "1.2.3.4")
+	ownerChanged := testEntry("e1", "a.example.com", &owner, "1.2.3.4")
+	sharedChanged := testEntry("e1", "a.example.com", nil, "1.2.3.4")
+	sharedChanged.Spec.Shared = &shared
+
+	if ClaimChanged(old, targets) {
+		t.Errorf("expected unchanged claim for changed targets")
+	}
+	for i, entry := range []*api.DNSEntry{name, ownerChanged, sharedChanged} {
+		if !ClaimChanged(old, entry) {
+			t.Errorf("case %d: expected changed claim", i)
+		}
+	}
+}
+
+func TestResponsibleProvider(t *testing.T) {
+	provider := func(name, state string, included []string, excluded ...string) *api.DNSProvider {
+		p := &api.DNSProvider{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: name}}
+		p.Status.State = state
+		p.Status.Domains.Included = included
+		p.Status.Domains.Excluded = excluded
+		return p
+	}
+	providers := []*api.DNSProvider{
+		provider("p1", api.STATE_READY, []string{"example.com"}),
+		provider("p2", api.STATE_READY, []string{"sub.example.com"}),
+		provider("p3", api.STATE_ERROR, []string{"a.sub.example.com"}),
+		provider("p4", api.STATE_READY, []string{"example.com"}, "other.example.com"),
+	}
+
+	if p, _ := ResponsibleProvider("a.sub.example.com", providers); p == nil || p.Name != "p2" {
+		t.Errorf("expected provider p2, got %v", p)
+	}
+	p, warnings := ResponsibleProvider("x.other.example.com", providers)
+	if p == nil || p.Name != "p1" {
+		t.Errorf("expected provider p1, got %v", p)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("expected warning for excluded domain, got %v", warnings)
+	}
+	if p, _ := ResponsibleProvider("a.example.org", providers); p != nil {
+		t.Errorf("expected no provider, got %s", p.Name)
+	}
+
+	providers[0].Labels = map[string]string{"tier": "public"}
+	entry := testEntry("e1", "a.sub.example.com", nil, "1.2.3.4")
+	entry.Spec.ProviderSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "public"}}
+	if p, _ := ResponsibleProvider(entry.Spec.DNSName, SelectableProviders(entry, providers)); p == nil || p.Name != "p1" {
+		t.Errorf("expected selected provider p1, got %v", p)
+	}
+	entry.Spec.ProviderSelector = nil
+	entry.Spec.ProviderRef = &api.ProviderReference{Name: "p4"}
+	if p, _ := ResponsibleProvider(entry.Spec.DNSName, SelectableProviders(entry, providers)); p == nil || p.Name != "p4" {
+		t.Errorf("expected referenced provider p4, got %v", p)
+	}
+	entry.Spec.ProviderRef = &api.ProviderReference{Namespace: "other", Name: "p4"}
+	if p, _ := ResponsibleProvider(entry.Spec.DNSName, SelectableProviders(entry, providers)); p != nil {
+		t.Errorf("expected no provider, got %s", p.Name)
+	}
+}
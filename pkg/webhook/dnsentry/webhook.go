@@ -0,0 +1,189 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsentry
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook/admission"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	adminreg "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/labels"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/dns"
+	dnsprovider "github.com/gardener/external-dns-management/pkg/dns/provider"
+)
+
+const (
+	// WEBHOOK_NAME is the name of the validating webhook for DNSEntries.
+	WEBHOOK_NAME = "validate.dnsentries.dns.gardener.cloud"
+
+	// OPT_CLASS is the option for the dns classes handled by the webhook.
+	OPT_CLASS = "dns-class"
+)
+
+func init() {
+	webhook.Configure(WEBHOOK_NAME).
+		Kind(admission.Validating(NewHandler).Operation(adminreg.Create, adminreg.Update)).
+		Cluster(webhook.CLUSTER_MAIN).
+		Resource(api.GroupName, api.DNSEntryKind).
+		DefaultedStringOption(OPT_CLASS, dns.DEFAULT_CLASS, "Class identifier of the DNSEntries and DNSProviders validated by the webhook").
+		MustRegister()
+}
+
+type handler struct {
+	classes   *controller.Classes
+	decoder   *admission.Decoder
+	entries   resources.Interface
+	providers resources.Interface
+}
+
+var _ admission.Interface = &handler{}
+
+// NewHandler creates the admission handler validating DNSEntries.
+func NewHandler(wh webhook.Interface) (admission.Interface, error) {
+	class, _ := wh.GetStringOption(OPT_CLASS)
+	classes := controller.NewClasses(wh, class, dns.CLASS_ANNOTATION, dns.DEFAULT_CLASS)
+
+	resc := wh.GetCluster().Resources()
+	entries, err := resc.Get(&api.DNSEntry{})
+	if err != nil {
+		return nil, err
+	}
+	providers, err := resc.Get(&api.DNSProvider{})
+	if err != nil {
+		return nil, err
+	}
+	return &handler{
+		classes:   classes,
+		decoder:   admission.NewDecoder(wh.GetScheme()),
+		entries:   entries,
+		providers: providers,
+	}, nil
+}
+
+func (h *handler) Handle(logger logger.LogContext, req admission.Request) admission.Response {
+	entry := &api.DNSEntry{}
+	if err := h.decoder.Decode(req, entry); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	if !h.isResponsibleFor(entry) {
+		return admission.Allowed("dns class not handled")
+	}
+	if req.Namespace != "" {
+		entry.Namespace = req.Namespace
+	}
+
+	var old *api.DNSEntry
+	if req.Operation == admissionv1beta1.Update {
+		old = &api.DNSEntry{}
+		if err := h.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		// metadata updates like the removal of the finalizer of a deleted entry must not be blocked
+		if entry.DeletionTimestamp != nil || equality.Semantic.DeepEqual(old.Spec, entry.Spec) {
+			return admission.Allowed("spec unchanged")
+		}
+	}
+
+	if errs := dnsprovider.ValidateEntry(entry); len(errs) > 0 {
+		logger.Infof("rejecting entry %s/%s: %s", entry.Namespace, entry.Name, strings.Join(errs, ", "))
+		return denied("invalid DNSEntry: %s", strings.Join(errs, ", "))
+	}
+	if entry.Spec.Reference != nil {
+		return admission.Allowed("entry reference")
+	}
+
+	others, err := h.listEntries()
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	if old != nil && !ClaimChanged(old, entry) {
+		// existing duplicates are reported by the controller, they must not block updates of the targets
+		others = nil
+	}
+	if conflicts := FindConflicts(entry, others); len(conflicts) > 0 {
+		logger.Infof("rejecting entry %s/%s: dns name %s already claimed by %s", entry.Namespace, entry.Name, entry.Spec.DNSName, strings.Join(conflicts, ", "))
+		return denied("dns name %s with owner id %q already claimed by DNSEntry %s",
+			entry.Spec.DNSName, ownerID(entry), strings.Join(conflicts, ", "))
+	}
+
+	providers, err := h.listProviders()
+	if err != nil {
+		return admission.ErrorResponse(http.StatusInternalServerError, err)
+	}
+	provider, warnings := ResponsibleProvider(entry.Spec.DNSName, SelectableProviders(entry, providers))
+	resp := admission.Allowed("valid")
+	if provider == nil {
+		warnings = append(warnings, fmt.Sprintf("no responsible DNSProvider found for dns name %s", entry.Spec.DNSName))
+	} else {
+		resp.Result.Message = fmt.Sprintf("responsible DNSProvider %s/%s", provider.Namespace, provider.Name)
+	}
+	resp.Warnings = warnings
+	return resp
+}
+
+// denied rejects a request reporting the reason as status message to the client.
+func denied(msgfmt string, args ...interface{}) admission.Response {
+	resp := admission.Denied("")
+	resp.Result.Message = fmt.Sprintf(msgfmt, args...)
+	return resp
+}
+
+func (h *handler) isResponsibleFor(obj *api.DNSEntry) bool {
+	class := obj.GetAnnotations()[dns.CLASS_ANNOTATION]
+	if class == "" {
+		class = h.classes.Default()
+	}
+	return h.classes.Contains(class)
+}
+
+func (h *handler) listEntries() ([]*api.DNSEntry, error) {
+	objs, err := h.entries.ListCached(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var result []*api.DNSEntry
+	for _, o := range objs {
+		if h.classes.IsResponsibleFor(nil, o) {
+			result = append(result, o.Data().(*api.DNSEntry))
+		}
+	}
+	return result, nil
+}
+
+func (h *handler) listProviders() ([]*api.DNSProvider, error) {
+	objs, err := h.providers.ListCached(labels.Everything())
+	if err != nil {
+		return nil, err
+	}
+	var result []*api.DNSProvider
+	for _, o := range objs {
+		if h.classes.IsResponsibleFor(nil, o) {
+			result = append(result, o.Data().(*api.DNSProvider))
+		}
+	}
+	return result, nil
+}
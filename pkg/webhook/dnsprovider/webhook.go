@@ -0,0 +1,138 @@
+/*
+ * Copyright 2022 SAP SE or an SAP affiliate company. All rights reserved. This file is licensed under the Apache Software License, v. 2 except as noted otherwise in the LICENSE file
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License");
+ * you may not use this file except in compliance with the License.
+ * You may obtain a copy of the License at
+ *
+ *      http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software
+ * distributed under the License is distributed on an "AS IS" BASIS,
+ * WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+ * See the License for the specific language governing permissions and
+ *
+ */
+
+package dnsprovider
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/controller"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook"
+	"github.com/gardener/controller-manager-library/pkg/controllermanager/webhook/admission"
+	"github.com/gardener/controller-manager-library/pkg/logger"
+	"github.com/gardener/controller-manager-library/pkg/resources"
+	admissionv1beta1 "k8s.io/api/admission/v1beta1"
+	adminreg "k8s.io/api/admissionregistration/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+
+	api "github.com/gardener/external-dns-management/pkg/apis/dns/v1alpha1"
+	"github.com/gardener/external-dns-management/pkg/controller/provider/compound"
+	"github.com/gardener/external-dns-management/pkg/dns"
+)
+
+const (
+	// WEBHOOK_NAME is the name of the validating webhook for DNSProviders.
+	WEBHOOK_NAME = "validate.dnsproviders.dns.gardener.cloud"
+
+	// OPT_CLASS is the option for the dns classes handled by the webhook.
+	OPT_CLASS = "dns-class"
+)
+
+func init() {
+	webhook.Configure(WEBHOOK_NAME).
+		Kind(admission.Validating(NewHandler).Operation(adminreg.Create, adminreg.Update)).
+		Cluster(webhook.CLUSTER_MAIN).
+		Resource(api.GroupName, api.DNSProviderKind).
+		DefaultedStringOption(OPT_CLASS, dns.DEFAULT_CLASS, "Class identifier of the DNSProviders validated by the webhook").
+		MustRegister()
+}
+
+type handler struct {
+	cluster resources.Cluster
+	classes *controller.Classes
+	decoder *admission.Decoder
+}
+
+var _ admission.Interface = &handler{}
+
+// NewHandler creates the admission handler validating the provider config and the credentials of DNSProviders
+// using the schemas of the provider types registered at the compound factory.
+func NewHandler(wh webhook.Interface) (admission.Interface, error) {
+	class, _ := wh.GetStringOption(OPT_CLASS)
+	return &handler{
+		cluster: wh.GetCluster(),
+		classes: controller.NewClasses(wh, class, dns.CLASS_ANNOTATION, dns.DEFAULT_CLASS),
+		decoder: admission.NewDecoder(wh.GetScheme()),
+	}, nil
+}
+
+func (h *handler) Handle(logger logger.LogContext, req admission.Request) admission.Response {
+	provider := &api.DNSProvider{}
+	if err := h.decoder.Decode(req, provider); err != nil {
+		return admission.ErrorResponse(http.StatusBadRequest, err)
+	}
+	class := provider.GetAnnotations()[dns.CLASS_ANNOTATION]
+	if class == "" {
+		class = h.classes.Default()
+	}
+	if !h.classes.Contains(class) {
+		return admission.Allowed("dns class not handled")
+	}
+	if req.Namespace != "" {
+		provider.Namespace = req.Namespace
+	}
+	if req.Operation == admissionv1beta1.Update {
+		old := &api.DNSProvider{}
+		if err := h.decoder.DecodeRaw(req.OldObject, old); err != nil {
+			return admission.ErrorResponse(http.StatusBadRequest, err)
+		}
+		// metadata updates like the removal of the finalizer of a deleted provider must not be blocked
+		if provider.DeletionTimestamp != nil || equality.Semantic.DeepEqual(old.Spec, provider.Spec) {
+			return admission.Allowed("spec unchanged")
+		}
+	}
+
+	typecode := provider.Spec.Type
+	if !compound.Factory.TypeCodes().Contains(typecode) {
+		resp := admission.Allowed("unknown provider type")
+		resp.Warnings = []string{fmt.Sprintf("provider type %q not supported by the dns controller manager", typecode)}
+		return resp
+	}
+	if provider.Spec.SecretRef == nil {
+		return denied("no secret specified")
+	}
+
+	var warnings []string
+	ref := *provider.Spec.SecretRef
+	if ref.Namespace == "" {
+		ref.Namespace = provider.Namespace
+	}
+	props, _, err := resources.GetSecretPropertiesByRef(h.cluster, &ref)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return admission.ErrorResponse(http.StatusInternalServerError, err)
+		}
+		warnings = append(warnings, fmt.Sprintf("secret %s/%s not found, credentials not validated", ref.Namespace, ref.Name))
+	}
+
+	configWarnings, err := compound.Factory.ValidateProviderConfig(typecode, provider.Spec.ProviderConfig, props)
+	if err != nil {
+		logger.Infof("rejecting provider %s/%s: %s", provider.Namespace, provider.Name, err)
+		return denied("invalid provider configuration: %s", err)
+	}
+	resp := admission.Allowed("valid")
+	resp.Warnings = append(warnings, configWarnings...)
+	return resp
+}
+
+// denied rejects a request reporting the reason as status message to the client.
+func denied(msgfmt string, args ...interface{}) admission.Response {
+	resp := admission.Denied("")
+	resp.Result.Message = fmt.Sprintf(msgfmt, args...)
+	return resp
+}
@@ -63,7 +63,7 @@ func newCertFileAndSecret(te *TestEnv) (*certFileAndSecret, error) {
 	}
 
 	serverData, err := remoteaccesscertificates.CreateCertificate(caCert, caKey, remoteaccesscertificates.CreateSubject("server"),
-		"server.local", 1, 2, true)
+		"server.local", 1, remoteaccesscertificates.KeySpec{Type: v1alpha1.RSAKeyType, Size: 2048}, big.NewInt(2), true)
 	if err != nil {
 		return nil, err
 	}
@@ -187,7 +187,7 @@ var _ = Describe("RemoteAccess", func() {
 
 func prepareRemoteAccessClientSecret(index int, remoteNamespace, namespace string, days int) (*corev1.Secret, error) {
 	clientData, err := remoteaccesscertificates.CreateCertificate(caCert, caKey, remoteaccesscertificates.CreateSubject(remoteNamespace+".client.local"),
-		"client.local", days, int64(3+index), false)
+		"client.local", days, remoteaccesscertificates.KeySpec{Type: v1alpha1.RSAKeyType, Size: 2048}, big.NewInt(int64(3+index)), false)
 	if err != nil {
 		return nil, err
 	}